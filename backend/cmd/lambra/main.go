@@ -0,0 +1,11 @@
+package main
+
+import (
+	"os"
+
+	"github.com/yourusername/lambra/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
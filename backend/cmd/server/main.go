@@ -28,7 +28,10 @@ func main() {
 	log.Println("Database connection established")
 
 	// Setup router
-	r := router.Setup(db)
+	r, err := router.Setup(db, cfg)
+	if err != nil {
+		log.Fatalf("Failed to setup router: %v", err)
+	}
 
 	// Start server
 	addr := fmt.Sprintf(":%s", cfg.Server.Port)
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+type AuditHandler struct {
+	service *service.AuditService
+}
+
+func NewAuditHandler(service *service.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// GetProjectLogs lists the audit trail of a project
+// GET /api/v1/projects/:id/audit-logs?resource_type=entity&action=update&actor=alice&since=2024-01-01T00:00:00Z
+func (h *AuditHandler) GetProjectLogs(c *gin.Context) {
+	projectID := c.Param("id")
+	if projectID == "" {
+		response.BadRequest(c, "Invalid project ID", nil)
+		return
+	}
+
+	filter, ok := parseAuditFilter(c)
+	if !ok {
+		return
+	}
+	filter.ResourceType = c.Query("resource_type")
+	filter.ResourceID = c.Query("resource_id")
+
+	page, limit := parsePagination(c)
+
+	logs, total, err := h.service.GetProjectLogs(c.Request.Context(), projectID, filter, page, limit)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve audit logs")
+		return
+	}
+
+	response.SuccessWithPagination(c, logs, newPagination(page, limit, total), "Audit logs retrieved successfully")
+}
+
+// GetResourceLogs lists the audit trail of a single project, entity, endpoint or template
+// GET /api/v1/audit-logs/:resource_type/:resource_id
+func (h *AuditHandler) GetResourceLogs(c *gin.Context) {
+	resourceType := c.Param("resource_type")
+	switch resourceType {
+	case models.AuditResourceProject, models.AuditResourceEntity, models.AuditResourceEndpoint, models.AuditResourceTemplate:
+	default:
+		response.BadRequest(c, "Invalid resource type", nil)
+		return
+	}
+
+	filter, ok := parseAuditFilter(c)
+	if !ok {
+		return
+	}
+
+	page, limit := parsePagination(c)
+
+	logs, total, err := h.service.GetResourceLogs(c.Request.Context(), resourceType, c.Param("resource_id"), filter, page, limit)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve audit logs")
+		return
+	}
+
+	response.SuccessWithPagination(c, logs, newPagination(page, limit, total), "Audit logs retrieved successfully")
+}
+
+// parseAuditFilter reads the action, actor and RFC 3339 since/until query params, responding 400 on bad times
+func parseAuditFilter(c *gin.Context) (*models.AuditLogFilter, bool) {
+	filter := &models.AuditLogFilter{
+		Action: c.Query("action"),
+		Actor:  c.Query("actor"),
+	}
+
+	for param, target := range map[string]**time.Time{"since": &filter.Since, "until": &filter.Until} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			response.BadRequest(c, "Invalid "+param+" time, expected RFC 3339", err)
+			return nil, false
+		}
+		*target = &t
+	}
+
+	return filter, true
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+type AuthHandler struct {
+	service *service.AuthService
+}
+
+func NewAuthHandler(service *service.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// Register creates a user account
+// POST /api/v1/auth/register
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req models.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	user, err := h.service.Register(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err, "Failed to register user")
+		return
+	}
+
+	response.Created(c, user, "User registered successfully")
+}
+
+// Login exchanges a username (or email) and password for a JWT
+// POST /api/v1/auth/login
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req models.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	login, err := h.service.Login(&req)
+	if err != nil {
+		respondError(c, err, "Failed to log in")
+		return
+	}
+
+	response.Success(c, login, "Logged in successfully")
+}
+
+// Me returns the authenticated user
+// GET /api/v1/auth/me
+func (h *AuthHandler) Me(c *gin.Context) {
+	user, ok := auth.UserFromContext(c.Request.Context())
+	if !ok {
+		response.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	response.Success(c, user, "User retrieved successfully")
+}
+
+// CreateAPIToken creates a long-lived API token, the token is only returned in this response
+// POST /api/v1/auth/tokens
+func (h *AuthHandler) CreateAPIToken(c *gin.Context) {
+	var req models.CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	token, err := h.service.CreateAPIToken(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err, "Failed to create API token")
+		return
+	}
+
+	response.Created(c, token, "API token created successfully")
+}
+
+// GetAPITokens lists the API tokens of the authenticated user
+// GET /api/v1/auth/tokens
+func (h *AuthHandler) GetAPITokens(c *gin.Context) {
+	tokens, err := h.service.GetAPITokens(c.Request.Context())
+	if err != nil {
+		respondError(c, err, "Failed to retrieve API tokens")
+		return
+	}
+
+	page, limit := parsePagination(c)
+	items, total := pageOf(tokens, page, limit)
+	response.SuccessWithPagination(c, items, newPagination(page, limit, total), "API tokens retrieved successfully")
+}
+
+// RevokeAPIToken revokes an API token of the authenticated user
+// DELETE /api/v1/auth/tokens/:id
+func (h *AuthHandler) RevokeAPIToken(c *gin.Context) {
+	err := h.service.RevokeAPIToken(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to revoke API token")
+		return
+	}
+
+	response.Success(c, nil, "API token revoked successfully")
+}
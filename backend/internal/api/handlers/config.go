@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+type ConfigHandler struct {
+	service *service.ConfigService
+}
+
+func NewConfigHandler(service *service.ConfigService) *ConfigHandler {
+	return &ConfigHandler{service: service}
+}
+
+// GetVariables lists the configuration of a project environment, secret values are masked
+// GET /api/v1/projects/:id/environments/:env/config
+func (h *ConfigHandler) GetVariables(c *gin.Context) {
+	variables, err := h.service.GetVariables(c.Request.Context(), c.Param("id"), c.Param("env"))
+	if err != nil {
+		respondError(c, err, "Failed to retrieve config variables")
+		return
+	}
+
+	page, limit := parsePagination(c)
+	items, total := pageOf(variables, page, limit)
+	response.SuccessWithPagination(c, items, newPagination(page, limit, total), "Config variables retrieved successfully")
+}
+
+// SetVariable creates or replaces a variable or secret of a project environment
+// PUT /api/v1/projects/:id/environments/:env/config/:name
+func (h *ConfigHandler) SetVariable(c *gin.Context) {
+	var req models.SetConfigVarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	variable, err := h.service.SetVariable(c.Request.Context(), c.Param("id"), c.Param("env"), c.Param("name"), &req)
+	if err != nil {
+		respondError(c, err, "Failed to save config variable")
+		return
+	}
+
+	response.Success(c, variable, "Config variable saved successfully")
+}
+
+// DeleteVariable deletes a variable of a project environment (soft delete)
+// DELETE /api/v1/projects/:id/environments/:env/config/:name
+func (h *ConfigHandler) DeleteVariable(c *gin.Context) {
+	err := h.service.DeleteVariable(c.Request.Context(), c.Param("id"), c.Param("env"), c.Param("name"))
+	if err != nil {
+		respondError(c, err, "Failed to delete config variable")
+		return
+	}
+
+	response.Success(c, nil, "Config variable deleted successfully")
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+type DeploymentHandler struct {
+	service *service.DeploymentService
+}
+
+func NewDeploymentHandler(service *service.DeploymentService) *DeploymentHandler {
+	return &DeploymentHandler{service: service}
+}
+
+// CreateDeployment deploys a project snapshot to an environment
+// POST /api/v1/projects/:id/deployments
+func (h *DeploymentHandler) CreateDeployment(c *gin.Context) {
+	var req models.CreateDeploymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	projectID := c.Param("id")
+	if projectID == "" {
+		response.BadRequest(c, "Invalid project ID", nil)
+		return
+	}
+	req.ProjectUUID = projectID
+
+	deployment, err := h.service.CreateDeployment(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err, "Failed to create deployment")
+		return
+	}
+
+	response.Created(c, deployment, "Deployment created successfully")
+}
+
+// GetDeploymentsByProject retrieves the deployments of a project
+// GET /api/v1/projects/:id/deployments
+func (h *DeploymentHandler) GetDeploymentsByProject(c *gin.Context) {
+	projectID := c.Param("id")
+	if projectID == "" {
+		response.BadRequest(c, "Invalid project ID", nil)
+		return
+	}
+
+	h.listDeployments(c, projectID)
+}
+
+// GetDeployments retrieves deployments across projects, optionally filtered by project_id
+// GET /api/v1/deployments
+func (h *DeploymentHandler) GetDeployments(c *gin.Context) {
+	h.listDeployments(c, c.Query("project_id"))
+}
+
+func (h *DeploymentHandler) listDeployments(c *gin.Context, projectID string) {
+	page, limit := parsePagination(c)
+
+	deployments, total, err := h.service.GetDeployments(c.Request.Context(), projectID, c.Query("environment"), c.Query("status"), page, limit)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve deployments")
+		return
+	}
+
+	response.SuccessWithPagination(c, deployments, newPagination(page, limit, total), "Deployments retrieved successfully")
+}
+
+// GetDeployment retrieves a deployment by UUID
+// GET /api/v1/deployments/:id
+func (h *DeploymentHandler) GetDeployment(c *gin.Context) {
+	uuid := c.Param("id")
+	if uuid == "" {
+		response.BadRequest(c, "Invalid deployment ID", nil)
+		return
+	}
+
+	deployment, err := h.service.GetDeploymentByUUID(c.Request.Context(), uuid)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve deployment")
+		return
+	}
+
+	response.Success(c, deployment, "Deployment retrieved successfully")
+}
+
+// UpdateDeploymentStatus records a status change reported by an executor
+// PUT /api/v1/deployments/:id/status
+func (h *DeploymentHandler) UpdateDeploymentStatus(c *gin.Context) {
+	uuid := c.Param("id")
+	if uuid == "" {
+		response.BadRequest(c, "Invalid deployment ID", nil)
+		return
+	}
+
+	var req models.UpdateDeploymentStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	deployment, err := h.service.UpdateDeploymentStatus(c.Request.Context(), uuid, &req)
+	if err != nil {
+		respondError(c, err, "Failed to update deployment status")
+		return
+	}
+
+	response.Success(c, deployment, "Deployment status updated successfully")
+}
+
+// GetDeploymentLogs retrieves a page of deployment logs
+// GET /api/v1/deployments/:id/logs
+func (h *DeploymentHandler) GetDeploymentLogs(c *gin.Context) {
+	uuid := c.Param("id")
+	if uuid == "" {
+		response.BadRequest(c, "Invalid deployment ID", nil)
+		return
+	}
+
+	page, limit := parsePagination(c)
+
+	logs, total, err := h.service.GetDeploymentLogs(c.Request.Context(), uuid, page, limit)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve deployment logs")
+		return
+	}
+
+	response.SuccessWithPagination(c, logs, newPagination(page, limit, total), "Deployment logs retrieved successfully")
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/yourusername/lambra/internal/models"
 	"github.com/yourusername/lambra/internal/service"
@@ -19,19 +21,37 @@ func NewEndpointHandler(service *service.EndpointService) *EndpointHandler {
 func (h *EndpointHandler) CreateEndpoint(c *gin.Context) {
 	var req models.CreateEndpointRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body", err)
+		bindError(c, err)
 		return
 	}
 
-	endpoint, err := h.service.CreateEndpoint(&req)
+	endpoint, err := h.service.CreateEndpoint(c.Request.Context(), &req)
 	if err != nil {
-		response.InternalError(c, "Failed to create endpoint", err)
+		respondError(c, err, "Failed to create endpoint")
 		return
 	}
 
 	response.Created(c, endpoint, "Endpoint created successfully")
 }
 
+// BulkCreateEndpoints creates several endpoints of a project's entities in one transaction
+// POST /api/v1/projects/:id/endpoints/bulk
+func (h *EndpointHandler) BulkCreateEndpoints(c *gin.Context) {
+	var req models.BulkCreateEndpointsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	endpoints, err := h.service.BulkCreateEndpoints(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err, "Failed to create endpoints")
+		return
+	}
+
+	response.Created(c, endpoints, "Endpoints created successfully")
+}
+
 // GetEndpoint retrieves an endpoint by UUID
 func (h *EndpointHandler) GetEndpoint(c *gin.Context) {
 	uuid := c.Param("id")
@@ -40,16 +60,17 @@ func (h *EndpointHandler) GetEndpoint(c *gin.Context) {
 		return
 	}
 
-	endpoint, err := h.service.GetEndpointByUUID(uuid)
+	endpoint, err := h.service.GetEndpointByUUID(c.Request.Context(), uuid)
 	if err != nil {
-		response.NotFound(c, "Endpoint not found")
+		respondError(c, err, "Failed to retrieve endpoint")
 		return
 	}
 
+	setETag(c, endpoint.Version)
 	response.Success(c, endpoint, "Endpoint retrieved successfully")
 }
 
-// GetEndpointsByProject retrieves all endpoints for a project
+// GetEndpointsByProject lists the endpoints of a project, see parseEndpointFilter for the query params
 // GET /api/v1/projects/:id/endpoints
 func (h *EndpointHandler) GetEndpointsByProject(c *gin.Context) {
 	projectID := c.Param("id")
@@ -58,16 +79,22 @@ func (h *EndpointHandler) GetEndpointsByProject(c *gin.Context) {
 		return
 	}
 
-	endpoints, err := h.service.GetEndpointsByProjectUUID(projectID)
+	filter, ok := parseEndpointFilter(c)
+	if !ok {
+		return
+	}
+	page, limit := parsePagination(c)
+
+	endpoints, total, err := h.service.GetEndpointsByProjectUUID(c.Request.Context(), projectID, filter, page, limit)
 	if err != nil {
-		response.InternalError(c, "Failed to retrieve endpoints", err)
+		respondError(c, err, "Failed to retrieve endpoints")
 		return
 	}
 
-	response.Success(c, endpoints, "Endpoints retrieved successfully")
+	response.SuccessWithPagination(c, endpoints, newPagination(page, limit, total), "Endpoints retrieved successfully")
 }
 
-// GetEndpointsByEntity retrieves all endpoints for an entity
+// GetEndpointsByEntity lists the endpoints of an entity, see parseEndpointFilter for the query params
 // GET /api/v1/entities/:id/endpoints
 func (h *EndpointHandler) GetEndpointsByEntity(c *gin.Context) {
 	entityID := c.Param("id")
@@ -76,13 +103,19 @@ func (h *EndpointHandler) GetEndpointsByEntity(c *gin.Context) {
 		return
 	}
 
-	endpoints, err := h.service.GetEndpointsByEntityUUID(entityID)
+	filter, ok := parseEndpointFilter(c)
+	if !ok {
+		return
+	}
+	page, limit := parsePagination(c)
+
+	endpoints, total, err := h.service.GetEndpointsByEntityUUID(c.Request.Context(), entityID, filter, page, limit)
 	if err != nil {
-		response.InternalError(c, "Failed to retrieve endpoints", err)
+		respondError(c, err, "Failed to retrieve endpoints")
 		return
 	}
 
-	response.Success(c, endpoints, "Endpoints retrieved successfully")
+	response.SuccessWithPagination(c, endpoints, newPagination(page, limit, total), "Endpoints retrieved successfully")
 }
 
 // UpdateEndpoint updates an endpoint by UUID
@@ -95,16 +128,17 @@ func (h *EndpointHandler) UpdateEndpoint(c *gin.Context) {
 
 	var req models.UpdateEndpointRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body", err)
+		bindError(c, err)
 		return
 	}
 
-	endpoint, err := h.service.UpdateEndpoint(uuid, &req)
+	endpoint, err := h.service.UpdateEndpoint(c.Request.Context(), uuid, &req, parseIfMatch(c))
 	if err != nil {
-		response.InternalError(c, "Failed to update endpoint", err)
+		respondError(c, err, "Failed to update endpoint")
 		return
 	}
 
+	setETag(c, endpoint.Version)
 	response.Success(c, endpoint, "Endpoint updated successfully")
 }
 
@@ -116,11 +150,31 @@ func (h *EndpointHandler) DeleteEndpoint(c *gin.Context) {
 		return
 	}
 
-	err := h.service.DeleteEndpoint(uuid)
+	err := h.service.DeleteEndpoint(c.Request.Context(), uuid, parseIfMatch(c))
 	if err != nil {
-		response.InternalError(c, "Failed to delete endpoint", err)
+		respondError(c, err, "Failed to delete endpoint")
 		return
 	}
 
 	response.Success(c, nil, "Endpoint deleted successfully")
 }
+
+// parseEndpointFilter reads the method and require_auth filters next to the shared list options.
+// Endpoints sort by name, path, method, created_at or updated_at.
+func parseEndpointFilter(c *gin.Context) (*models.EndpointFilter, bool) {
+	opts, ok := parseListOptions(c)
+	if !ok {
+		return nil, false
+	}
+
+	requireAuth, ok := parseBoolQuery(c, "require_auth")
+	if !ok {
+		return nil, false
+	}
+
+	return &models.EndpointFilter{
+		ListOptions: opts,
+		Method:      strings.ToUpper(c.Query("method")),
+		RequireAuth: requireAuth,
+	}, true
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+type EndpointRunHandler struct {
+	service *service.EndpointRunService
+}
+
+func NewEndpointRunHandler(service *service.EndpointRunService) *EndpointRunHandler {
+	return &EndpointRunHandler{service: service}
+}
+
+// TestEndpoint runs an endpoint against a running service and records the run
+// POST /api/v1/endpoints/:id/test
+func (h *EndpointRunHandler) TestEndpoint(c *gin.Context) {
+	uuid := c.Param("id")
+	if uuid == "" {
+		response.BadRequest(c, "Invalid endpoint ID", nil)
+		return
+	}
+
+	var req models.TestEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	result, err := h.service.TestEndpoint(c.Request.Context(), uuid, &req)
+	if err != nil {
+		respondError(c, err, "Failed to test endpoint")
+		return
+	}
+
+	response.Success(c, result, "Endpoint tested successfully")
+}
+
+// GetRuns lists the test runs of an endpoint, newest first
+// GET /api/v1/endpoints/:id/runs
+func (h *EndpointRunHandler) GetRuns(c *gin.Context) {
+	uuid := c.Param("id")
+	if uuid == "" {
+		response.BadRequest(c, "Invalid endpoint ID", nil)
+		return
+	}
+
+	page, limit := parsePagination(c)
+
+	runs, total, err := h.service.GetRuns(c.Request.Context(), uuid, page, limit)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve endpoint runs")
+		return
+	}
+
+	response.SuccessWithPagination(c, runs, newPagination(page, limit, total), "Endpoint runs retrieved successfully")
+}
@@ -20,7 +20,7 @@ func NewEntityHandler(service *service.EntityService) *EntityHandler {
 func (h *EntityHandler) CreateEntity(c *gin.Context) {
 	var req models.CreateEntityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body", err)
+		bindError(c, err)
 		return
 	}
 
@@ -32,15 +32,33 @@ func (h *EntityHandler) CreateEntity(c *gin.Context) {
 	}
 	req.ProjectUUID = projectID
 
-	entity, err := h.service.CreateEntity(&req)
+	entity, err := h.service.CreateEntity(c.Request.Context(), &req)
 	if err != nil {
-		response.InternalError(c, "Failed to create entity", err)
+		respondError(c, err, "Failed to create entity")
 		return
 	}
 
 	response.Created(c, entity, "Entity created successfully")
 }
 
+// BulkCreateEntities creates several entities of a project in one transaction
+// POST /api/v1/projects/:id/entities/bulk
+func (h *EntityHandler) BulkCreateEntities(c *gin.Context) {
+	var req models.BulkCreateEntitiesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	entities, err := h.service.BulkCreateEntities(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err, "Failed to create entities")
+		return
+	}
+
+	response.Created(c, entities, "Entities created successfully")
+}
+
 // GetEntity retrieves an entity by UUID
 func (h *EntityHandler) GetEntity(c *gin.Context) {
 	uuid := c.Param("id")
@@ -49,16 +67,18 @@ func (h *EntityHandler) GetEntity(c *gin.Context) {
 		return
 	}
 
-	entity, err := h.service.GetEntityByUUID(uuid)
+	entity, err := h.service.GetEntityByUUID(c.Request.Context(), uuid)
 	if err != nil {
-		response.NotFound(c, "Entity not found")
+		respondError(c, err, "Failed to retrieve entity")
 		return
 	}
 
+	setETag(c, entity.Version)
 	response.Success(c, entity, "Entity retrieved successfully")
 }
 
-// GetEntitiesByProject retrieves all entities for a project
+// GetEntitiesByProject lists the entities of a project (search, created_by, created_after,
+// created_before, sort by name, table_name, created_at or updated_at, paginated)
 // GET /api/v1/projects/:id/entities
 func (h *EntityHandler) GetEntitiesByProject(c *gin.Context) {
 	projectID := c.Param("id")
@@ -67,13 +87,20 @@ func (h *EntityHandler) GetEntitiesByProject(c *gin.Context) {
 		return
 	}
 
-	entities, err := h.service.GetEntitiesByProjectUUID(projectID)
+	opts, ok := parseListOptions(c)
+	if !ok {
+		return
+	}
+	page, limit := parsePagination(c)
+
+	filter := &models.EntityFilter{ListOptions: opts}
+	entities, total, err := h.service.GetEntitiesByProjectUUID(c.Request.Context(), projectID, filter, page, limit)
 	if err != nil {
-		response.InternalError(c, "Failed to retrieve entities", err)
+		respondError(c, err, "Failed to retrieve entities")
 		return
 	}
 
-	response.Success(c, entities, "Entities retrieved successfully")
+	response.SuccessWithPagination(c, entities, newPagination(page, limit, total), "Entities retrieved successfully")
 }
 
 // UpdateEntity updates an entity by UUID
@@ -86,16 +113,17 @@ func (h *EntityHandler) UpdateEntity(c *gin.Context) {
 
 	var req models.UpdateEntityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body", err)
+		bindError(c, err)
 		return
 	}
 
-	entity, err := h.service.UpdateEntity(uuid, &req)
+	entity, err := h.service.UpdateEntity(c.Request.Context(), uuid, &req, parseIfMatch(c))
 	if err != nil {
-		response.InternalError(c, "Failed to update entity", err)
+		respondError(c, err, "Failed to update entity")
 		return
 	}
 
+	setETag(c, entity.Version)
 	response.Success(c, entity, "Entity updated successfully")
 }
 
@@ -107,9 +135,9 @@ func (h *EntityHandler) DeleteEntity(c *gin.Context) {
 		return
 	}
 
-	err := h.service.DeleteEntity(uuid)
+	err := h.service.DeleteEntity(c.Request.Context(), uuid, parseIfMatch(c))
 	if err != nil {
-		response.InternalError(c, "Failed to delete entity", err)
+		respondError(c, err, "Failed to delete entity")
 		return
 	}
 
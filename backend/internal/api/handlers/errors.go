@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+func init() {
+	// Report validation failures under the JSON names clients send
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+	}
+}
+
+// statusOf returns the HTTP status of a domain error kind
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, apperror.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperror.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, apperror.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, apperror.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, apperror.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, apperror.ErrUnauthorized):
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+// respondError writes the problem of a service error. Domain errors get the status of their kind
+// and their own message; any other error is a 500 described by message.
+func respondError(c *gin.Context, err error, message string) {
+	detail, ok := apperror.Message(err)
+	if !ok {
+		response.InternalError(c, message, err)
+		return
+	}
+
+	response.WriteProblem(c, statusOf(err), detail, fieldErrors(apperror.Fields(err)))
+}
+
+// bindError writes the validation problem of a request body that failed to bind
+func bindError(c *gin.Context, err error) {
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make([]response.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, response.FieldError{Field: fieldPath(fe), Message: ruleMessage(fe)})
+		}
+		response.ValidationFailed(c, "Invalid request body", fields)
+	case errors.As(err, &typeErr):
+		response.ValidationFailed(c, "Invalid request body", []response.FieldError{
+			{Field: typeErr.Field, Message: "must be of type " + typeErr.Type.String()},
+		})
+	default:
+		response.BadRequest(c, "Invalid request body", err)
+	}
+}
+
+// fieldPath returns the JSON path of a field error without the name of the request struct
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+// ruleMessage describes the validation rule a field failed
+func ruleMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	case "eq":
+		return "must be " + fe.Param()
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "email":
+		return "must be a valid email address"
+	case "hostname_rfc1123":
+		return "must be a valid DNS label"
+	}
+	if fe.Param() != "" {
+		return fmt.Sprintf("must satisfy %s=%s", fe.Tag(), fe.Param())
+	}
+	return "must satisfy " + fe.Tag()
+}
+
+func fieldErrors(fields []apperror.FieldError) []response.FieldError {
+	if len(fields) == 0 {
+		return nil
+	}
+	result := make([]response.FieldError, len(fields))
+	for i, f := range fields {
+		result[i] = response.FieldError{Field: f.Field, Message: f.Message}
+	}
+	return result
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// setETag sets the ETag header of a resource at version
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// parseIfMatch returns the precondition of the If-Match header, or nil when the header is absent or "*".
+// Weak and malformed tags never match, as If-Match compares strongly.
+func parseIfMatch(c *gin.Context) *models.Precondition {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	precondition := &models.Precondition{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+		if err != nil {
+			continue
+		}
+		precondition.Versions = append(precondition.Versions, version)
+	}
+	return precondition
+}
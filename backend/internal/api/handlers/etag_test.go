@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		header   string
+		any      bool
+		versions []int64
+	}{
+		{"absent", "", true, nil},
+		{"any", "*", true, nil},
+		{"any with spaces", " * ", true, nil},
+		{"strong tag", `"3"`, false, []int64{3}},
+		{"list of tags", `"3", "5" ,"8"`, false, []int64{3, 5, 8}},
+		{"weak tag never matches", `W/"3"`, false, nil},
+		{"weak tag in a list", `W/"3", "4"`, false, []int64{4}},
+		{"unquoted tag", `3`, false, nil},
+		{"half quoted tag", `"3`, false, nil},
+		{"lone quote", `"`, false, nil},
+		{"not a version", `"abc"`, false, nil},
+		{"empty tag", `""`, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.header != "" {
+				c.Request.Header.Set("If-Match", tt.header)
+			}
+
+			got := parseIfMatch(c)
+			if tt.any {
+				if got != nil {
+					t.Fatalf("parseIfMatch(%q) = %v, want nil", tt.header, got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("parseIfMatch(%q) = nil, want a precondition", tt.header)
+			}
+			if !reflect.DeepEqual(got.Versions, tt.versions) {
+				t.Errorf("parseIfMatch(%q) versions = %v, want %v", tt.header, got.Versions, tt.versions)
+			}
+			if len(tt.versions) == 0 && got.Matches(3) {
+				t.Errorf("parseIfMatch(%q) matched version 3, want no match", tt.header)
+			}
+		})
+	}
+}
+
+func TestSetETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	setETag(c, 42)
+
+	if got := rec.Header().Get("ETag"); got != `"42"` {
+		t.Errorf("ETag = %q, want %q", got, `"42"`)
+	}
+}
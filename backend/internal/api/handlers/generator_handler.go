@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
 )
 
 // GeneratorHandler handles code generation HTTP requests
@@ -39,14 +41,14 @@ type GenerateProjectRequest struct {
 // @Accept json
 // @Produce json
 // @Param request body GenerateEntityRequest true "Generation request"
-// @Success 200 {object} service.GenerateCodeResponse
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Success 200 {object} response.Response{data=service.GenerateCodeResponse}
+// @Failure 400 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Router /api/v1/generate/entity [post]
 func (h *GeneratorHandler) GenerateEntity(c *gin.Context) {
 	var req GenerateEntityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		bindError(c, err)
 		return
 	}
 
@@ -54,13 +56,13 @@ func (h *GeneratorHandler) GenerateEntity(c *gin.Context) {
 		req.OutputDir = "./generated"
 	}
 
-	response, err := h.service.GenerateEntity(c.Request.Context(), req.EntityID, req.OutputDir)
+	result, err := h.service.GenerateEntity(c.Request.Context(), req.EntityID, req.OutputDir)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err, "Failed to generate entity code")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	response.Success(c, result, "Code generated successfully")
 }
 
 // GenerateProject generates code for all entities in a project
@@ -70,14 +72,14 @@ func (h *GeneratorHandler) GenerateEntity(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request body GenerateProjectRequest true "Generation request"
-// @Success 200 {object} service.GenerateCodeResponse
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Success 200 {object} response.Response{data=service.GenerateCodeResponse}
+// @Failure 400 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Router /api/v1/generate/project [post]
 func (h *GeneratorHandler) GenerateProject(c *gin.Context) {
 	var req GenerateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		bindError(c, err)
 		return
 	}
 
@@ -85,13 +87,41 @@ func (h *GeneratorHandler) GenerateProject(c *gin.Context) {
 		req.OutputDir = "./generated"
 	}
 
-	response, err := h.service.GenerateProject(c.Request.Context(), req.ProjectID, req.OutputDir)
+	result, err := h.service.GenerateProject(c.Request.Context(), req.ProjectID, req.OutputDir)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err, "Failed to generate project code")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	response.Success(c, result, "Code generated successfully")
+}
+
+// DownloadProjectArchive generates the code of a project and returns it as a zip archive
+// @Summary Download generated project code
+// @Description Generates code for all entities of a project and returns the files as a zip archive
+// @Tags generator
+// @Produce application/zip
+// @Param id path string true "Project ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Router /api/v1/projects/:id/archive [get]
+func (h *GeneratorHandler) DownloadProjectArchive(c *gin.Context) {
+	id := c.Param("id")
+
+	result, err := h.service.GenerateProjectByUUID(c.Request.Context(), id, "")
+	if err != nil {
+		respondError(c, err, "Failed to generate project code")
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.zip"`, id))
+	c.Status(http.StatusOK)
+	if err := service.WriteArchive(c.Writer, result.Files); err != nil {
+		c.Error(err)
+	}
 }
 
 // PreviewEntity previews generated code without writing files
@@ -101,24 +131,24 @@ func (h *GeneratorHandler) GenerateProject(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Entity ID"
-// @Success 200 {object} service.GenerateCodeResponse
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Success 200 {object} response.Response{data=service.GenerateCodeResponse}
+// @Failure 400 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Router /api/v1/generate/preview/:id [get]
 func (h *GeneratorHandler) PreviewEntity(c *gin.Context) {
 	entityID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity ID"})
+		response.BadRequest(c, "Invalid entity ID", nil)
 		return
 	}
 
-	response, err := h.service.PreviewEntity(c.Request.Context(), entityID)
+	result, err := h.service.PreviewEntity(c.Request.Context(), entityID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err, "Failed to preview entity code")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	response.Success(c, result, "Code preview generated successfully")
 }
 
 // GetGeneratedFilesList returns list of files that will be generated
@@ -128,27 +158,22 @@ func (h *GeneratorHandler) PreviewEntity(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Entity ID"
-// @Success 200 {object} map[string][]string
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Success 200 {object} response.Response{data=map[string][]string}
+// @Failure 400 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Router /api/v1/generate/files/:id [get]
 func (h *GeneratorHandler) GetGeneratedFilesList(c *gin.Context) {
 	entityID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity ID"})
+		response.BadRequest(c, "Invalid entity ID", nil)
 		return
 	}
 
 	files, err := h.service.GetGeneratedFilesList(c.Request.Context(), entityID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err, "Failed to list generated files")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"files": files})
-}
-
-// ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error string `json:"error"`
+	response.Success(c, gin.H{"files": files}, "Generated files retrieved successfully")
 }
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+type GitRepositoryHandler struct {
+	service *service.GitService
+}
+
+func NewGitRepositoryHandler(service *service.GitService) *GitRepositoryHandler {
+	return &GitRepositoryHandler{service: service}
+}
+
+// CreateRepository provisions a git repository for a project
+// POST /api/v1/repositories
+func (h *GitRepositoryHandler) CreateRepository(c *gin.Context) {
+	var req models.CreateGitRepositoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	repo, err := h.service.CreateRepository(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err, "Failed to create git repository")
+		return
+	}
+
+	response.Created(c, repo, "Git repository created successfully")
+}
+
+// GetRepositoryByProject retrieves the git repository of a project
+// GET /api/v1/projects/:id/repository
+func (h *GitRepositoryHandler) GetRepositoryByProject(c *gin.Context) {
+	projectID := c.Param("id")
+	if projectID == "" {
+		response.BadRequest(c, "Invalid project ID", nil)
+		return
+	}
+
+	repo, err := h.service.GetRepositoryByProjectUUID(c.Request.Context(), projectID)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve git repository")
+		return
+	}
+
+	response.Success(c, repo, "Git repository retrieved successfully")
+}
+
+// PublishGeneratedCode pushes regenerated code and opens a merge request when it changed
+// POST /api/v1/projects/:id/repository/merge-requests
+func (h *GitRepositoryHandler) PublishGeneratedCode(c *gin.Context) {
+	projectID := c.Param("id")
+	if projectID == "" {
+		response.BadRequest(c, "Invalid project ID", nil)
+		return
+	}
+
+	result, err := h.service.PublishGeneratedCode(c.Request.Context(), projectID)
+	if err != nil {
+		respondError(c, err, "Failed to publish generated code")
+		return
+	}
+
+	response.Success(c, result, result.Message)
+}
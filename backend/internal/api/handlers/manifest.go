@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/yourusername/lambra/internal/manifest"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+// maxManifestSize limits the size of an uploaded manifest
+const maxManifestSize = 1 << 20
+
+type ManifestHandler struct {
+	service *service.ManifestService
+}
+
+func NewManifestHandler(service *service.ManifestService) *ManifestHandler {
+	return &ManifestHandler{service: service}
+}
+
+// ExportManifest returns the manifest of a project as YAML, or as JSON with format=json
+// GET /api/v1/projects/:id/manifest
+func (h *ManifestHandler) ExportManifest(c *gin.Context) {
+	format := c.DefaultQuery("format", manifest.FormatYAML)
+	if format != manifest.FormatYAML && format != manifest.FormatJSON {
+		respondError(c, manifest.ErrUnknownFormat, "Invalid query parameter")
+		return
+	}
+
+	m, err := h.service.ExportProject(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to export manifest")
+		return
+	}
+
+	data, err := manifest.Encode(m, format)
+	if err != nil {
+		response.InternalError(c, "Failed to export manifest", err)
+		return
+	}
+
+	contentType := "application/yaml"
+	if format == manifest.FormatJSON {
+		contentType = "application/json"
+	}
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// ApplyManifest makes a project match a YAML or JSON manifest and returns the changes it made,
+// or only computes them with dry_run=true
+// PUT /api/v1/projects/:id/manifest
+func (h *ManifestHandler) ApplyManifest(c *gin.Context) {
+	dryRun, ok := parseBoolQuery(c, "dry_run")
+	if !ok {
+		return
+	}
+	preview := dryRun != nil && *dryRun
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxManifestSize))
+	if err != nil {
+		response.BadRequest(c, "Invalid manifest", err)
+		return
+	}
+
+	m, err := manifest.Parse(data)
+	if err != nil {
+		respondError(c, err, "Invalid manifest")
+		return
+	}
+	if err := binding.Validator.ValidateStruct(m); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	plan, err := h.service.ApplyManifest(c.Request.Context(), c.Param("id"), m, preview)
+	if err != nil {
+		respondError(c, err, "Failed to apply manifest")
+		return
+	}
+
+	message := "Manifest applied successfully"
+	if preview {
+		message = "Manifest plan computed successfully"
+	}
+	response.Success(c, plan, message)
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+func TestExportManifestRejectsUnknownFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// The format is checked before the project is loaded, so no service is needed
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/projects/prj-1/manifest?format=xml", nil)
+	NewManifestHandler(nil).ExportManifest(c)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+	var problem response.Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "format" || problem.Errors[0].Message != "must be one of yaml, json" {
+		t.Errorf("errors = %v, want format to list yaml and json", problem.Errors)
+	}
+}
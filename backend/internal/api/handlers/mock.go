@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+const (
+	// MockScenarioHeader selects the mock scenario of the endpoint answering a request
+	MockScenarioHeader = "X-Mock-Scenario"
+	// MockScenarioQuery selects the mock scenario where headers cannot be set, e.g. in a browser
+	MockScenarioQuery = "__scenario"
+	// MockEndpointHeader names the endpoint that answered a mock request
+	MockEndpointHeader = "X-Mock-Endpoint"
+
+	maxMockBodyBytes = 1 << 20
+)
+
+type MockHandler struct {
+	service *service.MockService
+}
+
+func NewMockHandler(service *service.MockService) *MockHandler {
+	return &MockHandler{service: service}
+}
+
+// Serve answers a request to the mock API of a project from its endpoint definitions
+// ANY /mock/:project/*path
+func (h *MockHandler) Serve(c *gin.Context) {
+	projectID := c.Param("project")
+	if projectID == "" {
+		response.BadRequest(c, "Invalid project ID", nil)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxMockBodyBytes+1))
+	if err != nil {
+		response.BadRequest(c, "Failed to read request body", nil)
+		return
+	}
+	if len(body) > maxMockBodyBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+		return
+	}
+
+	scenario := c.GetHeader(MockScenarioHeader)
+	if scenario == "" {
+		scenario = c.Query(MockScenarioQuery)
+	}
+
+	resp, err := h.service.Serve(c.Request.Context(), projectID, &models.MockRequest{
+		Method:        c.Request.Method,
+		Path:          c.Param("path"),
+		Scenario:      scenario,
+		Authorization: c.GetHeader("Authorization"),
+		Body:          body,
+	})
+	if err != nil {
+		respondError(c, err, "Failed to serve mock")
+		return
+	}
+
+	for name, value := range resp.Headers {
+		c.Header(name, value)
+	}
+	if resp.EndpointUUID != "" {
+		c.Header(MockEndpointHeader, resp.EndpointUUID)
+	}
+	if resp.Body == nil {
+		c.Status(resp.Status)
+		return
+	}
+	c.Data(resp.Status, "application/json; charset=utf-8", resp.Body)
+}
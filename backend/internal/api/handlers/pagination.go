@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+// parsePagination reads page and limit query params, falling back to page 1 with 20 items
+func parsePagination(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	return page, limit
+}
+
+// newPagination builds pagination metadata for a page of results
+func newPagination(page, limit int, total int64) response.Pagination {
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return response.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: total,
+		TotalPages: totalPages,
+	}
+}
+
+// parseListOptions reads the search, created_by, RFC 3339 created_after/created_before and sort
+// query params shared by listings, responding 400 on bad values. A sort field prefixed with "-"
+// sorts descending.
+func parseListOptions(c *gin.Context) (models.ListOptions, bool) {
+	opts := models.ListOptions{
+		Search:    strings.TrimSpace(c.Query("search")),
+		CreatedBy: c.Query("created_by"),
+	}
+
+	sort := c.Query("sort")
+	opts.Descending = strings.HasPrefix(sort, "-")
+	opts.Sort = strings.TrimPrefix(sort, "-")
+
+	for param, target := range map[string]**time.Time{"created_after": &opts.CreatedAfter, "created_before": &opts.CreatedBefore} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			response.ValidationFailed(c, "Invalid query parameter", []response.FieldError{
+				{Field: param, Message: "must be an RFC 3339 time"},
+			})
+			return opts, false
+		}
+		*target = &t
+	}
+
+	return opts, true
+}
+
+// parseBoolQuery reads an optional boolean query param, responding 400 on bad values
+func parseBoolQuery(c *gin.Context, param string) (*bool, bool) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, true
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		response.ValidationFailed(c, "Invalid query parameter", []response.FieldError{
+			{Field: param, Message: "must be true or false"},
+		})
+		return nil, false
+	}
+
+	return &b, true
+}
+
+// pageOf returns one page of a fully loaded listing with the number of items
+func pageOf[T any](items []T, page, limit int) ([]T, int64) {
+	total := int64(len(items))
+	start := (page - 1) * limit
+	if start >= len(items) {
+		return []T{}, total
+	}
+
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end], total
+}
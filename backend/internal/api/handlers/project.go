@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"strconv"
-
 	"github.com/gin-gonic/gin"
 	"github.com/yourusername/lambra/internal/models"
 	"github.com/yourusername/lambra/internal/service"
@@ -20,19 +18,37 @@ func NewProjectHandler(service *service.ProjectService) *ProjectHandler {
 func (h *ProjectHandler) CreateProject(c *gin.Context) {
 	var req models.CreateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body", err)
+		bindError(c, err)
 		return
 	}
 
-	project, err := h.service.CreateProject(&req)
+	project, err := h.service.CreateProject(c.Request.Context(), &req)
 	if err != nil {
-		response.InternalError(c, "Failed to create project", err)
+		respondError(c, err, "Failed to create project")
 		return
 	}
 
 	response.Created(c, project, "Project created successfully")
 }
 
+// CloneProject copies a project with its entities and endpoints, optionally from a snapshot
+// POST /api/v1/projects/:id/clone
+func (h *ProjectHandler) CloneProject(c *gin.Context) {
+	var req models.CloneProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	project, err := h.service.CloneProject(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err, "Failed to clone project")
+		return
+	}
+
+	response.Created(c, project, "Project cloned successfully")
+}
+
 func (h *ProjectHandler) GetProject(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -40,38 +56,47 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 		return
 	}
 
-	project, err := h.service.GetProjectWithRelations(id)
+	project, err := h.service.GetProjectWithRelations(c.Request.Context(), id)
 	if err != nil {
-		response.NotFound(c, "Project not found")
+		respondError(c, err, "Failed to retrieve project")
 		return
 	}
 
+	setETag(c, project.Version)
 	response.Success(c, project, "Project retrieved successfully")
 }
 
 func (h *ProjectHandler) GetAllProjects(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	h.listProjects(c, c.Query("team_id"))
+}
 
-	projects, total, err := h.service.GetAllProjects(page, limit)
-	if err != nil {
-		response.InternalError(c, "Failed to retrieve projects", err)
+// GetProjectsByTeam lists the projects of a team visible to the current user
+// GET /api/v1/teams/:id/projects
+func (h *ProjectHandler) GetProjectsByTeam(c *gin.Context) {
+	h.listProjects(c, c.Param("id"))
+}
+
+// listProjects lists projects filtered by status, namespace and the shared list options.
+// Projects sort by name, namespace, status, created_at or updated_at.
+func (h *ProjectHandler) listProjects(c *gin.Context, teamID string) {
+	opts, ok := parseListOptions(c)
+	if !ok {
 		return
 	}
+	page, limit := parsePagination(c)
 
-	totalPages := int(total) / limit
-	if int(total)%limit > 0 {
-		totalPages++
+	filter := &models.ProjectFilter{
+		ListOptions: opts,
+		Status:      c.Query("status"),
+		Namespace:   c.Query("namespace"),
 	}
-
-	pagination := response.Pagination{
-		Page:       page,
-		Limit:      limit,
-		TotalItems: total,
-		TotalPages: totalPages,
+	projects, total, err := h.service.GetAllProjects(c.Request.Context(), teamID, filter, page, limit)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve projects")
+		return
 	}
 
-	response.SuccessWithPagination(c, projects, pagination, "Projects retrieved successfully")
+	response.SuccessWithPagination(c, projects, newPagination(page, limit, total), "Projects retrieved successfully")
 }
 
 func (h *ProjectHandler) UpdateProject(c *gin.Context) {
@@ -83,16 +108,17 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 
 	var req models.UpdateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "Invalid request body", err)
+		bindError(c, err)
 		return
 	}
 
-	project, err := h.service.UpdateProject(id, &req)
+	project, err := h.service.UpdateProject(c.Request.Context(), id, &req, parseIfMatch(c))
 	if err != nil {
-		response.InternalError(c, "Failed to update project", err)
+		respondError(c, err, "Failed to update project")
 		return
 	}
 
+	setETag(c, project.Version)
 	response.Success(c, project, "Project updated successfully")
 }
 
@@ -103,11 +129,55 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 		return
 	}
 
-	err := h.service.DeleteProject(id)
+	err := h.service.DeleteProject(c.Request.Context(), id, parseIfMatch(c))
 	if err != nil {
-		response.InternalError(c, "Failed to delete project", err)
+		respondError(c, err, "Failed to delete project")
 		return
 	}
 
 	response.Success(c, nil, "Project deleted successfully")
 }
+
+// GetMembers lists the members of a project and their roles
+// GET /api/v1/projects/:id/members
+func (h *ProjectHandler) GetMembers(c *gin.Context) {
+	members, err := h.service.GetMembers(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to retrieve project members")
+		return
+	}
+
+	page, limit := parsePagination(c)
+	items, total := pageOf(members, page, limit)
+	response.SuccessWithPagination(c, items, newPagination(page, limit, total), "Project members retrieved successfully")
+}
+
+// SetMember adds a user to a project or changes their role
+// PUT /api/v1/projects/:id/members/:username
+func (h *ProjectHandler) SetMember(c *gin.Context) {
+	var req models.SetProjectMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	err := h.service.SetMember(c.Request.Context(), c.Param("id"), c.Param("username"), &req)
+	if err != nil {
+		respondError(c, err, "Failed to save project member")
+		return
+	}
+
+	response.Success(c, nil, "Project member saved successfully")
+}
+
+// RemoveMember removes a user from a project
+// DELETE /api/v1/projects/:id/members/:username
+func (h *ProjectHandler) RemoveMember(c *gin.Context) {
+	err := h.service.RemoveMember(c.Request.Context(), c.Param("id"), c.Param("username"))
+	if err != nil {
+		respondError(c, err, "Failed to remove project member")
+		return
+	}
+
+	response.Success(c, nil, "Project member removed successfully")
+}
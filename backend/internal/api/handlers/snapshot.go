@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+type SnapshotHandler struct {
+	service *service.SnapshotService
+}
+
+func NewSnapshotHandler(service *service.SnapshotService) *SnapshotHandler {
+	return &SnapshotHandler{service: service}
+}
+
+// CreateSnapshot freezes the current project definition under a version
+// POST /api/v1/projects/:id/snapshots
+func (h *SnapshotHandler) CreateSnapshot(c *gin.Context) {
+	var req models.CreateSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	projectID := c.Param("id")
+	if projectID == "" {
+		response.BadRequest(c, "Invalid project ID", nil)
+		return
+	}
+	req.ProjectUUID = projectID
+
+	snapshot, err := h.service.CreateSnapshot(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err, "Failed to create snapshot")
+		return
+	}
+
+	response.Created(c, snapshot, "Snapshot created successfully")
+}
+
+// GetSnapshotsByProject lists the snapshots of a project, newest first (paginated)
+// GET /api/v1/projects/:id/snapshots
+func (h *SnapshotHandler) GetSnapshotsByProject(c *gin.Context) {
+	projectID := c.Param("id")
+	if projectID == "" {
+		response.BadRequest(c, "Invalid project ID", nil)
+		return
+	}
+
+	page, limit := parsePagination(c)
+
+	snapshots, total, err := h.service.GetSnapshotsByProjectUUID(c.Request.Context(), projectID, page, limit)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve snapshots")
+		return
+	}
+
+	response.SuccessWithPagination(c, snapshots, newPagination(page, limit, total), "Snapshots retrieved successfully")
+}
+
+// DiffSnapshot returns the changes from a snapshot to the snapshot named by the to query
+// parameter, or to the current state of the project without it
+// GET /api/v1/snapshots/:id/diff
+func (h *SnapshotHandler) DiffSnapshot(c *gin.Context) {
+	plan, err := h.service.DiffSnapshots(c.Request.Context(), c.Param("id"), c.Query("to"))
+	if err != nil {
+		respondError(c, err, "Failed to diff snapshot")
+		return
+	}
+
+	response.Success(c, plan, "Snapshot diff computed successfully")
+}
+
+// GetSnapshot retrieves a snapshot by UUID
+// GET /api/v1/snapshots/:id
+func (h *SnapshotHandler) GetSnapshot(c *gin.Context) {
+	uuid := c.Param("id")
+	if uuid == "" {
+		response.BadRequest(c, "Invalid snapshot ID", nil)
+		return
+	}
+
+	snapshot, err := h.service.GetSnapshotByUUID(c.Request.Context(), uuid)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve snapshot")
+		return
+	}
+
+	response.Success(c, snapshot, "Snapshot retrieved successfully")
+}
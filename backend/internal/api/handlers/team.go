@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+type TeamHandler struct {
+	service *service.TeamService
+}
+
+func NewTeamHandler(service *service.TeamService) *TeamHandler {
+	return &TeamHandler{service: service}
+}
+
+// CreateTeam creates a team owned by the current user
+// POST /api/v1/teams
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	var req models.CreateTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	team, err := h.service.CreateTeam(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err, "Failed to create team")
+		return
+	}
+
+	response.Created(c, team, "Team created successfully")
+}
+
+// GetTeams lists the teams of the current user
+// GET /api/v1/teams
+func (h *TeamHandler) GetTeams(c *gin.Context) {
+	page, limit := parsePagination(c)
+
+	teams, total, err := h.service.GetTeams(c.Request.Context(), page, limit)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve teams")
+		return
+	}
+
+	response.SuccessWithPagination(c, teams, newPagination(page, limit, total), "Teams retrieved successfully")
+}
+
+// GetTeam retrieves a team by UUID
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	team, err := h.service.GetTeam(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to retrieve team")
+		return
+	}
+
+	response.Success(c, team, "Team retrieved successfully")
+}
+
+// UpdateTeam updates a team and its project defaults
+func (h *TeamHandler) UpdateTeam(c *gin.Context) {
+	var req models.UpdateTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	team, err := h.service.UpdateTeam(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err, "Failed to update team")
+		return
+	}
+
+	response.Success(c, team, "Team updated successfully")
+}
+
+// DeleteTeam deletes a team without projects (soft delete)
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	err := h.service.DeleteTeam(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to delete team")
+		return
+	}
+
+	response.Success(c, nil, "Team deleted successfully")
+}
+
+// GetTeamStats summarizes the projects of a team
+// GET /api/v1/teams/:id/stats
+func (h *TeamHandler) GetTeamStats(c *gin.Context) {
+	stats, err := h.service.GetTeamStats(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to retrieve team stats")
+		return
+	}
+
+	response.Success(c, stats, "Team stats retrieved successfully")
+}
+
+// GetMembers lists the members of a team
+// GET /api/v1/teams/:id/members
+func (h *TeamHandler) GetMembers(c *gin.Context) {
+	members, err := h.service.GetMembers(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to retrieve team members")
+		return
+	}
+
+	page, limit := parsePagination(c)
+	items, total := pageOf(members, page, limit)
+	response.SuccessWithPagination(c, items, newPagination(page, limit, total), "Team members retrieved successfully")
+}
+
+// SetMember adds a user to a team or changes their role
+// PUT /api/v1/teams/:id/members/:username
+func (h *TeamHandler) SetMember(c *gin.Context) {
+	var req models.SetTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	member, err := h.service.SetMember(c.Request.Context(), c.Param("id"), c.Param("username"), &req)
+	if err != nil {
+		respondError(c, err, "Failed to save team member")
+		return
+	}
+
+	response.Success(c, member, "Team member saved successfully")
+}
+
+// RemoveMember removes a user from a team
+// DELETE /api/v1/teams/:id/members/:username
+func (h *TeamHandler) RemoveMember(c *gin.Context) {
+	err := h.service.RemoveMember(c.Request.Context(), c.Param("id"), c.Param("username"))
+	if err != nil {
+		respondError(c, err, "Failed to remove team member")
+		return
+	}
+
+	response.Success(c, nil, "Team member removed successfully")
+}
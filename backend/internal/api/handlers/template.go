@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+type TemplateHandler struct {
+	service *service.TemplateService
+}
+
+func NewTemplateHandler(service *service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{service: service}
+}
+
+// CreateTemplate creates a new code generation template
+// POST /api/v1/templates
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var req models.CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	template, err := h.service.CreateTemplate(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err, "Failed to create template")
+		return
+	}
+
+	response.Created(c, template, "Template created successfully")
+}
+
+// GetTemplates lists templates, optionally filtered by type
+// GET /api/v1/templates?type=handler&page=1&limit=20
+func (h *TemplateHandler) GetTemplates(c *gin.Context) {
+	page, limit := parsePagination(c)
+
+	templates, total, err := h.service.GetTemplates(c.Query("type"), page, limit)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve templates")
+		return
+	}
+
+	response.SuccessWithPagination(c, templates, newPagination(page, limit, total), "Templates retrieved successfully")
+}
+
+// GetTemplate retrieves a template by UUID
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	uuid := c.Param("id")
+	if uuid == "" {
+		response.BadRequest(c, "Invalid template ID", nil)
+		return
+	}
+
+	template, err := h.service.GetTemplateByUUID(uuid)
+	if err != nil {
+		respondError(c, err, "Failed to retrieve template")
+		return
+	}
+
+	setETag(c, template.Version)
+	response.Success(c, template, "Template retrieved successfully")
+}
+
+// UpdateTemplate updates a template by UUID
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	uuid := c.Param("id")
+	if uuid == "" {
+		response.BadRequest(c, "Invalid template ID", nil)
+		return
+	}
+
+	var req models.UpdateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		bindError(c, err)
+		return
+	}
+
+	template, err := h.service.UpdateTemplate(c.Request.Context(), uuid, &req, parseIfMatch(c))
+	if err != nil {
+		respondError(c, err, "Failed to update template")
+		return
+	}
+
+	setETag(c, template.Version)
+	response.Success(c, template, "Template updated successfully")
+}
+
+// DeleteTemplate deletes a template by UUID (soft delete)
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	uuid := c.Param("id")
+	if uuid == "" {
+		response.BadRequest(c, "Invalid template ID", nil)
+		return
+	}
+
+	err := h.service.DeleteTemplate(c.Request.Context(), uuid, parseIfMatch(c))
+	if err != nil {
+		respondError(c, err, "Failed to delete template")
+		return
+	}
+
+	response.Success(c, nil, "Template deleted successfully")
+}
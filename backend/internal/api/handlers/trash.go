@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+type TrashHandler struct {
+	service *service.TrashService
+}
+
+func NewTrashHandler(service *service.TrashService) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+// GetDeletedProjects lists the deleted projects of the current user, most recently deleted first
+// GET /api/v1/trash/projects
+func (h *TrashHandler) GetDeletedProjects(c *gin.Context) {
+	projects, err := h.service.GetDeletedProjects(c.Request.Context())
+	if err != nil {
+		respondError(c, err, "Failed to retrieve deleted projects")
+		return
+	}
+
+	page, limit := parsePagination(c)
+	items, total := pageOf(projects, page, limit)
+	response.SuccessWithPagination(c, items, newPagination(page, limit, total), "Deleted projects retrieved successfully")
+}
+
+// GetProjectTrash lists the deleted entities and endpoints of a project
+// GET /api/v1/projects/:id/trash
+func (h *TrashHandler) GetProjectTrash(c *gin.Context) {
+	trash, err := h.service.GetProjectTrash(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to retrieve project trash")
+		return
+	}
+
+	response.Success(c, trash, "Project trash retrieved successfully")
+}
+
+// RestoreProject brings back a deleted project
+// POST /api/v1/trash/projects/:id/restore
+func (h *TrashHandler) RestoreProject(c *gin.Context) {
+	project, err := h.service.RestoreProject(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to restore project")
+		return
+	}
+
+	setETag(c, project.Version)
+	response.Success(c, project, "Project restored successfully")
+}
+
+// PurgeProject permanently removes a deleted project
+// DELETE /api/v1/trash/projects/:id
+func (h *TrashHandler) PurgeProject(c *gin.Context) {
+	if err := h.service.PurgeProject(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err, "Failed to purge project")
+		return
+	}
+
+	response.Success(c, nil, "Project purged successfully")
+}
+
+// RestoreEntity brings back a deleted entity
+// POST /api/v1/trash/entities/:id/restore
+func (h *TrashHandler) RestoreEntity(c *gin.Context) {
+	entity, err := h.service.RestoreEntity(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to restore entity")
+		return
+	}
+
+	setETag(c, entity.Version)
+	response.Success(c, entity, "Entity restored successfully")
+}
+
+// PurgeEntity permanently removes a deleted entity
+// DELETE /api/v1/trash/entities/:id
+func (h *TrashHandler) PurgeEntity(c *gin.Context) {
+	if err := h.service.PurgeEntity(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err, "Failed to purge entity")
+		return
+	}
+
+	response.Success(c, nil, "Entity purged successfully")
+}
+
+// RestoreEndpoint brings back a deleted endpoint
+// POST /api/v1/trash/endpoints/:id/restore
+func (h *TrashHandler) RestoreEndpoint(c *gin.Context) {
+	endpoint, err := h.service.RestoreEndpoint(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "Failed to restore endpoint")
+		return
+	}
+
+	setETag(c, endpoint.Version)
+	response.Success(c, endpoint, "Endpoint restored successfully")
+}
+
+// PurgeEndpoint permanently removes a deleted endpoint
+// DELETE /api/v1/trash/endpoints/:id
+func (h *TrashHandler) PurgeEndpoint(c *gin.Context) {
+	if err := h.service.PurgeEndpoint(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err, "Failed to purge endpoint")
+		return
+	}
+
+	response.Success(c, nil, "Endpoint purged successfully")
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/gitprovider"
+	"github.com/yourusername/lambra/internal/service"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+type WebhookHandler struct {
+	service *service.GitService
+}
+
+func NewWebhookHandler(service *service.GitService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// HandleWebhook receives push and merge events from a git provider
+// POST /api/v1/webhooks/:provider
+func (h *WebhookHandler) HandleWebhook(c *gin.Context) {
+	provider, err := h.service.GetProvider(c.Param("provider"))
+	if err != nil {
+		response.NotFound(c, "Unknown git provider")
+		return
+	}
+
+	event, err := provider.ParseWebhook(c.Request)
+	if errors.Is(err, gitprovider.ErrInvalidSignature) {
+		response.Unauthorized(c, "Invalid webhook signature")
+		return
+	}
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook payload", err)
+		return
+	}
+
+	if event == nil {
+		response.Success(c, nil, "Event ignored")
+		return
+	}
+
+	if err := h.service.HandleWebhook(provider.Name(), event); err != nil {
+		respondError(c, err, "Failed to process webhook")
+		return
+	}
+
+	response.Success(c, nil, "Webhook processed successfully")
+}
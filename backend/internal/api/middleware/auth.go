@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+// Authenticator resolves the user of a bearer token (JWT or API token)
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*models.User, error)
+}
+
+// Auth rejects requests without a valid bearer token and puts the acting user
+// into the request context, where services read it with auth.UserFromContext
+func Auth(authenticator Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		authenticate(c, authenticator, token, ok)
+	}
+}
+
+// TokenAuth is Auth with the token in another header, bare or as a bearer token, for routes where
+// Authorization belongs to the caller's own API, like the mock server
+func TokenAuth(authenticator Authenticator, header string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value := strings.TrimSpace(c.GetHeader(header))
+		token, ok := bearerToken(value)
+		if !ok {
+			token, ok = value, value != ""
+		}
+		authenticate(c, authenticator, token, ok)
+	}
+}
+
+func authenticate(c *gin.Context, authenticator Authenticator, token string, ok bool) {
+	if !ok {
+		response.Unauthorized(c, "Authentication required")
+		c.Abort()
+		return
+	}
+
+	user, err := authenticator.Authenticate(c.Request.Context(), token)
+	if err != nil {
+		response.Unauthorized(c, "Invalid or expired token")
+		c.Abort()
+		return
+	}
+
+	c.Request = c.Request.WithContext(auth.WithUser(c.Request.Context(), user))
+	c.Next()
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+type fakeAuthenticator map[string]*models.User
+
+func (f fakeAuthenticator) Authenticate(ctx context.Context, token string) (*models.User, error) {
+	if user, ok := f[token]; ok {
+		return user, nil
+	}
+	return nil, errors.New("unknown token")
+}
+
+func TestAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Auth(fakeAuthenticator{"good": {Username: "alice"}}))
+	router.GET("/whoami", func(c *gin.Context) {
+		c.String(http.StatusOK, auth.Actor(c.Request.Context()))
+	})
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantBody   string
+	}{
+		{"valid token", "Bearer good", http.StatusOK, "alice"},
+		{"lowercase scheme", "bearer good", http.StatusOK, "alice"},
+		{"missing header", "", http.StatusUnauthorized, ""},
+		{"wrong scheme", "Basic good", http.StatusUnauthorized, ""},
+		{"unknown token", "Bearer bad", http.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestTokenAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(TokenAuth(fakeAuthenticator{"good": {Username: "alice"}}, "X-Lambra-Token"))
+	router.GET("/whoami", func(c *gin.Context) {
+		c.String(http.StatusOK, auth.Actor(c.Request.Context())+" "+c.GetHeader("Authorization"))
+	})
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+	}{
+		{"bare token", "good", http.StatusOK},
+		{"bearer token", "Bearer good", http.StatusOK},
+		{"missing header", "", http.StatusUnauthorized},
+		{"unknown token", "bad", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+			req.Header.Set("Authorization", "Bearer app-token")
+			if tt.token != "" {
+				req.Header.Set("X-Lambra-Token", tt.token)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && rec.Body.String() != "alice Bearer app-token" {
+				t.Errorf("body = %q, want the user and the untouched Authorization header", rec.Body.String())
+			}
+		})
+	}
+}
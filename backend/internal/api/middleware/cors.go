@@ -11,8 +11,8 @@ func CORS() gin.HandlerFunc {
 	return cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:5173", "http://localhost:3000"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID", "If-Match", "X-Lambra-Token", "X-Mock-Scenario"},
+		ExposeHeaders:    []string{"Content-Length", "X-Request-ID", "ETag", "X-Mock-Endpoint"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	})
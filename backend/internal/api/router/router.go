@@ -1,19 +1,31 @@
 package router
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	"github.com/yourusername/lambra/internal/api/handlers"
 	"github.com/yourusername/lambra/internal/api/middleware"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/config"
+	"github.com/yourusername/lambra/internal/deploy"
+	"github.com/yourusername/lambra/internal/gitprovider"
+	"github.com/yourusername/lambra/internal/rbac"
 	"github.com/yourusername/lambra/internal/repository"
+	"github.com/yourusername/lambra/internal/secrets"
 	"github.com/yourusername/lambra/internal/service"
 )
 
-func Setup(db *sqlx.DB) *gin.Engine {
+func Setup(db *sqlx.DB, cfg *config.Config) (*gin.Engine, error) {
 	router := gin.New()
 
 	// Middleware
 	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
 	router.Use(middleware.Logger())
 	router.Use(middleware.CORS())
 
@@ -21,27 +33,144 @@ func Setup(db *sqlx.DB) *gin.Engine {
 	projectRepo := repository.NewProjectRepository(db)
 	entityRepo := repository.NewEntityRepository(db)
 	endpointRepo := repository.NewEndpointRepository(db)
+	gitRepositoryRepo := repository.NewGitRepositoryRepository(db)
+	snapshotRepo := repository.NewSnapshotRepository(db)
+	deploymentRepo := repository.NewDeploymentRepository(db)
+	configRepo := repository.NewConfigRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	apiTokenRepo := repository.NewAPITokenRepository(db)
+	projectMemberRepo := repository.NewProjectMemberRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	templateRepo := repository.NewTemplateRepository(db)
+	teamRepo := repository.NewTeamRepository(db)
+	endpointRunRepo := repository.NewEndpointRunRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
+
+	// Initialize external clients
+	gitProviders := gitprovider.NewRegistry(cfg)
+
+	// There is no built-in executor yet, so deployments stay pending for an external executor to report on
+	var deployExecutor deploy.Executor
+
+	// Secrets stay disabled until an encryption key is configured
+	var secretCipher *secrets.Cipher
+	if cfg.Secrets.EncryptionKey != "" {
+		var err error
+		secretCipher, err = secrets.NewCipher(cfg.Secrets.EncryptionKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Login tokens do not survive a restart until a JWT secret is configured
+	jwtSecret := cfg.Auth.JWTSecret
+	if jwtSecret == "" {
+		random := make([]byte, 32)
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+		jwtSecret = hex.EncodeToString(random)
+		log.Println("Warning: JWT_SECRET is not set, using a random key for this process")
+	}
+	tokenManager := auth.NewTokenManager(jwtSecret, cfg.Auth.TokenTTL)
+
+	// Project roles live in Lambra's tables unless the external RBAC service is configured
+	var authorizer rbac.Authorizer = rbac.NewLocalAuthorizer(projectMemberRepo, projectRepo, userRepo, cfg.RBAC.Admins)
+	if cfg.RBAC.Provider == "remote" {
+		authorizer = rbac.NewRemoteAuthorizer(&cfg.RBAC)
+	}
 
 	// Initialize services
-	projectService := service.NewProjectService(projectRepo)
-	entityService := service.NewEntityService(entityRepo, projectRepo)
-	endpointService := service.NewEndpointService(endpointRepo, entityRepo, projectRepo)
-	generatorService := service.NewGeneratorService(projectRepo, entityRepo, endpointRepo)
+	authService := service.NewAuthService(userRepo, apiTokenRepo, tokenManager, &cfg.Auth)
+	auditService := service.NewAuditService(auditRepo, projectRepo, authorizer)
+	projectService := service.NewProjectService(projectRepo, userRepo, teamRepo, entityRepo, endpointRepo, snapshotRepo, unitOfWork, auditService, authorizer)
+	entityService := service.NewEntityService(entityRepo, projectRepo, unitOfWork, auditService, authorizer)
+	endpointService := service.NewEndpointService(endpointRepo, entityRepo, projectRepo, unitOfWork, auditService, authorizer)
+	manifestService := service.NewManifestService(projectRepo, entityRepo, endpointRepo, unitOfWork, auditService, authorizer)
+	templateService := service.NewTemplateService(templateRepo, teamRepo, unitOfWork, auditService)
+	teamService := service.NewTeamService(teamRepo, projectRepo, userRepo, templateRepo)
+	generatorService := service.NewGeneratorService(projectRepo, entityRepo, endpointRepo, configRepo, teamRepo, templateRepo, authorizer)
+	gitService := service.NewGitService(gitRepositoryRepo, projectRepo, generatorService, gitProviders, &cfg.Git, authorizer)
+	snapshotService := service.NewSnapshotService(snapshotRepo, projectRepo, entityRepo, endpointRepo, gitRepositoryRepo, generatorService, authorizer)
+	configService := service.NewConfigService(configRepo, projectRepo, secretCipher, authorizer)
+	deploymentService := service.NewDeploymentService(deploymentRepo, snapshotRepo, projectRepo, configService, deployExecutor, authorizer)
+	mockService := service.NewMockService(projectRepo, entityRepo, endpointRepo, authorizer)
+	endpointRunService, err := service.NewEndpointRunService(endpointRunRepo, endpointRepo, entityRepo, projectRepo, configRepo, deploymentRepo, authorizer, cfg.Tests.AllowedTargets)
+	if err != nil {
+		return nil, err
+	}
+	trashService := service.NewTrashService(projectRepo, entityRepo, endpointRepo, unitOfWork, auditService, authorizer)
+
+	// Deleted resources are purged once they outlive the retention period
+	if cfg.Trash.Retention > 0 {
+		go trashService.RunPurger(context.Background(), cfg.Trash.Retention, cfg.Trash.PurgeInterval)
+	}
 
 	// Initialize handlers
 	healthHandler := handlers.NewHealthHandler(db)
+	authHandler := handlers.NewAuthHandler(authService)
 	projectHandler := handlers.NewProjectHandler(projectService)
 	entityHandler := handlers.NewEntityHandler(entityService)
 	endpointHandler := handlers.NewEndpointHandler(endpointService)
+	endpointRunHandler := handlers.NewEndpointRunHandler(endpointRunService)
+	mockHandler := handlers.NewMockHandler(mockService)
 	generatorHandler := handlers.NewGeneratorHandler(generatorService)
+	gitRepositoryHandler := handlers.NewGitRepositoryHandler(gitService)
+	webhookHandler := handlers.NewWebhookHandler(gitService)
+	snapshotHandler := handlers.NewSnapshotHandler(snapshotService)
+	deploymentHandler := handlers.NewDeploymentHandler(deploymentService)
+	configHandler := handlers.NewConfigHandler(configService)
+	templateHandler := handlers.NewTemplateHandler(templateService)
+	manifestHandler := handlers.NewManifestHandler(manifestService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	teamHandler := handlers.NewTeamHandler(teamService)
+	trashHandler := handlers.NewTrashHandler(trashService)
 
 	// Health check routes
 	router.GET("/health", healthHandler.HealthCheck)
 	router.GET("/ready", healthHandler.Readiness)
 
+	// Mock APIs of projects; Authorization is left to the mocked endpoints, so the Lambra token
+	// goes in X-Lambra-Token
+	router.Any("/mock/:project/*path", middleware.TokenAuth(authService, "X-Lambra-Token"), mockHandler.Serve)
+
 	// API v1 routes
 	v1 := router.Group("/api/v1")
+
+	// Public routes
+	v1.POST("/auth/register", authHandler.Register)
+	v1.POST("/auth/login", authHandler.Login)
+
+	// Inbound git provider webhooks, verified by the provider signature
+	v1.POST("/webhooks/:provider", webhookHandler.HandleWebhook)
+
+	// Everything else requires a JWT or API token
+	v1.Use(middleware.Auth(authService))
 	{
+		// Current user and API tokens
+		authRoutes := v1.Group("/auth")
+		{
+			authRoutes.GET("/me", authHandler.Me)
+			authRoutes.POST("/tokens", authHandler.CreateAPIToken)
+			authRoutes.GET("/tokens", authHandler.GetAPITokens)
+			authRoutes.DELETE("/tokens/:id", authHandler.RevokeAPIToken)
+		}
+
+		// Teams
+		teams := v1.Group("/teams")
+		{
+			teams.POST("", teamHandler.CreateTeam)
+			teams.GET("", teamHandler.GetTeams)
+			teams.GET("/:id", teamHandler.GetTeam)
+			teams.PUT("/:id", teamHandler.UpdateTeam)
+			teams.DELETE("/:id", teamHandler.DeleteTeam)
+			teams.GET("/:id/stats", teamHandler.GetTeamStats)
+			teams.GET("/:id/projects", projectHandler.GetProjectsByTeam)
+			teams.GET("/:id/members", teamHandler.GetMembers)
+			teams.PUT("/:id/members/:username", teamHandler.SetMember)
+			teams.DELETE("/:id/members/:username", teamHandler.RemoveMember)
+		}
+
 		// Projects
 		projects := v1.Group("/projects")
 		{
@@ -50,11 +179,31 @@ func Setup(db *sqlx.DB) *gin.Engine {
 			projects.GET("/:id", projectHandler.GetProject)
 			projects.PUT("/:id", projectHandler.UpdateProject)
 			projects.DELETE("/:id", projectHandler.DeleteProject)
+			projects.POST("/:id/clone", projectHandler.CloneProject)
+			projects.GET("/:id/archive", generatorHandler.DownloadProjectArchive)
+			projects.GET("/:id/manifest", manifestHandler.ExportManifest)
+			projects.PUT("/:id/manifest", manifestHandler.ApplyManifest)
+			projects.GET("/:id/members", projectHandler.GetMembers)
+			projects.PUT("/:id/members/:username", projectHandler.SetMember)
+			projects.DELETE("/:id/members/:username", projectHandler.RemoveMember)
 
 			// Nested routes for project entities and endpoints
 			projects.POST("/:id/entities", entityHandler.CreateEntity)
+			projects.POST("/:id/entities/bulk", entityHandler.BulkCreateEntities)
 			projects.GET("/:id/entities", entityHandler.GetEntitiesByProject)
 			projects.GET("/:id/endpoints", endpointHandler.GetEndpointsByProject)
+			projects.POST("/:id/endpoints/bulk", endpointHandler.BulkCreateEndpoints)
+			projects.GET("/:id/repository", gitRepositoryHandler.GetRepositoryByProject)
+			projects.POST("/:id/repository/merge-requests", gitRepositoryHandler.PublishGeneratedCode)
+			projects.POST("/:id/snapshots", snapshotHandler.CreateSnapshot)
+			projects.GET("/:id/snapshots", snapshotHandler.GetSnapshotsByProject)
+			projects.POST("/:id/deployments", deploymentHandler.CreateDeployment)
+			projects.GET("/:id/deployments", deploymentHandler.GetDeploymentsByProject)
+			projects.GET("/:id/environments/:env/config", configHandler.GetVariables)
+			projects.PUT("/:id/environments/:env/config/:name", configHandler.SetVariable)
+			projects.DELETE("/:id/environments/:env/config/:name", configHandler.DeleteVariable)
+			projects.GET("/:id/audit-logs", auditHandler.GetProjectLogs)
+			projects.GET("/:id/trash", trashHandler.GetProjectTrash)
 		}
 
 		// Entities
@@ -73,6 +222,14 @@ func Setup(db *sqlx.DB) *gin.Engine {
 			endpoints.GET("/:id", endpointHandler.GetEndpoint)
 			endpoints.PUT("/:id", endpointHandler.UpdateEndpoint)
 			endpoints.DELETE("/:id", endpointHandler.DeleteEndpoint)
+			endpoints.POST("/:id/test", endpointRunHandler.TestEndpoint)
+			endpoints.GET("/:id/runs", endpointRunHandler.GetRuns)
+		}
+
+		// Git Repositories
+		repositories := v1.Group("/repositories")
+		{
+			repositories.POST("", gitRepositoryHandler.CreateRepository)
 		}
 
 		// Code Generation
@@ -84,21 +241,47 @@ func Setup(db *sqlx.DB) *gin.Engine {
 			generate.GET("/files/:id", generatorHandler.GetGeneratedFilesList)
 		}
 
-		// Deployments (will be implemented in Phase 4)
-		// deployments := v1.Group("/deployments")
-		// {
-		// 	deployments.GET("", deploymentHandler.GetDeployments)
-		// 	deployments.GET("/:id", deploymentHandler.GetDeployment)
-		// 	deployments.GET("/:id/logs", deploymentHandler.GetDeploymentLogs)
-		// }
-
-		// Snapshots (will be implemented in Phase 4)
-		// snapshots := v1.Group("/snapshots")
-		// {
-		// 	snapshots.GET("/project/:project_id", snapshotHandler.GetSnapshots)
-		// 	snapshots.POST("/:id/rollback", snapshotHandler.Rollback)
-		// }
+		// Deployments
+		deployments := v1.Group("/deployments")
+		{
+			deployments.GET("", deploymentHandler.GetDeployments)
+			deployments.GET("/:id", deploymentHandler.GetDeployment)
+			deployments.PUT("/:id/status", deploymentHandler.UpdateDeploymentStatus)
+			deployments.GET("/:id/logs", deploymentHandler.GetDeploymentLogs)
+		}
+
+		// Snapshots
+		snapshots := v1.Group("/snapshots")
+		{
+			snapshots.GET("/:id", snapshotHandler.GetSnapshot)
+			snapshots.GET("/:id/diff", snapshotHandler.DiffSnapshot)
+		}
+
+		// Trash of soft deleted resources
+		trash := v1.Group("/trash")
+		{
+			trash.GET("/projects", trashHandler.GetDeletedProjects)
+			trash.POST("/projects/:id/restore", trashHandler.RestoreProject)
+			trash.DELETE("/projects/:id", trashHandler.PurgeProject)
+			trash.POST("/entities/:id/restore", trashHandler.RestoreEntity)
+			trash.DELETE("/entities/:id", trashHandler.PurgeEntity)
+			trash.POST("/endpoints/:id/restore", trashHandler.RestoreEndpoint)
+			trash.DELETE("/endpoints/:id", trashHandler.PurgeEndpoint)
+		}
+
+		// Templates
+		templates := v1.Group("/templates")
+		{
+			templates.POST("", templateHandler.CreateTemplate)
+			templates.GET("", templateHandler.GetTemplates)
+			templates.GET("/:id", templateHandler.GetTemplate)
+			templates.PUT("/:id", templateHandler.UpdateTemplate)
+			templates.DELETE("/:id", templateHandler.DeleteTemplate)
+		}
+
+		// Audit trail of a single resource, kept after the resource is deleted
+		v1.GET("/audit-logs/:resource_type/:resource_id", auditHandler.GetResourceLogs)
 	}
 
-	return router
+	return router, nil
 }
@@ -0,0 +1,90 @@
+// Package apperror defines the kinds of domain errors repositories and services return,
+// so handlers can map them to status codes without matching on messages.
+package apperror
+
+import "errors"
+
+// Kinds of domain errors; test with errors.Is
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrConflict           = errors.New("conflict")
+	ErrValidation         = errors.New("validation failed")
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrForbidden          = errors.New("forbidden")
+	ErrUnauthorized       = errors.New("unauthorized")
+)
+
+// FieldError describes why one field of a request is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is a domain error of a kind with a message safe to show to clients
+type Error struct {
+	Kind    error
+	Message string
+	Fields  []FieldError
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap makes errors.Is match the kind of the error
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// New returns an error of a kind
+func New(kind error, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// NotFound returns the error of a missing resource, e.g. NotFound("project")
+func NotFound(resource string) *Error {
+	return New(ErrNotFound, resource+" not found")
+}
+
+// Conflict returns the error of a request clashing with the current state of a resource
+func Conflict(message string) *Error {
+	return New(ErrConflict, message)
+}
+
+// Validation returns the error of an invalid request with the fields at fault
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Kind: ErrValidation, Message: message, Fields: fields}
+}
+
+// PreconditionFailed returns the error of a request whose precondition does not hold
+func PreconditionFailed(message string) *Error {
+	return New(ErrPreconditionFailed, message)
+}
+
+// Forbidden returns the error of a caller lacking the permission an operation requires
+func Forbidden(message string) *Error {
+	return New(ErrForbidden, message)
+}
+
+// Unauthorized returns the error of a caller that could not be authenticated
+func Unauthorized(message string) *Error {
+	return New(ErrUnauthorized, message)
+}
+
+// Fields returns the field errors of a validation error anywhere in the chain of err
+func Fields(err error) []FieldError {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Fields
+	}
+	return nil
+}
+
+// Message returns the client message of the domain error in the chain of err, and false when there is none
+func Message(err error) (string, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Message, true
+	}
+	return "", false
+}
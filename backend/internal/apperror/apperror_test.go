@@ -0,0 +1,67 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"not found", NotFound("project"), ErrNotFound},
+		{"conflict", Conflict("namespace taken"), ErrConflict},
+		{"validation", Validation("invalid name"), ErrValidation},
+		{"precondition failed", PreconditionFailed("etag mismatch"), ErrPreconditionFailed},
+		{"forbidden", Forbidden("no role"), ErrForbidden},
+		{"unauthorized", Unauthorized("bad token"), ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("failed to load: %w", tt.err)
+			if !errors.Is(wrapped, tt.kind) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", wrapped, tt.kind)
+			}
+			if !errors.Is(wrapped, tt.err) {
+				t.Errorf("errors.Is(%v, sentinel) = false, want true", wrapped)
+			}
+			for _, other := range []error{ErrNotFound, ErrConflict, ErrValidation, ErrPreconditionFailed, ErrForbidden, ErrUnauthorized} {
+				if other != tt.kind && errors.Is(wrapped, other) {
+					t.Errorf("errors.Is(%v, %v) = true, want false", wrapped, other)
+				}
+			}
+		})
+	}
+}
+
+func TestNotFoundMessage(t *testing.T) {
+	if got := NotFound("project").Error(); got != "project not found" {
+		t.Errorf("Error() = %q, want %q", got, "project not found")
+	}
+}
+
+func TestMessageAndFields(t *testing.T) {
+	err := fmt.Errorf("failed to create project: %w",
+		Validation("team not found", FieldError{Field: "team_id", Message: "references an unknown team"}))
+
+	message, ok := Message(err)
+	if !ok || message != "team not found" {
+		t.Errorf("Message() = %q, %v, want %q, true", message, ok, "team not found")
+	}
+
+	fields := Fields(err)
+	if len(fields) != 1 || fields[0].Field != "team_id" {
+		t.Errorf("Fields() = %+v, want the team_id field", fields)
+	}
+
+	if _, ok := Message(errors.New("connection refused")); ok {
+		t.Error("Message() of a plain error reports a domain error")
+	}
+	if Fields(errors.New("connection refused")) != nil {
+		t.Error("Fields() of a plain error is not nil")
+	}
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Change is the value of one field before and after an operation; nil means absent
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// ignoredFields are bookkeeping fields that change on every write
+var ignoredFields = map[string]bool{
+	"updated_at": true,
+	"updated_by": true,
+}
+
+// Diff compares the JSON representations of two values and returns the changed fields keyed
+// by path, e.g. "fields.2.type". Either value may be nil for creates and deletes.
+func Diff(before, after interface{}) (map[string]Change, error) {
+	b, err := normalize(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := normalize(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]Change)
+	diffValues("", b, a, changes)
+	return changes, nil
+}
+
+// normalize converts a value into its generic JSON form (maps, slices, strings, float64s, bools)
+func normalize(v interface{}) (interface{}, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit value: %w", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit value: %w", err)
+	}
+	return generic, nil
+}
+
+func diffValues(path string, before, after interface{}, changes map[string]Change) {
+	switch b := before.(type) {
+	case map[string]interface{}:
+		if a, ok := after.(map[string]interface{}); ok {
+			for _, key := range unionKeys(b, a) {
+				if path == "" && ignoredFields[key] {
+					continue
+				}
+				diffValues(join(path, key), b[key], a[key], changes)
+			}
+			return
+		}
+	case []interface{}:
+		// Elements are compared by position; a change in length records the whole array
+		if a, ok := after.([]interface{}); ok && len(a) == len(b) {
+			for i := range b {
+				diffValues(join(path, strconv.Itoa(i)), b[i], a[i], changes)
+			}
+			return
+		}
+	}
+
+	// A created or deleted object is recorded field by field
+	if before == nil {
+		if a, ok := after.(map[string]interface{}); ok && path == "" {
+			diffValues(path, map[string]interface{}{}, a, changes)
+			return
+		}
+	}
+	if after == nil {
+		if b, ok := before.(map[string]interface{}); ok && path == "" {
+			diffValues(path, b, map[string]interface{}{}, changes)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		changes[path] = Change{Before: before, After: after}
+	}
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+type entity struct {
+	Name      string  `json:"name"`
+	Fields    []field `json:"fields"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func TestDiff_Update(t *testing.T) {
+	before := &entity{Name: "User", Fields: []field{{"id", "int"}, {"age", "string"}}, UpdatedAt: "t1"}
+	after := &entity{Name: "User", Fields: []field{{"id", "int"}, {"age", "int"}}, UpdatedAt: "t2"}
+
+	changes, err := Diff(before, after)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	want := map[string]Change{"fields.1.type": {Before: "string", After: "int"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Diff() = %v, want %v", changes, want)
+	}
+}
+
+func TestDiff_LengthChangeRecordsWholeArray(t *testing.T) {
+	before := &entity{Fields: []field{{"id", "int"}}}
+	after := &entity{Fields: []field{{"id", "int"}, {"email", "string"}}}
+
+	changes, _ := Diff(before, after)
+	change, ok := changes["fields"]
+	if !ok || len(changes) != 1 {
+		t.Fatalf("Diff() = %v, want a single fields change", changes)
+	}
+	if len(change.After.([]interface{})) != 2 {
+		t.Errorf("after = %v", change.After)
+	}
+}
+
+func TestDiff_CreateAndDelete(t *testing.T) {
+	value := &entity{Name: "User", Fields: []field{}}
+
+	created, _ := Diff(nil, value)
+	if created["name"].Before != nil || created["name"].After != "User" {
+		t.Errorf("create diff = %v", created)
+	}
+	if _, ok := created["updated_at"]; ok {
+		t.Errorf("create diff should skip bookkeeping fields: %v", created)
+	}
+
+	var missing *entity
+	deleted, _ := Diff(value, missing)
+	if deleted["name"].Before != "User" || deleted["name"].After != nil {
+		t.Errorf("delete diff = %v", deleted)
+	}
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// APITokenPrefix marks long-lived API tokens so they can be told apart from JWTs
+const APITokenPrefix = "lmb_"
+
+// apiTokenDisplayLength is the number of leading characters kept to identify a token in listings
+const apiTokenDisplayLength = 12
+
+// GenerateAPIToken creates a random API token. Only the returned hash is stored;
+// the token itself is shown to the user once.
+func GenerateAPIToken() (token, hash, displayPrefix string, err error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate API token: %w", err)
+	}
+
+	token = APITokenPrefix + hex.EncodeToString(random)
+	return token, HashAPIToken(token), token[:apiTokenDisplayLength], nil
+}
+
+// HashAPIToken returns the hex encoded SHA-256 of an API token
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIToken reports whether a bearer token is an API token rather than a JWT
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
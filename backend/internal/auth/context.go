@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// SystemActor is recorded in audit columns for work not triggered by a user (webhooks, background jobs)
+const SystemActor = "system"
+
+type contextKey struct{}
+
+// WithUser returns a context carrying the authenticated user
+func WithUser(ctx context.Context, user *models.User) context.Context {
+	return context.WithValue(ctx, contextKey{}, user)
+}
+
+// UserFromContext returns the authenticated user of a context
+func UserFromContext(ctx context.Context) (*models.User, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	user, ok := ctx.Value(contextKey{}).(*models.User)
+	return user, ok && user != nil
+}
+
+// Actor returns the name recorded in created_by/updated_by/deleted_by for a context
+func Actor(ctx context.Context) string {
+	if user, ok := UserFromContext(ctx); ok {
+		return user.Username
+	}
+	return SystemActor
+}
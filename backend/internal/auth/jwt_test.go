@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenManager_IssueAndParse(t *testing.T) {
+	m := NewTokenManager("test-secret", time.Hour)
+
+	token, expiresAt, err := m.Issue("0190a6e2-7d1c-7000-8000-000000000001", "alice")
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if time.Until(expiresAt) <= 0 {
+		t.Errorf("expiresAt = %v, want in the future", expiresAt)
+	}
+
+	claims, err := m.Parse(token)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if claims.Subject != "0190a6e2-7d1c-7000-8000-000000000001" || claims.Username != "alice" {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestTokenManager_Rejects(t *testing.T) {
+	m := NewTokenManager("test-secret", time.Hour)
+	token, _, _ := m.Issue("user-uuid", "alice")
+
+	other := NewTokenManager("other-secret", time.Hour)
+	if _, err := other.Parse(token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Parse() with wrong secret error = %v, want ErrInvalidToken", err)
+	}
+
+	parts := strings.Split(token, ".")
+	forged := parts[0] + "." + encodeSegment([]byte(`{"sub":"admin","iss":"lambra","exp":9999999999}`)) + "." + parts[2]
+	if _, err := m.Parse(forged); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Parse() forged payload error = %v, want ErrInvalidToken", err)
+	}
+
+	if _, err := m.Parse("not-a-token"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Parse() malformed error = %v, want ErrInvalidToken", err)
+	}
+
+	m.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := m.Parse(token); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("Parse() expired error = %v, want ErrExpiredToken", err)
+	}
+}
+
+func TestGenerateAPIToken(t *testing.T) {
+	token, hash, prefix, err := GenerateAPIToken()
+	if err != nil {
+		t.Fatalf("GenerateAPIToken() error = %v", err)
+	}
+	if !IsAPIToken(token) || !strings.HasPrefix(token, prefix) {
+		t.Errorf("token = %q, prefix = %q", token, prefix)
+	}
+	if hash != HashAPIToken(token) || strings.Contains(hash, token) {
+		t.Errorf("hash = %q does not match token", hash)
+	}
+}
+
+func TestPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	if err := CheckPassword(hash, "correct horse"); err != nil {
+		t.Errorf("CheckPassword() error = %v", err)
+	}
+	if err := CheckPassword(hash, "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("CheckPassword() error = %v, want ErrInvalidCredentials", err)
+	}
+}
@@ -0,0 +1,250 @@
+// Package cli implements lambra, the command-line client of the Lambra API.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/lambra/internal/apperror"
+)
+
+// Exit codes of the lambra command
+const (
+	ExitOK      = 0
+	ExitFailure = 1 // The API rejected the command, it failed, or a deployment failed
+	ExitUsage   = 2 // The command line is invalid
+)
+
+// Output formats
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+// Environment variables providing defaults of the global flags
+const (
+	EnvURL   = "LAMBRA_URL"
+	EnvToken = "LAMBRA_TOKEN"
+)
+
+const defaultURL = "http://localhost:8080"
+
+// usageError is an invalid command line
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+func usagef(format string, args ...interface{}) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// errSilent fails a command that already reported why
+var errSilent = errors.New("command failed")
+
+// command is a subcommand of lambra
+type command struct {
+	usage   string // Arguments after the command name
+	summary string
+	run     func(a *app, args []string) error
+}
+
+// commands are keyed by their name, e.g. "projects list"
+var commands = map[string]*command{
+	"projects list": {
+		usage:   "[--search TEXT] [--team ID] [--status STATUS] [--page N] [--limit N]",
+		summary: "List projects",
+		run:     runProjectsList,
+	},
+	"projects create": {
+		usage:   "--name NAME --namespace NAMESPACE [--team ID] [--description TEXT] [--module-path PATH] [--dialect DIALECT]",
+		summary: "Create a project",
+		run:     runProjectsCreate,
+	},
+	"apply": {
+		usage:   "--project ID [--file lambra.yaml] [--dry-run]",
+		summary: "Apply a manifest to a project",
+		run:     runApply,
+	},
+	"export": {
+		usage:   "--project ID [--file PATH] [--format yaml|json]",
+		summary: "Export the manifest of a project",
+		run:     runExport,
+	},
+	"generate": {
+		usage:   "--project ID [--file PATH]",
+		summary: "Generate the code of a project and download it as a zip archive",
+		run:     runGenerate,
+	},
+	"render": {
+		usage:   "[--file lambra.yaml] [--dir DIR] [--templates DIR]",
+		summary: "Generate the code of a manifest locally, without the API",
+		run:     runRender,
+	},
+	"snapshots list": {
+		usage:   "--project ID [--page N] [--limit N]",
+		summary: "List the snapshots of a project",
+		run:     runSnapshotsList,
+	},
+	"snapshots diff": {
+		usage:   "SNAPSHOT [--to SNAPSHOT]",
+		summary: "Show the changes from a snapshot to another one or to the current project",
+		run:     runSnapshotsDiff,
+	},
+	"logs": {
+		usage:   "DEPLOYMENT [--follow] [--interval DURATION]",
+		summary: "Show the logs of a deployment",
+		run:     runLogs,
+	},
+}
+
+// app is the state of one lambra invocation
+type app struct {
+	name   string
+	stdout io.Writer
+	stderr io.Writer
+	stdin  io.Reader
+	getenv func(string) string
+	sleep  func(time.Duration)
+
+	url    string
+	token  string
+	output string
+	client *Client
+}
+
+// Run executes the lambra command line args and returns its exit code
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	a := &app{
+		stdout: stdout,
+		stderr: stderr,
+		stdin:  stdin,
+		getenv: os.Getenv,
+		sleep:  time.Sleep,
+	}
+	return a.run(args)
+}
+
+func (a *app) run(args []string) int {
+	cmd, name, rest := lookup(args)
+	if cmd == nil {
+		if len(args) > 0 && args[0] != "help" && args[0] != "-h" && args[0] != "--help" {
+			fmt.Fprintf(a.stderr, "lambra: unknown command %q\n\n", strings.Join(args, " "))
+			a.printUsage()
+			return ExitUsage
+		}
+		a.printUsage()
+		return ExitOK
+	}
+	a.name = name
+
+	err := cmd.run(a, rest)
+	var usageErr *usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(a.stderr, "lambra %s: %s\nusage: lambra %s %s\n", name, usageErr.message, name, cmd.usage)
+		return ExitUsage
+	case errors.Is(err, errSilent):
+		return ExitFailure
+	}
+
+	fmt.Fprintf(a.stderr, "lambra %s: %s\n", name, err)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		for _, field := range apiErr.Errors {
+			fmt.Fprintf(a.stderr, "  %s: %s\n", field.Field, field.Message)
+		}
+	}
+	// Offline commands such as render validate locally
+	for _, field := range apperror.Fields(err) {
+		fmt.Fprintf(a.stderr, "  %s: %s\n", field.Field, field.Message)
+	}
+	return ExitFailure
+}
+
+// lookup returns the command named by the leading arguments, with its name and remaining arguments
+func lookup(args []string) (*command, string, []string) {
+	if len(args) >= 2 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return cmd, args[0] + " " + args[1], args[2:]
+		}
+	}
+	if len(args) >= 1 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd, args[0], args[1:]
+		}
+	}
+	return nil, "", nil
+}
+
+func (a *app) printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(a.stderr, "usage: lambra <command> [flags]")
+	fmt.Fprintln(a.stderr, "\ncommands:")
+	for _, name := range names {
+		fmt.Fprintf(a.stderr, "  %-18s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(a.stderr, "\nglobal flags:")
+	fmt.Fprintf(a.stderr, "  --url URL          API address (%s, default %s)\n", EnvURL, defaultURL)
+	fmt.Fprintf(a.stderr, "  --token TOKEN      API token (%s)\n", EnvToken)
+	fmt.Fprintln(a.stderr, "  -o, --output FMT   table or json (default table)")
+}
+
+// flags returns the flag set of the current command with the global flags registered
+func (a *app) flags() *flag.FlagSet {
+	fs := flag.NewFlagSet("lambra "+a.name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+
+	url := a.getenv(EnvURL)
+	if url == "" {
+		url = defaultURL
+	}
+	fs.StringVar(&a.url, "url", url, "API address")
+	fs.StringVar(&a.token, "token", a.getenv(EnvToken), "API token")
+	fs.StringVar(&a.output, "output", OutputTable, "output format: table or json")
+	fs.StringVar(&a.output, "o", OutputTable, "output format: table or json")
+	return fs
+}
+
+// parse parses the arguments of the current command, allowing flags after positional
+// arguments, and returns the positional arguments
+func (a *app) parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, usagef("%s", err)
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if a.output != OutputTable && a.output != OutputJSON {
+		return nil, usagef("invalid output %q, must be table or json", a.output)
+	}
+	a.client = NewClient(a.url, a.token)
+	return positional, nil
+}
@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/lambra/internal/manifest"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// runTest runs a command line against a fake API backed by the given mux
+func runTest(t *testing.T, mux *http.ServeMux, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var stdout, stderr bytes.Buffer
+	a := &app{
+		stdout: &stdout,
+		stderr: &stderr,
+		stdin:  strings.NewReader(stdin),
+		getenv: func(key string) string {
+			switch key {
+			case EnvURL:
+				return server.URL
+			case EnvToken:
+				return "test-token"
+			}
+			return ""
+		},
+		sleep: func(time.Duration) {},
+	}
+	code := a.run(args)
+	return code, stdout.String(), stderr.String()
+}
+
+func writeData(w http.ResponseWriter, status int, data interface{}, pagination *Pagination) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": data, "pagination": pagination})
+}
+
+func TestRun_ProjectsList(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/projects", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q, want Bearer test-token", got)
+		}
+		if got := r.URL.Query().Get("search"); got != "user" {
+			t.Errorf("search = %q, want user", got)
+		}
+		writeData(w, http.StatusOK, []Project{{ID: "p-1", Name: "User Service", Namespace: "users", Status: "draft"}}, &Pagination{Page: 1, Limit: 20, TotalItems: 1, TotalPages: 1})
+	})
+
+	code, stdout, stderr := runTest(t, mux, "", "projects", "list", "--search", "user")
+	if code != ExitOK {
+		t.Fatalf("exit code = %d, want %d; stderr = %s", code, ExitOK, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "User Service") {
+		t.Errorf("stdout = %q, want a header and one project", stdout)
+	}
+
+	code, stdout, _ = runTest(t, mux, "", "projects", "list", "--search", "user", "-o", "json")
+	var projects []Project
+	if code != ExitOK || json.Unmarshal([]byte(stdout), &projects) != nil || len(projects) != 1 {
+		t.Errorf("json output = %q, exit code %d", stdout, code)
+	}
+}
+
+func TestRun_ApplyDryRunFromStdin(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/projects/p-1/manifest", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		if got := r.URL.Query().Get("dry_run"); got != "true" {
+			t.Errorf("dry_run = %q, want true", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "kind: Project\n" {
+			t.Errorf("body = %q", body)
+		}
+		writeData(w, http.StatusOK, manifest.Plan{Changes: []manifest.Change{
+			{Action: manifest.ActionCreate, Resource: manifest.ResourceEntity, Entity: "User"},
+		}}, nil)
+	})
+
+	code, stdout, stderr := runTest(t, mux, "kind: Project\n", "apply", "-p", "p-1", "-f", "-", "--dry-run")
+	if code != ExitOK {
+		t.Fatalf("exit code = %d, want %d; stderr = %s", code, ExitOK, stderr)
+	}
+	if !strings.Contains(stdout, "create") || !strings.Contains(stdout, "User") {
+		t.Errorf("stdout = %q, want the planned change", stdout)
+	}
+}
+
+func TestRun_APIErrorFails(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"title":"Bad Request","status":400,"detail":"Invalid request body","errors":[{"field":"namespace","message":"is required"}]}`))
+	})
+
+	code, _, stderr := runTest(t, mux, "", "projects", "create", "--name", "User Service", "--namespace", "users")
+	if code != ExitFailure {
+		t.Errorf("exit code = %d, want %d", code, ExitFailure)
+	}
+	if !strings.Contains(stderr, "Invalid request body (400)") || !strings.Contains(stderr, "namespace: is required") {
+		t.Errorf("stderr = %q, want the problem detail and field errors", stderr)
+	}
+}
+
+func TestRun_UsageErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	for _, args := range [][]string{
+		{"unknown"},
+		{"apply"},
+		{"projects", "list", "--output", "yaml"},
+		{"snapshots", "diff"},
+		{"logs", "--bogus", "d-1"},
+	} {
+		if code, _, _ := runTest(t, mux, "", args...); code != ExitUsage {
+			t.Errorf("%v: exit code = %d, want %d", args, code, ExitUsage)
+		}
+	}
+}
+
+func TestRun_LogsFollowUntilFailed(t *testing.T) {
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/deployments/d-1", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		status := models.DeploymentStatusDeploying
+		if polls > 1 {
+			status = models.DeploymentStatusFailed
+		}
+		writeData(w, http.StatusOK, Deployment{ID: "d-1", Status: status, ErrorMessage: "rollout timed out"}, nil)
+	})
+	mux.HandleFunc("/api/v1/deployments/d-1/logs", func(w http.ResponseWriter, r *http.Request) {
+		logs := []models.DeploymentLog{{Level: "info", Message: "applying manifests"}}
+		if polls > 1 {
+			logs = append(logs, models.DeploymentLog{Level: "error", Message: "rollout timed out"})
+		}
+		writeData(w, http.StatusOK, logs, nil)
+	})
+
+	code, stdout, stderr := runTest(t, mux, "", "logs", "d-1", "--follow")
+	if code != ExitFailure {
+		t.Errorf("exit code = %d, want %d", code, ExitFailure)
+	}
+	if strings.Count(stdout, "applying manifests") != 1 || strings.Count(stdout, "rollout timed out") != 1 {
+		t.Errorf("stdout = %q, want each entry once", stdout)
+	}
+	if !strings.Contains(stderr, "Deployment failed: rollout timed out") {
+		t.Errorf("stderr = %q", stderr)
+	}
+}
+
+func TestRun_RenderOffline(t *testing.T) {
+	dir := t.TempDir()
+	templates := filepath.Join(dir, "templates")
+	if err := os.Mkdir(templates, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(templates, "model.tmpl"), []byte("// custom model for {{ .EntityName }}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifestYAML := `apiVersion: lambra/v1
+kind: Project
+metadata: {name: shop, namespace: shop}
+spec:
+  module_path: example.com/shop
+  entities:
+    - name: User
+      table_name: users
+      fields: [{name: email, type: string, required: true}]
+      endpoints: [{name: Get user, method: GET, path: /users/:id}]
+`
+	out := filepath.Join(dir, "out")
+
+	// No server is involved: the mux fails any request
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	code, _, stderr := runTest(t, mux, manifestYAML, "render", "-f", "-", "--dir", out, "--templates", templates)
+	if code != ExitOK {
+		t.Fatalf("exit code = %d, want %d; stderr = %s", code, ExitOK, stderr)
+	}
+
+	model, err := os.ReadFile(filepath.Join(out, "models", "user.go"))
+	if err != nil || string(model) != "// custom model for User\n" {
+		t.Errorf("models/user.go = %q, %v; want the custom template", model, err)
+	}
+	router, err := os.ReadFile(filepath.Join(out, "api", "router", "router.go"))
+	if err != nil || !strings.Contains(string(router), "example.com/shop/internal/api/handlers") {
+		t.Errorf("api/router/router.go = %q, %v; want imports of the module path", router, err)
+	}
+}
+
+func TestRun_RenderRejectsInvalidManifest(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  string
+		field string
+	}{
+		{
+			name: "conflicting routes",
+			spec: `
+    - name: User
+      table_name: users
+      fields: [{name: email, type: string}]
+      endpoints:
+        - {name: Get user, method: GET, path: /users/:id}
+        - {name: Get me, method: GET, path: /users/me}`,
+			field: "spec.entities[0].endpoints[1].path",
+		},
+		{
+			name: "invalid response schema",
+			spec: `
+    - name: User
+      table_name: users
+      fields: [{name: email, type: string}]
+      endpoints:
+        - {name: Get user, method: GET, path: /users/:id, response_schema: {type: strin}}`,
+			field: "spec.entities[0].endpoints[0].response_schema",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifestYAML := "apiVersion: lambra/v1\nkind: Project\nmetadata: {name: shop, namespace: shop}\nspec:\n  entities:" + tt.spec + "\n"
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			})
+
+			out := filepath.Join(t.TempDir(), "out")
+			code, _, stderr := runTest(t, mux, manifestYAML, "render", "-f", "-", "--dir", out)
+			if code != ExitFailure {
+				t.Fatalf("exit code = %d, want %d; stderr = %s", code, ExitFailure, stderr)
+			}
+			if !strings.Contains(stderr, tt.field) {
+				t.Errorf("stderr = %q, want an error of %s", stderr, tt.field)
+			}
+			if _, err := os.Stat(out); !os.IsNotExist(err) {
+				t.Errorf("render wrote %s for an invalid manifest", out)
+			}
+		})
+	}
+}
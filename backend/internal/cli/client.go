@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/yourusername/lambra/internal/manifest"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/pkg/response"
+)
+
+// Client calls the Lambra API with an API token
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// NewClient creates a client of the API at baseURL, e.g. http://localhost:8080
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// APIError is a problem response of the API
+type APIError struct {
+	Status int                   `json:"status"`
+	Title  string                `json:"title"`
+	Detail string                `json:"detail"`
+	Errors []response.FieldError `json:"errors"`
+}
+
+func (e *APIError) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("%s (%d)", e.Detail, e.Status)
+	}
+	return fmt.Sprintf("%s (%d)", e.Title, e.Status)
+}
+
+// Pagination of a list response
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	TotalItems int64 `json:"total_items"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// envelope is the body of a successful JSON response
+type envelope struct {
+	Message    string          `json:"message"`
+	Data       json.RawMessage `json:"data"`
+	Pagination *Pagination     `json:"pagination"`
+}
+
+// Project as returned by the API
+type Project struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Namespace   string    `json:"namespace"`
+	Status      string    `json:"status"`
+	TeamID      string    `json:"team_id,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Version     int64     `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Snapshot as returned by the API
+type Snapshot struct {
+	ID            string    `json:"id"`
+	Version       string    `json:"version"`
+	Status        string    `json:"status"`
+	GitCommitHash string    `json:"git_commit_hash"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Deployment as returned by the API
+type Deployment struct {
+	ID           string `json:"id"`
+	Environment  string `json:"environment"`
+	Status       string `json:"status"`
+	Version      string `json:"version"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// Finished reports whether the deployment reached a terminal status
+func (d *Deployment) Finished() bool {
+	return d.Status == models.DeploymentStatusSuccess || d.Status == models.DeploymentStatusFailed
+}
+
+// do sends a request and returns the response of a 2xx status; other statuses become an *APIError
+func (c *Client) do(method, path string, query url.Values, body io.Reader, contentType string) (*http.Response, error) {
+	target := c.baseURL + "/api/v1" + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	apiErr := &APIError{Status: resp.StatusCode, Title: http.StatusText(resp.StatusCode)}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	json.Unmarshal(data, apiErr)
+	apiErr.Status = resp.StatusCode
+	return nil, apiErr
+}
+
+// call sends in as JSON and decodes the data of the JSON response into out
+func (c *Client) call(method, path string, query url.Values, in, out interface{}) (*Pagination, error) {
+	if in == nil {
+		return c.send(method, path, query, nil, "", out)
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	return c.send(method, path, query, bytes.NewReader(data), "application/json", out)
+}
+
+// send sends a request and decodes the data of its JSON response into out
+func (c *Client) send(method, path string, query url.Values, body io.Reader, contentType string, out interface{}) (*Pagination, error) {
+	resp, err := c.do(method, path, query, body, contentType)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return nil, fmt.Errorf("invalid response from %s: %w", path, err)
+	}
+	if out != nil && len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return nil, fmt.Errorf("invalid response from %s: %w", path, err)
+		}
+	}
+	return env.Pagination, nil
+}
+
+// ListProjects lists the projects visible to the token, filtered by query
+func (c *Client) ListProjects(query url.Values) ([]Project, *Pagination, error) {
+	var projects []Project
+	pagination, err := c.call(http.MethodGet, "/projects", query, nil, &projects)
+	return projects, pagination, err
+}
+
+// CreateProject creates a project
+func (c *Client) CreateProject(req *models.CreateProjectRequest) (*Project, error) {
+	var project Project
+	_, err := c.call(http.MethodPost, "/projects", nil, req, &project)
+	return &project, err
+}
+
+// ApplyManifest applies a YAML or JSON manifest to a project, or only plans it with dryRun
+func (c *Client) ApplyManifest(projectID string, data []byte, dryRun bool) (*manifest.Plan, error) {
+	query := url.Values{}
+	if dryRun {
+		query.Set("dry_run", "true")
+	}
+
+	var plan manifest.Plan
+	_, err := c.send(http.MethodPut, "/projects/"+url.PathEscape(projectID)+"/manifest", query, bytes.NewReader(data), "application/yaml", &plan)
+	return &plan, err
+}
+
+// ExportManifest writes the manifest of a project in a format to w
+func (c *Client) ExportManifest(projectID, format string, w io.Writer) error {
+	return c.download("/projects/"+url.PathEscape(projectID)+"/manifest", url.Values{"format": {format}}, w)
+}
+
+// DownloadArchive generates the code of a project and writes it as a zip archive to w
+func (c *Client) DownloadArchive(projectID string, w io.Writer) error {
+	return c.download("/projects/"+url.PathEscape(projectID)+"/archive", nil, w)
+}
+
+func (c *Client) download(path string, query url.Values, w io.Writer) error {
+	resp, err := c.do(http.MethodGet, path, query, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// ListSnapshots lists the snapshots of a project
+func (c *Client) ListSnapshots(projectID string, query url.Values) ([]Snapshot, *Pagination, error) {
+	var snapshots []Snapshot
+	pagination, err := c.call(http.MethodGet, "/projects/"+url.PathEscape(projectID)+"/snapshots", query, nil, &snapshots)
+	return snapshots, pagination, err
+}
+
+// DiffSnapshot returns the changes from a snapshot to another one, or to the current project state when to is empty
+func (c *Client) DiffSnapshot(id, to string) (*manifest.Plan, error) {
+	query := url.Values{}
+	if to != "" {
+		query.Set("to", to)
+	}
+
+	var plan manifest.Plan
+	_, err := c.call(http.MethodGet, "/snapshots/"+url.PathEscape(id)+"/diff", query, nil, &plan)
+	return &plan, err
+}
+
+// GetDeployment returns a deployment
+func (c *Client) GetDeployment(id string) (*Deployment, error) {
+	var deployment Deployment
+	_, err := c.call(http.MethodGet, "/deployments/"+url.PathEscape(id), nil, nil, &deployment)
+	return &deployment, err
+}
+
+// DeploymentLogs returns one page of the logs of a deployment, oldest first
+func (c *Client) DeploymentLogs(id string, page, limit int) ([]models.DeploymentLog, *Pagination, error) {
+	query := url.Values{"page": {fmt.Sprint(page)}, "limit": {fmt.Sprint(limit)}}
+
+	var logs []models.DeploymentLog
+	pagination, err := c.call(http.MethodGet, "/deployments/"+url.PathEscape(id)+"/logs", query, nil, &logs)
+	return logs, pagination, err
+}
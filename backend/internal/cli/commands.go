@@ -0,0 +1,292 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/lambra/internal/manifest"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// logsPageSize is the number of log entries requested at once
+const logsPageSize = 100
+
+func runProjectsList(a *app, args []string) error {
+	fs := a.flags()
+	search := fs.String("search", "", "search in name and description")
+	team := fs.String("team", "", "only projects of a team")
+	status := fs.String("status", "", "only projects with a status")
+	page := fs.Int("page", 1, "page")
+	limit := fs.Int("limit", 20, "projects per page")
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+
+	query := url.Values{"page": {strconv.Itoa(*page)}, "limit": {strconv.Itoa(*limit)}}
+	for key, value := range map[string]string{"search": *search, "team_id": *team, "status": *status} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	projects, _, err := a.client.ListProjects(query)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(projects))
+	for i, p := range projects {
+		rows[i] = []string{p.ID, p.Name, p.Namespace, p.Status, formatTime(p.CreatedAt)}
+	}
+	return a.print(projects, []string{"ID", "NAME", "NAMESPACE", "STATUS", "CREATED"}, rows)
+}
+
+func runProjectsCreate(a *app, args []string) error {
+	fs := a.flags()
+	var req models.CreateProjectRequest
+	fs.StringVar(&req.Name, "name", "", "project name")
+	fs.StringVar(&req.Namespace, "namespace", "", "Kubernetes namespace")
+	fs.StringVar(&req.TeamUUID, "team", "", "owning team")
+	fs.StringVar(&req.Description, "description", "", "description")
+	fs.StringVar(&req.ModulePath, "module-path", "", "Go module path of the generated service")
+	fs.StringVar(&req.Dialect, "dialect", "", "SQL dialect: postgres or mysql")
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if req.Name == "" || req.Namespace == "" {
+		return usagef("--name and --namespace are required")
+	}
+
+	project, err := a.client.CreateProject(&req)
+	if err != nil {
+		return err
+	}
+
+	return a.print(project, []string{"ID", "NAME", "NAMESPACE", "STATUS"},
+		[][]string{{project.ID, project.Name, project.Namespace, project.Status}})
+}
+
+func runApply(a *app, args []string) error {
+	fs := a.flags()
+	project := projectFlag(fs)
+	file := fs.String("file", manifest.FileName, "manifest to apply, - for stdin")
+	fs.StringVar(file, "f", manifest.FileName, "manifest to apply, - for stdin")
+	dryRun := fs.Bool("dry-run", false, "only show the changes")
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *project == "" {
+		return usagef("--project is required")
+	}
+
+	data, err := a.readFile(*file)
+	if err != nil {
+		return err
+	}
+
+	plan, err := a.client.ApplyManifest(*project, data, *dryRun)
+	if err != nil {
+		return err
+	}
+	return a.printPlan(plan)
+}
+
+func runExport(a *app, args []string) error {
+	fs := a.flags()
+	project := projectFlag(fs)
+	file := fs.String("file", "", "write the manifest to a file instead of stdout")
+	fs.StringVar(file, "f", "", "write the manifest to a file instead of stdout")
+	format := fs.String("format", manifest.FormatYAML, "manifest format: yaml or json")
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *project == "" {
+		return usagef("--project is required")
+	}
+	if *format != manifest.FormatYAML && *format != manifest.FormatJSON {
+		return usagef("invalid format %q, must be yaml or json", *format)
+	}
+
+	return a.writeFile(*file, func(w io.Writer) error {
+		return a.client.ExportManifest(*project, *format, w)
+	})
+}
+
+func runGenerate(a *app, args []string) error {
+	fs := a.flags()
+	project := projectFlag(fs)
+	file := fs.String("file", "", "archive path, default <project>.zip")
+	fs.StringVar(file, "f", "", "archive path, default <project>.zip")
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *project == "" {
+		return usagef("--project is required")
+	}
+	if *file == "" {
+		*file = *project + ".zip"
+	}
+
+	if err := a.writeFile(*file, func(w io.Writer) error {
+		return a.client.DownloadArchive(*project, w)
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Wrote %s\n", *file)
+	return nil
+}
+
+func runSnapshotsList(a *app, args []string) error {
+	fs := a.flags()
+	project := projectFlag(fs)
+	page := fs.Int("page", 1, "page")
+	limit := fs.Int("limit", 20, "snapshots per page")
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *project == "" {
+		return usagef("--project is required")
+	}
+
+	query := url.Values{"page": {strconv.Itoa(*page)}, "limit": {strconv.Itoa(*limit)}}
+	snapshots, _, err := a.client.ListSnapshots(*project, query)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(snapshots))
+	for i, s := range snapshots {
+		rows[i] = []string{s.ID, s.Version, s.Status, orDash(s.GitCommitHash), formatTime(s.CreatedAt)}
+	}
+	return a.print(snapshots, []string{"ID", "VERSION", "STATUS", "COMMIT", "CREATED"}, rows)
+}
+
+func runSnapshotsDiff(a *app, args []string) error {
+	fs := a.flags()
+	to := fs.String("to", "", "snapshot to compare with, default the current project")
+	positional, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("expected one snapshot ID")
+	}
+
+	plan, err := a.client.DiffSnapshot(positional[0], *to)
+	if err != nil {
+		return err
+	}
+	return a.printPlan(plan)
+}
+
+// runLogs prints the logs of a deployment. With --follow it polls for new entries until the
+// deployment finishes, and fails when the deployment failed.
+func runLogs(a *app, args []string) error {
+	fs := a.flags()
+	follow := fs.Bool("follow", false, "wait for new entries until the deployment finishes")
+	fs.BoolVar(follow, "f", false, "wait for new entries until the deployment finishes")
+	interval := fs.Duration("interval", 2*time.Second, "polling interval of --follow")
+	positional, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("expected one deployment ID")
+	}
+	id := positional[0]
+
+	seen := 0
+	for {
+		// Read the status first so entries written before it finished are not missed
+		var deployment *Deployment
+		if *follow {
+			if deployment, err = a.client.GetDeployment(id); err != nil {
+				return err
+			}
+		}
+
+		for {
+			logs, _, err := a.client.DeploymentLogs(id, seen/logsPageSize+1, logsPageSize)
+			if err != nil {
+				return err
+			}
+			fresh := logs[min(seen%logsPageSize, len(logs)):]
+			for _, entry := range fresh {
+				if err := a.printLog(entry); err != nil {
+					return err
+				}
+			}
+			seen += len(fresh)
+			if len(logs) < logsPageSize {
+				break
+			}
+		}
+
+		if !*follow {
+			return nil
+		}
+		if deployment.Finished() {
+			if deployment.Status == models.DeploymentStatusFailed {
+				fmt.Fprintf(a.stderr, "Deployment failed: %s\n", orDash(deployment.ErrorMessage))
+				return errSilent
+			}
+			return nil
+		}
+		a.sleep(*interval)
+	}
+}
+
+// printLog writes a log entry as a line of text, or as one JSON object per line
+func (a *app) printLog(entry models.DeploymentLog) error {
+	if a.output == OutputJSON {
+		return json.NewEncoder(a.stdout).Encode(entry)
+	}
+	_, err := fmt.Fprintf(a.stdout, "%s %-7s %s\n", formatTime(entry.CreatedAt), entry.Level, entry.Message)
+	return err
+}
+
+// projectFlag registers --project and its shorthand -p
+func projectFlag(fs *flag.FlagSet) *string {
+	project := new(string)
+	fs.StringVar(project, "project", "", "project ID")
+	fs.StringVar(project, "p", "", "project ID")
+	return project
+}
+
+// readFile reads a file, or stdin for "-"
+func (a *app) readFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(a.stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// writeFile writes what write produces to a file, or to stdout when path is empty. A file is
+// only created once the request succeeded.
+func (a *app) writeFile(path string, write func(w io.Writer) error) error {
+	if path == "" {
+		return write(a.stdout)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".lambra-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/yourusername/lambra/internal/manifest"
+)
+
+// print writes data as indented JSON, or as a table of rows under headers
+func (a *app) print(data interface{}, headers []string, rows [][]string) error {
+	if a.output == OutputJSON {
+		encoder := json.NewEncoder(a.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// printPlan writes the changes of a plan
+func (a *app) printPlan(plan *manifest.Plan) error {
+	if a.output == OutputTable && plan.Empty() {
+		fmt.Fprintln(a.stdout, "No changes")
+		return nil
+	}
+
+	rows := make([][]string, len(plan.Changes))
+	for i, change := range plan.Changes {
+		name := change.Entity
+		if change.Resource == manifest.ResourceEndpoint {
+			name = change.Entity + " " + change.Endpoint
+		}
+		if change.Resource == manifest.ResourceProject {
+			name = "-"
+		}
+		rows[i] = []string{change.Action, change.Resource, name, strings.Join(change.Fields, ",")}
+	}
+	return a.print(plan, []string{"ACTION", "RESOURCE", "NAME", "FIELDS"}, rows)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
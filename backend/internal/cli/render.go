@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/yourusername/lambra/internal/generator"
+	"github.com/yourusername/lambra/internal/manifest"
+	"github.com/yourusername/lambra/internal/service"
+)
+
+// templateExt is the extension of custom template files, named after their layer
+const templateExt = ".tmpl"
+
+// renderedFile is a file written by render, as printed in JSON
+type renderedFile struct {
+	Path  string `json:"path"`
+	Layer string `json:"layer"`
+}
+
+// runRender generates the code of a manifest into a directory with the same templates and
+// options as the server, without the API or a database
+func runRender(a *app, args []string) error {
+	fs := a.flags()
+	file := fs.String("file", manifest.FileName, "manifest to generate, - for stdin")
+	fs.StringVar(file, "f", manifest.FileName, "manifest to generate, - for stdin")
+	dir := fs.String("dir", "generated", "directory the code is written to")
+	templatesDir := fs.String("templates", "", "directory of custom templates named <layer>"+templateExt)
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+
+	data, err := a.readFile(*file)
+	if err != nil {
+		return err
+	}
+	m, err := manifest.Parse(data)
+	if err != nil {
+		return err
+	}
+	if err := binding.Validator.ValidateStruct(m); err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	if err := service.CheckManifestSchemas(m); err != nil {
+		return err
+	}
+
+	project, entities, endpoints, err := m.ToProject()
+	if err != nil {
+		return err
+	}
+	templates, err := readTemplates(*templatesDir)
+	if err != nil {
+		return err
+	}
+
+	files, err := service.NewRenderer().RenderProject(&service.ProjectDefinition{
+		Project:   project,
+		Entities:  entities,
+		Endpoints: endpoints,
+		Templates: templates,
+	}, *dir)
+	if err != nil {
+		return err
+	}
+
+	written := make([]renderedFile, len(files))
+	rows := make([][]string, len(files))
+	for i, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(f.Path, []byte(f.Content), 0644); err != nil {
+			return err
+		}
+		written[i] = renderedFile{Path: f.Path, Layer: f.Layer}
+		rows[i] = []string{f.Path, f.Layer}
+	}
+	return a.print(written, []string{"PATH", "LAYER"}, rows)
+}
+
+// readTemplates reads the custom templates of a directory by layer, nil without a directory
+func readTemplates(dir string) (map[string]string, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	layers := make(map[string]bool, len(generator.Layers))
+	for _, layer := range generator.Layers {
+		layers[layer] = true
+	}
+
+	templates := make(map[string]string)
+	for _, entry := range entries {
+		layer, ok := strings.CutSuffix(entry.Name(), templateExt)
+		if entry.IsDir() || !ok {
+			continue
+		}
+		if !layers[layer] {
+			return nil, fmt.Errorf("unknown template layer %q in %s, must be one of %s", layer, dir, strings.Join(generator.Layers, ", "))
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		templates[layer] = string(content)
+	}
+	return templates, nil
+}
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -10,10 +14,17 @@ import (
 type Config struct {
 	Server     ServerConfig
 	Database   DatabaseConfig
+	Git        GitConfig
 	GitLab     GitLabConfig
+	GitHub     GitHubConfig
+	Gitea      GiteaConfig
 	RBAC       RBACConfig
 	Ambassador AmbassadorConfig
 	Workspace  WorkspaceConfig
+	Trash      TrashConfig
+	Secrets    SecretsConfig
+	Auth       AuthConfig
+	Tests      EndpointTestConfig
 }
 
 type ServerConfig struct {
@@ -30,14 +41,36 @@ type DatabaseConfig struct {
 	Name     string
 }
 
+type GitConfig struct {
+	DefaultProvider string
+}
+
 type GitLabConfig struct {
-	URL     string
-	Token   string
-	GroupID string
+	URL           string
+	Token         string
+	GroupID       string
+	WebhookSecret string
+}
+
+type GitHubConfig struct {
+	APIURL        string
+	Token         string
+	Organization  string
+	WebhookSecret string
+}
+
+type GiteaConfig struct {
+	URL           string
+	Token         string
+	Organization  string
+	WebhookSecret string
 }
 
 type RBACConfig struct {
+	Provider   string // local (project_members table) or remote (external RBAC service)
 	ServiceURL string
+	Token      string   // Bearer token for the external RBAC service
+	Admins     []string // Usernames owning every project with the local provider
 }
 
 type AmbassadorConfig struct {
@@ -48,6 +81,25 @@ type WorkspaceConfig struct {
 	Path string
 }
 
+type TrashConfig struct {
+	Retention     time.Duration // How long deleted resources stay restorable, zero disables the scheduled purge
+	PurgeInterval time.Duration // How often the scheduled purge runs
+}
+
+type SecretsConfig struct {
+	EncryptionKey string // Base64 encoded 32 byte AES key, secrets are disabled when empty
+}
+
+type AuthConfig struct {
+	JWTSecret         string        // HMAC key signing login tokens
+	TokenTTL          time.Duration // Lifetime of login tokens
+	AllowRegistration bool          // Whether anyone may create an account through the API
+}
+
+type EndpointTestConfig struct {
+	AllowedTargets []string // Hosts, IPs and CIDR ranges endpoint tests may reach; public addresses only when empty
+}
+
 func Load() (*Config, error) {
 	// Load .env file if exists (ignore error in production)
 	_ = godotenv.Load()
@@ -65,13 +117,32 @@ func Load() (*Config, error) {
 			Password: getEnv("DB_PASSWORD", ""),
 			Name:     getEnv("DB_NAME", "lambra_db"),
 		},
+		Git: GitConfig{
+			DefaultProvider: getEnv("GIT_DEFAULT_PROVIDER", "gitlab"),
+		},
 		GitLab: GitLabConfig{
-			URL:     getEnv("GITLAB_URL", "https://gitlab.com"),
-			Token:   getEnv("GITLAB_TOKEN", ""),
-			GroupID: getEnv("GITLAB_GROUP_ID", ""),
+			URL:           getEnv("GITLAB_URL", "https://gitlab.com"),
+			Token:         getEnv("GITLAB_TOKEN", ""),
+			GroupID:       getEnv("GITLAB_GROUP_ID", ""),
+			WebhookSecret: getEnv("GITLAB_WEBHOOK_SECRET", ""),
+		},
+		GitHub: GitHubConfig{
+			APIURL:        getEnv("GITHUB_API_URL", "https://api.github.com"),
+			Token:         getEnv("GITHUB_TOKEN", ""),
+			Organization:  getEnv("GITHUB_ORGANIZATION", ""),
+			WebhookSecret: getEnv("GITHUB_WEBHOOK_SECRET", ""),
+		},
+		Gitea: GiteaConfig{
+			URL:           getEnv("GITEA_URL", ""),
+			Token:         getEnv("GITEA_TOKEN", ""),
+			Organization:  getEnv("GITEA_ORGANIZATION", ""),
+			WebhookSecret: getEnv("GITEA_WEBHOOK_SECRET", ""),
 		},
 		RBAC: RBACConfig{
+			Provider:   getEnv("RBAC_PROVIDER", "local"),
 			ServiceURL: getEnv("RBAC_SERVICE_URL", "http://localhost:8081"),
+			Token:      getEnv("RBAC_SERVICE_TOKEN", ""),
+			Admins:     getEnvList("RBAC_ADMINS"),
 		},
 		Ambassador: AmbassadorConfig{
 			ServiceURL: getEnv("AMBASSADOR_SERVICE_URL", "http://localhost:8082"),
@@ -79,6 +150,54 @@ func Load() (*Config, error) {
 		Workspace: WorkspaceConfig{
 			Path: getEnv("WORKSPACE_PATH", "/tmp/lambra-workspace"),
 		},
+		Secrets: SecretsConfig{
+			EncryptionKey: getEnv("SECRETS_ENCRYPTION_KEY", ""),
+		},
+		Auth: AuthConfig{
+			JWTSecret: getEnv("JWT_SECRET", ""),
+		},
+		Tests: EndpointTestConfig{
+			AllowedTargets: getEnvList("ENDPOINT_TEST_ALLOWED_TARGETS"),
+		},
+	}
+
+	ttl, err := time.ParseDuration(getEnv("JWT_EXPIRATION", "24h"))
+	if err != nil || ttl <= 0 {
+		return nil, fmt.Errorf("JWT_EXPIRATION must be a positive duration such as 24h")
+	}
+	config.Auth.TokenTTL = ttl
+
+	retention, err := time.ParseDuration(getEnv("TRASH_RETENTION", "720h"))
+	if err != nil || retention < 0 {
+		return nil, fmt.Errorf("TRASH_RETENTION must be a duration such as 720h, or 0 to keep deleted resources")
+	}
+	config.Trash.Retention = retention
+
+	interval, err := time.ParseDuration(getEnv("TRASH_PURGE_INTERVAL", "1h"))
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("TRASH_PURGE_INTERVAL must be a positive duration such as 1h")
+	}
+	config.Trash.PurgeInterval = interval
+
+	allowRegistration, err := strconv.ParseBool(getEnv("ALLOW_REGISTRATION", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("ALLOW_REGISTRATION must be a boolean")
+	}
+	config.Auth.AllowRegistration = allowRegistration
+
+	if config.RBAC.Provider != "local" && config.RBAC.Provider != "remote" {
+		return nil, fmt.Errorf("RBAC_PROVIDER must be local or remote")
+	}
+
+	if config.Auth.JWTSecret == "" && config.Server.Env == "production" {
+		return nil, fmt.Errorf("JWT_SECRET is required in production")
+	}
+
+	if key := config.Secrets.EncryptionKey; key != "" {
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil || len(decoded) != 32 {
+			return nil, fmt.Errorf("SECRETS_ENCRYPTION_KEY must be a base64 encoded 32 byte key")
+		}
 	}
 
 	return config, nil
@@ -95,3 +214,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvList splits a comma separated variable, skipping empty items
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
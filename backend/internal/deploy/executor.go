@@ -0,0 +1,55 @@
+package deploy
+
+import (
+	"context"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// ErrInvalidTransition is returned when a deployment status change is not allowed
+var ErrInvalidTransition = apperror.Conflict("invalid deployment status transition")
+
+// transitions lists the statuses a deployment may move to from each status
+var transitions = map[string][]string{
+	models.DeploymentStatusPending:   {models.DeploymentStatusDeploying},
+	models.DeploymentStatusDeploying: {models.DeploymentStatusSuccess, models.DeploymentStatusFailed},
+}
+
+// CanTransition reports whether a deployment may move from one status to another
+func CanTransition(from, to string) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Logger receives log lines produced while a deployment runs
+type Logger interface {
+	Log(level, message string)
+}
+
+// Request describes what an executor should deploy
+type Request struct {
+	DeploymentUUID string
+	ProjectName    string
+	Namespace      string // Namespace of the environment, the one the rendered manifests use
+	Environment    string
+	Version        string
+	Snapshot       *models.GenerationSnapshot
+	// Secrets holds decrypted secrets of the environment, keyed by name.
+	// Executors create the Secret object from them and must never persist or log the values.
+	Secrets map[string]string
+}
+
+// Result is returned by an executor after a successful deployment
+type Result struct {
+	URL string
+}
+
+// Executor rolls out a generated snapshot to a target environment
+type Executor interface {
+	Execute(ctx context.Context, req *Request, logger Logger) (*Result, error)
+}
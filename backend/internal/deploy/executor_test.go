@@ -0,0 +1,64 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+type recordingLogger struct {
+	levels []string
+}
+
+func (l *recordingLogger) Log(level, message string) {
+	l.levels = append(l.levels, level)
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{models.DeploymentStatusPending, models.DeploymentStatusDeploying, true},
+		{models.DeploymentStatusDeploying, models.DeploymentStatusSuccess, true},
+		{models.DeploymentStatusDeploying, models.DeploymentStatusFailed, true},
+		{models.DeploymentStatusPending, models.DeploymentStatusSuccess, false},
+		{models.DeploymentStatusSuccess, models.DeploymentStatusDeploying, false},
+		{models.DeploymentStatusFailed, models.DeploymentStatusPending, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestFakeExecutor(t *testing.T) {
+	executor := NewFakeExecutor()
+	logger := &recordingLogger{}
+	req := &Request{ProjectName: "users", Namespace: "users", Environment: models.DeploymentEnvStaging, Version: "1.0.0"}
+
+	result, err := executor.Execute(context.Background(), req, logger)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.URL != "http://users.staging.local" {
+		t.Errorf("URL = %q", result.URL)
+	}
+	if len(executor.Requests()) != 1 || len(logger.levels) != 2 {
+		t.Errorf("requests = %d, logs = %d", len(executor.Requests()), len(logger.levels))
+	}
+
+	executor.Err = errors.New("cluster unreachable")
+	logger = &recordingLogger{}
+
+	if _, err := executor.Execute(context.Background(), req, logger); !errors.Is(err, executor.Err) {
+		t.Errorf("Execute() error = %v, want %v", err, executor.Err)
+	}
+	if logger.levels[len(logger.levels)-1] != models.DeploymentLogError {
+		t.Errorf("last log level = %q, want error", logger.levels[len(logger.levels)-1])
+	}
+}
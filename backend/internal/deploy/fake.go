@@ -0,0 +1,62 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// FakeExecutor is an in-memory Executor for tests.
+// It records every request and returns the configured URL or error.
+type FakeExecutor struct {
+	// URL is returned on success; defaults to http://<namespace>.<environment>.local
+	URL string
+	// Err, when set, makes every deployment fail
+	Err error
+
+	mu       sync.Mutex
+	requests []*Request
+}
+
+// NewFakeExecutor creates a FakeExecutor that always succeeds
+func NewFakeExecutor() *FakeExecutor {
+	return &FakeExecutor{}
+}
+
+func (f *FakeExecutor) Execute(ctx context.Context, req *Request, logger Logger) (*Result, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+
+	logger.Log(models.DeploymentLogInfo, fmt.Sprintf("Deploying %s version %s to %s", req.ProjectName, req.Version, req.Environment))
+
+	if len(req.Secrets) > 0 {
+		logger.Log(models.DeploymentLogInfo, fmt.Sprintf("Applying %d secrets", len(req.Secrets)))
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if f.Err != nil {
+		logger.Log(models.DeploymentLogError, f.Err.Error())
+		return nil, f.Err
+	}
+
+	url := f.URL
+	if url == "" {
+		url = fmt.Sprintf("http://%s.%s.local", req.Namespace, req.Environment)
+	}
+
+	logger.Log(models.DeploymentLogInfo, "Deployment available at "+url)
+	return &Result{URL: url}, nil
+}
+
+// Requests returns the requests received so far
+func (f *FakeExecutor) Requests() []*Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]*Request(nil), f.requests...)
+}
@@ -9,6 +9,20 @@ import (
 	"github.com/yourusername/lambra/internal/models"
 )
 
+// Layers of the generated code; custom templates replace the built-in template of their layer
+const (
+	LayerModel         = "model"
+	LayerRepository    = "repository"
+	LayerService       = "service"
+	LayerHandler       = "handler"
+	LayerDTO           = "dto"
+	LayerMigrationUp   = "migration_up"
+	LayerMigrationDown = "migration_down"
+)
+
+// Layers lists every layer a custom template can replace
+var Layers = []string{LayerModel, LayerRepository, LayerService, LayerHandler, LayerDTO, LayerMigrationUp, LayerMigrationDown}
+
 // CodeGenerator generates code from entities
 type CodeGenerator struct {
 	engine    *TemplateEngine
@@ -23,6 +37,9 @@ func NewCodeGenerator() *CodeGenerator {
 	}
 }
 
+// DefaultModulePath is the Go module path of generated services whose project does not set one
+const DefaultModulePath = "github.com/yourusername/lambra"
+
 // GenerateContext holds all data needed for code generation
 type GenerateContext struct {
 	Project      *models.Project
@@ -35,6 +52,38 @@ type GenerateContext struct {
 	TableName    string
 	HasUUID      bool
 	HasTimestamp bool
+	ModulePath   string            // Go module path, DefaultModulePath when empty
+	Dialect      string            // SQL dialect, postgres when empty
+	Templates    map[string]string // Templates by layer replacing the built-in ones
+}
+
+// Module returns the Go module path imports of the generated code start with
+func (c *GenerateContext) Module() string {
+	if c.ModulePath != "" {
+		return c.ModulePath
+	}
+	return DefaultModulePath
+}
+
+// IsMySQL reports whether the generated code targets MySQL rather than PostgreSQL
+func (c *GenerateContext) IsMySQL() bool {
+	return c.Dialect == models.DialectMySQL
+}
+
+// Bind returns the placeholder of the n-th query argument in the dialect
+func (c *GenerateContext) Bind(n int) string {
+	if c.IsMySQL() {
+		return "?"
+	}
+	return fmt.Sprintf("$%d", n)
+}
+
+// template returns the template of a layer, preferring a custom one from the context
+func (c *GenerateContext) template(layer, builtin string) string {
+	if custom, ok := c.Templates[layer]; ok && custom != "" {
+		return custom
+	}
+	return builtin
 }
 
 // FieldContext represents a field for template rendering
@@ -59,15 +108,15 @@ func (g *CodeGenerator) GenerateAll(ctx *GenerateContext, outputDir string) erro
 		template string
 		filename string
 	}{
-		{"model", modelTemplate, fmt.Sprintf("%s.go", toSnakeCase(ctx.EntityName))},
-		{"repository", repositoryTemplate, fmt.Sprintf("%s_repository.go", toSnakeCase(ctx.EntityName))},
-		{"service", serviceTemplate, fmt.Sprintf("%s_service.go", toSnakeCase(ctx.EntityName))},
-		{"handler", handlerTemplate, fmt.Sprintf("%s_handler.go", toSnakeCase(ctx.EntityName))},
-		{"dto", dtoTemplate, fmt.Sprintf("%s_dto.go", toSnakeCase(ctx.EntityName))},
+		{LayerModel, modelTemplate, fmt.Sprintf("%s.go", toSnakeCase(ctx.EntityName))},
+		{LayerRepository, repositoryTemplate, fmt.Sprintf("%s_repository.go", toSnakeCase(ctx.EntityName))},
+		{LayerService, serviceTemplate, fmt.Sprintf("%s_service.go", toSnakeCase(ctx.EntityName))},
+		{LayerHandler, handlerTemplate, fmt.Sprintf("%s_handler.go", toSnakeCase(ctx.EntityName))},
+		{LayerDTO, dtoTemplate, fmt.Sprintf("%s_dto.go", toSnakeCase(ctx.EntityName))},
 	}
 
 	for _, gen := range generators {
-		code, err := g.engine.Render(gen.template, ctx)
+		code, err := g.engine.Render(ctx.template(gen.name, gen.template), ctx)
 		if err != nil {
 			return fmt.Errorf("failed to generate %s: %w", gen.name, err)
 		}
@@ -75,15 +124,15 @@ func (g *CodeGenerator) GenerateAll(ctx *GenerateContext, outputDir string) erro
 		// Determine output path based on layer
 		var layerDir string
 		switch gen.name {
-		case "model":
+		case LayerModel:
 			layerDir = "models"
-		case "repository":
+		case LayerRepository:
 			layerDir = "repository"
-		case "service":
+		case LayerService:
 			layerDir = "service"
-		case "handler":
+		case LayerHandler:
 			layerDir = filepath.Join("api", "handlers")
-		case "dto":
+		case LayerDTO:
 			layerDir = filepath.Join("api", "dto")
 		}
 
@@ -98,37 +147,42 @@ func (g *CodeGenerator) GenerateAll(ctx *GenerateContext, outputDir string) erro
 
 // GenerateModel generates model code
 func (g *CodeGenerator) GenerateModel(ctx *GenerateContext) (string, error) {
-	return g.engine.Render(modelTemplate, ctx)
+	return g.engine.Render(ctx.template(LayerModel, modelTemplate), ctx)
 }
 
 // GenerateRepository generates repository code
 func (g *CodeGenerator) GenerateRepository(ctx *GenerateContext) (string, error) {
-	return g.engine.Render(repositoryTemplate, ctx)
+	return g.engine.Render(ctx.template(LayerRepository, repositoryTemplate), ctx)
 }
 
 // GenerateService generates service code
 func (g *CodeGenerator) GenerateService(ctx *GenerateContext) (string, error) {
-	return g.engine.Render(serviceTemplate, ctx)
+	return g.engine.Render(ctx.template(LayerService, serviceTemplate), ctx)
 }
 
 // GenerateHandler generates handler code
 func (g *CodeGenerator) GenerateHandler(ctx *GenerateContext) (string, error) {
-	return g.engine.Render(handlerTemplate, ctx)
+	return g.engine.Render(ctx.template(LayerHandler, handlerTemplate), ctx)
 }
 
 // GenerateDTO generates DTO code
 func (g *CodeGenerator) GenerateDTO(ctx *GenerateContext) (string, error) {
-	return g.engine.Render(dtoTemplate, ctx)
+	return g.engine.Render(ctx.template(LayerDTO, dtoTemplate), ctx)
 }
 
 // GenerateMigration generates database migration
 func (g *CodeGenerator) GenerateMigration(ctx *GenerateContext) (up string, down string, err error) {
-	up, err = g.engine.Render(migrationUpTemplate, ctx)
+	upTemplate := migrationUpTemplate
+	if ctx.IsMySQL() {
+		upTemplate = mysqlMigrationUpTemplate
+	}
+
+	up, err = g.engine.Render(ctx.template(LayerMigrationUp, upTemplate), ctx)
 	if err != nil {
 		return "", "", err
 	}
 
-	down, err = g.engine.Render(migrationDownTemplate, ctx)
+	down, err = g.engine.Render(ctx.template(LayerMigrationDown, migrationDownTemplate), ctx)
 	if err != nil {
 		return "", "", err
 	}
@@ -147,6 +201,8 @@ func (g *CodeGenerator) PrepareContext(project *models.Project, entity *models.E
 		PackageName:  "models",
 		HasUUID:      true,
 		HasTimestamp: true,
+		ModulePath:   project.ModulePath,
+		Dialect:      project.Dialect,
 	}
 
 	// Parse fields from JSON
@@ -443,6 +443,89 @@ func TestCodeGenerator_GenerateMigration(t *testing.T) {
 	}
 }
 
+func TestCodeGenerator_Dialect(t *testing.T) {
+	gen := NewCodeGenerator()
+
+	ctx := &GenerateContext{
+		EntityName:   "User",
+		EntityNameLC: "user",
+		TableName:    "users",
+		Dialect:      models.DialectMySQL,
+		ModulePath:   "github.com/acme/users",
+		Fields: []FieldContext{
+			{Name: "Name", NameLC: "name", Type: "string", GoType: "string", Required: true},
+		},
+	}
+
+	repo, err := gen.GenerateRepository(ctx)
+	if err != nil {
+		t.Fatalf("GenerateRepository() error = %v", err)
+	}
+	for _, expected := range []string{`"github.com/acme/users/internal/models"`, "WHERE id = ? AND", "LIMIT ? OFFSET ?", "LastInsertId()"} {
+		if !strings.Contains(repo, expected) {
+			t.Errorf("MySQL repository does not contain: %q", expected)
+		}
+	}
+	if strings.Contains(repo, "RETURNING") || strings.Contains(repo, "$1") {
+		t.Errorf("MySQL repository uses PostgreSQL syntax:\n%s", repo)
+	}
+
+	up, _, err := gen.GenerateMigration(ctx)
+	if err != nil {
+		t.Fatalf("GenerateMigration() error = %v", err)
+	}
+	if !strings.Contains(up, "AUTO_INCREMENT") || strings.Contains(up, "BIGSERIAL") {
+		t.Errorf("MySQL migration uses the wrong dialect:\n%s", up)
+	}
+
+	ctx.Dialect = ""
+	ctx.ModulePath = ""
+	repo, _ = gen.GenerateRepository(ctx)
+	for _, expected := range []string{`"` + DefaultModulePath + `/internal/models"`, "WHERE id = $1 AND", "RETURNING id"} {
+		if !strings.Contains(repo, expected) {
+			t.Errorf("default repository does not contain: %q", expected)
+		}
+	}
+}
+
+func TestCodeGenerator_CustomTemplates(t *testing.T) {
+	gen := NewCodeGenerator()
+
+	ctx := &GenerateContext{
+		EntityName: "User",
+		TableName:  "users",
+		Templates: map[string]string{
+			LayerModel:       "// custom model for {{ .EntityName }}",
+			LayerMigrationUp: "CREATE TABLE {{ .TableName }} (id INT);",
+		},
+	}
+
+	model, err := gen.GenerateModel(ctx)
+	if err != nil {
+		t.Fatalf("GenerateModel() error = %v", err)
+	}
+	if model != "// custom model for User" {
+		t.Errorf("GenerateModel() = %q, want the custom template", model)
+	}
+
+	up, down, err := gen.GenerateMigration(ctx)
+	if err != nil {
+		t.Fatalf("GenerateMigration() error = %v", err)
+	}
+	if up != "CREATE TABLE users (id INT);" {
+		t.Errorf("UP migration = %q, want the custom template", up)
+	}
+	if !strings.Contains(down, "DROP TABLE IF EXISTS users") {
+		t.Errorf("DOWN migration should fall back to the built-in template:\n%s", down)
+	}
+
+	// Layers without a custom template keep the built-in one
+	dto, err := gen.GenerateDTO(ctx)
+	if err != nil || !strings.Contains(dto, "package dto") {
+		t.Errorf("GenerateDTO() = %q, %v; want the built-in template", dto, err)
+	}
+}
+
 func TestCodeGenerator_GetGeneratedFiles(t *testing.T) {
 	gen := NewCodeGenerator()
 
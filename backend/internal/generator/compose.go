@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// ComposeEnvironment is the environment the generated Compose file runs
+const ComposeEnvironment = models.DeploymentEnvDev
+
+// composeSecretKeys are the credentials the generated Compose file always needs
+var composeSecretKeys = []string{"DB_PASSWORD", "DB_ROOT_PASSWORD", "DB_USERNAME"}
+
+// GenerateCompose renders a docker-compose.yml and .env.example keyed by file name.
+// Credentials are referenced through the env file and never rendered.
+func (g *CodeGenerator) GenerateCompose(ctx *DeployContext) (map[string]string, error) {
+	envCtx := *ctx
+	envCtx.SecretKeys = append([]string{}, ctx.SecretKeys...)
+	for _, key := range composeSecretKeys {
+		if _, ok := ctx.Config[key]; !ok && !containsString(envCtx.SecretKeys, key) {
+			envCtx.SecretKeys = append(envCtx.SecretKeys, key)
+		}
+	}
+	sort.Strings(envCtx.SecretKeys)
+
+	compose, err := g.engine.Render(composeTemplate, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate docker-compose.yml: %w", err)
+	}
+
+	envExample, err := g.engine.Render(envExampleTemplate, &envCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate .env.example: %w", err)
+	}
+
+	return map[string]string{
+		"docker-compose.yml": compose,
+		".env.example":       envExample,
+	}, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
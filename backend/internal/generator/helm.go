@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// semverPattern matches the versions Helm accepts for a chart
+var semverPattern = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// defaultChartVersion is used when the snapshot version is not valid SemVer
+const defaultChartVersion = "0.1.0"
+
+// HelmContext holds the chart level data of a Helm chart
+type HelmContext struct {
+	Name            string
+	Description     string
+	ChartVersion    string
+	AppVersion      string
+	ImageRepository string
+	ImageTag        string
+	Port            int
+	Environments    []*HelmEnvironmentContext
+}
+
+// HelmEnvironmentContext holds the values of one environment of a Helm chart
+type HelmEnvironmentContext struct {
+	*DeployContext
+	ValuesSuffix string
+}
+
+// PrepareHelmContext prepares the Helm chart context of a project.
+// The version becomes the chart version and the image tag. Configs are keyed by environment.
+func (g *CodeGenerator) PrepareHelmContext(project *models.Project, version string, configs map[string]*models.EnvironmentConfig) (*HelmContext, error) {
+	ctx := &HelmContext{
+		Description:  fmt.Sprintf("Helm chart for %s generated by Lambra", project.Name),
+		ChartVersion: chartVersion(version),
+	}
+	if project.Description.Valid && project.Description.String != "" {
+		ctx.Description = project.Description.String
+	}
+
+	for _, environment := range models.DeploymentEnvironments {
+		deployCtx, err := g.PrepareDeployContext(project, environment, version, configs[environment])
+		if err != nil {
+			return nil, err
+		}
+
+		ctx.Environments = append(ctx.Environments, &HelmEnvironmentContext{
+			DeployContext: deployCtx,
+			ValuesSuffix:  helmValuesSuffix(environment),
+		})
+	}
+
+	base := ctx.Environments[0].DeployContext
+	ctx.Name = base.Name
+	ctx.ImageRepository = base.ImageRepository
+	ctx.ImageTag = base.ImageTag
+	ctx.AppVersion = base.ImageTag
+	ctx.Port = base.Port
+
+	return ctx, nil
+}
+
+// GenerateHelmChart renders a Helm chart keyed by path relative to the chart directory
+func (g *CodeGenerator) GenerateHelmChart(ctx *HelmContext) (map[string]string, error) {
+	files := make(map[string]string, len(helmChartFiles)+2+len(ctx.Environments))
+
+	chart, err := g.engine.Render(helmChartTemplate, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Chart.yaml: %w", err)
+	}
+	files["Chart.yaml"] = chart
+
+	values, err := g.engine.Render(helmValuesTemplate, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate values.yaml: %w", err)
+	}
+	files["values.yaml"] = values
+
+	for _, env := range ctx.Environments {
+		filename := fmt.Sprintf("values-%s.yaml", env.ValuesSuffix)
+		content, err := g.engine.Render(helmEnvironmentValuesTemplate, env)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", filename, err)
+		}
+		files[filename] = content
+	}
+
+	for path, content := range helmChartFiles {
+		files[path] = content
+	}
+
+	return files, nil
+}
+
+// HelmChartFiles returns the paths of a generated chart in a stable order
+func HelmChartFiles(files map[string]string) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// chartVersion converts a snapshot version to a SemVer chart version
+func chartVersion(version string) string {
+	version = strings.TrimPrefix(version, "v")
+	if semverPattern.MatchString(version) {
+		return version
+	}
+	return defaultChartVersion
+}
+
+// helmValuesSuffix returns the values file suffix of an environment
+func helmValuesSuffix(environment string) string {
+	if environment == models.DeploymentEnvProduction {
+		return "prod"
+	}
+	return environment
+}
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+func TestCodeGenerator_GenerateHelmChart(t *testing.T) {
+	gen := NewCodeGenerator()
+
+	environments, _ := json.Marshal(map[string]models.EnvironmentSettings{
+		models.DeploymentEnvProduction: {
+			Replicas:    2,
+			MaxReplicas: 6,
+			TargetCPU:   70,
+			Host:        "users.example.com",
+			CPURequest:  "100m",
+		},
+	})
+
+	project := &models.Project{
+		Name:         "UserService",
+		Namespace:    "users",
+		Image:        "registry.example.com/users",
+		Port:         9000,
+		Environments: environments,
+	}
+
+	configs := map[string]*models.EnvironmentConfig{
+		models.DeploymentEnvProduction: {SecretKeys: []string{"DB_PASSWORD"}},
+	}
+
+	ctx, err := gen.PrepareHelmContext(project, "v1.4.0", configs)
+	if err != nil {
+		t.Fatalf("PrepareHelmContext() error = %v", err)
+	}
+
+	files, err := gen.GenerateHelmChart(ctx)
+	if err != nil {
+		t.Fatalf("GenerateHelmChart() error = %v", err)
+	}
+
+	for _, path := range []string{
+		"Chart.yaml", "values.yaml", "values-dev.yaml", "values-staging.yaml", "values-prod.yaml",
+		"templates/_helpers.tpl", "templates/deployment.yaml", "templates/service.yaml",
+		"templates/configmap.yaml", "templates/ingress.yaml", "templates/hpa.yaml",
+	} {
+		if _, ok := files[path]; !ok {
+			t.Errorf("chart is missing %s", path)
+		}
+	}
+
+	chart := files["Chart.yaml"]
+	if !strings.Contains(chart, "name: user-service") || !strings.Contains(chart, "version: 1.4.0") || !strings.Contains(chart, `appVersion: "v1.4.0"`) {
+		t.Errorf("unexpected Chart.yaml:\n%s", chart)
+	}
+
+	values := files["values.yaml"]
+	if !strings.Contains(values, "repository: registry.example.com/users") || !strings.Contains(values, "targetPort: 9000") {
+		t.Errorf("unexpected values.yaml:\n%s", values)
+	}
+
+	prod := files["values-prod.yaml"]
+	for _, want := range []string{"replicaCount: 2", "maxReplicas: 6", "targetCPUUtilizationPercentage: 70", "host: users.example.com", "cpu: 100m", `ENV: "production"`, "- DB_PASSWORD"} {
+		if !strings.Contains(prod, want) {
+			t.Errorf("values-prod.yaml missing %q:\n%s", want, prod)
+		}
+	}
+
+	dev := files["values-dev.yaml"]
+	if strings.Contains(dev, "ingress:") || strings.Contains(dev, "autoscaling:") || strings.Contains(dev, "resources:") || strings.Contains(dev, "secrets:") {
+		t.Errorf("values-dev.yaml should not enable unset features:\n%s", dev)
+	}
+
+	if !strings.Contains(files["templates/deployment.yaml"], "livenessProbe:") {
+		t.Errorf("deployment template is missing probes")
+	}
+}
+
+func TestChartVersion(t *testing.T) {
+	tests := map[string]string{
+		"1.2.3":        "1.2.3",
+		"v2.0.0":       "2.0.0",
+		"1.0.0-rc.1":   "1.0.0-rc.1",
+		"release-2024": defaultChartVersion,
+		"":             defaultChartVersion,
+	}
+
+	for version, want := range tests {
+		if got := chartVersion(version); got != want {
+			t.Errorf("chartVersion(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
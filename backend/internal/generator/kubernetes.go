@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// DeployContext holds the data needed to render deployment manifests for one environment
+type DeployContext struct {
+	Name            string
+	Namespace       string
+	Image           string
+	ImageRepository string
+	ImageTag        string
+	Port            int
+	Dialect         string // Database of the service, mysql when the project does not set one
+	Version         string
+	Environment     string
+	ConfigMapName   string
+	SecretName      string
+	Settings        models.EnvironmentSettings
+	Config          map[string]string
+	SecretKeys      []string // Names of secrets referenced from SecretName, values are never rendered
+}
+
+// HasResources reports whether any resource request or limit is configured
+func (c *DeployContext) HasResources() bool {
+	s := c.Settings
+	return s.CPURequest != "" || s.MemoryRequest != "" || s.CPULimit != "" || s.MemoryLimit != ""
+}
+
+// HasAutoscaling reports whether the environment scales beyond its base replica count
+func (c *DeployContext) HasAutoscaling() bool {
+	return c.Settings.MaxReplicas > c.Settings.Replicas
+}
+
+// PrepareDeployContext prepares the deployment context of a project for an environment.
+// The version tags the image; "latest" is used when it is empty. The config holds the
+// variables stored in Lambra for the environment and may be nil. Each environment deploys
+// to its own namespace (see Project.EnvironmentNamespace), so environments on one cluster
+// do not overwrite each other.
+func (g *CodeGenerator) PrepareDeployContext(project *models.Project, environment, version string, config *models.EnvironmentConfig) (*DeployContext, error) {
+	name := toKebabCase(project.Name)
+	if name == "" {
+		return nil, fmt.Errorf("project name is required")
+	}
+	if project.Namespace == "" {
+		return nil, fmt.Errorf("project namespace is required")
+	}
+
+	if version == "" {
+		version = "latest"
+	}
+
+	settings := project.GetEnvironments()[environment]
+	if settings.Replicas == 0 {
+		settings.Replicas = 1
+	}
+
+	repository, tag := imageReference(project, name, version)
+
+	ctx := &DeployContext{
+		Name:            name,
+		Namespace:       project.EnvironmentNamespace(environment),
+		Image:           repository + ":" + tag,
+		ImageRepository: repository,
+		ImageTag:        tag,
+		Port:            project.Port,
+		Dialect:         project.Dialect,
+		Version:         version,
+		Environment:     environment,
+		ConfigMapName:   name + "-config",
+		SecretName:      name + "-secrets",
+		Settings:        settings,
+	}
+	if ctx.Port == 0 {
+		ctx.Port = models.DefaultProjectPort
+	}
+	if ctx.Dialect == "" {
+		ctx.Dialect = models.DialectMySQL
+	}
+
+	ginMode := "release"
+	if environment == models.DeploymentEnvDev {
+		ginMode = "debug"
+	}
+
+	// Defaults read by the generated service; per-environment settings and stored
+	// variables override them. Credentials never belong here, they are referenced
+	// from the secret.
+	ctx.Config = map[string]string{
+		"ENV":           environment,
+		"PORT":          strconv.Itoa(ctx.Port),
+		"GIN_MODE":      ginMode,
+		"DB_DRIVERNAME": ctx.Dialect,
+		"DB_NAME":       toSnakeCase(project.Name),
+	}
+	for key, value := range settings.Config {
+		ctx.Config[key] = value
+	}
+
+	if config != nil {
+		for key, value := range config.Variables {
+			ctx.Config[key] = value
+		}
+		ctx.SecretKeys = append(ctx.SecretKeys, config.SecretKeys...)
+		sort.Strings(ctx.SecretKeys)
+	}
+
+	return ctx, nil
+}
+
+// imageReference returns the image repository and tag, keeping an explicit tag from the project image
+func imageReference(project *models.Project, name, version string) (string, string) {
+	image := project.Image
+	if image == "" {
+		image = project.Namespace + "/" + name
+	}
+
+	slash := strings.LastIndex(image, "/")
+	if colon := strings.LastIndex(image, ":"); colon > slash {
+		return image[:colon], image[colon+1:]
+	}
+	return image, version
+}
+
+// GenerateKubernetesManifests renders Deployment, Service and ConfigMap manifests keyed by file name
+func (g *CodeGenerator) GenerateKubernetesManifests(ctx *DeployContext) (map[string]string, error) {
+	manifests := []struct {
+		filename string
+		template string
+	}{
+		{"deployment.yaml", k8sDeploymentTemplate},
+		{"service.yaml", k8sServiceTemplate},
+		{"configmap.yaml", k8sConfigMapTemplate},
+	}
+
+	files := make(map[string]string, len(manifests))
+	for _, manifest := range manifests {
+		content, err := g.engine.Render(manifest.template, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", manifest.filename, err)
+		}
+		files[manifest.filename] = content
+	}
+
+	return files, nil
+}
@@ -0,0 +1,190 @@
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+func TestCodeGenerator_GenerateKubernetesManifests(t *testing.T) {
+	gen := NewCodeGenerator()
+
+	environments, _ := json.Marshal(map[string]models.EnvironmentSettings{
+		models.DeploymentEnvProduction: {
+			Namespace:   "users-prod",
+			Replicas:    3,
+			MemoryLimit: "128Mi",
+			Config:      map[string]string{"DB_HOST": "db.prod.internal"},
+		},
+	})
+
+	project := &models.Project{
+		Name:         "UserService",
+		Namespace:    "users",
+		Image:        "registry.example.com/users",
+		Port:         9000,
+		Environments: environments,
+	}
+
+	config := &models.EnvironmentConfig{
+		Variables:  map[string]string{"FEATURE_FLAGS": "search"},
+		SecretKeys: []string{"DB_PASSWORD", "API_KEY"},
+	}
+
+	ctx, err := gen.PrepareDeployContext(project, models.DeploymentEnvProduction, "1.2.0", config)
+	if err != nil {
+		t.Fatalf("PrepareDeployContext() error = %v", err)
+	}
+
+	files, err := gen.GenerateKubernetesManifests(ctx)
+	if err != nil {
+		t.Fatalf("GenerateKubernetesManifests() error = %v", err)
+	}
+
+	deployment := files["deployment.yaml"]
+	for _, want := range []string{
+		"name: user-service",
+		"namespace: users-prod",
+		"replicas: 3",
+		"image: registry.example.com/users:1.2.0",
+		"containerPort: 9000",
+		"memory: 128Mi",
+		"name: user-service-secrets",
+		"key: API_KEY",
+		"key: DB_PASSWORD",
+	} {
+		if !strings.Contains(deployment, want) {
+			t.Errorf("deployment.yaml missing %q:\n%s", want, deployment)
+		}
+	}
+	if strings.Contains(deployment, "requests:") || strings.Contains(deployment, "cpu:") {
+		t.Errorf("deployment.yaml should only render configured resources:\n%s", deployment)
+	}
+
+	configMap := files["configmap.yaml"]
+	for _, want := range []string{`DB_HOST: "db.prod.internal"`, `ENV: "production"`, `PORT: "9000"`, `GIN_MODE: "release"`, `FEATURE_FLAGS: "search"`} {
+		if !strings.Contains(configMap, want) {
+			t.Errorf("configmap.yaml missing %q:\n%s", want, configMap)
+		}
+	}
+	if strings.Contains(configMap, "PASSWORD") {
+		t.Errorf("configmap.yaml must not contain credentials:\n%s", configMap)
+	}
+
+	if !strings.Contains(files["service.yaml"], "targetPort: http-api") {
+		t.Errorf("service.yaml missing target port:\n%s", files["service.yaml"])
+	}
+}
+
+func TestCodeGenerator_PrepareDeployContextDefaults(t *testing.T) {
+	gen := NewCodeGenerator()
+	project := &models.Project{Name: "Orders", Namespace: "shop"}
+
+	ctx, err := gen.PrepareDeployContext(project, models.DeploymentEnvDev, "", nil)
+	if err != nil {
+		t.Fatalf("PrepareDeployContext() error = %v", err)
+	}
+
+	if ctx.Image != "shop/orders:latest" {
+		t.Errorf("Image = %q, want shop/orders:latest", ctx.Image)
+	}
+	if ctx.Port != models.DefaultProjectPort || ctx.Settings.Replicas != 1 || ctx.HasResources() {
+		t.Errorf("unexpected defaults: port=%d replicas=%d resources=%v", ctx.Port, ctx.Settings.Replicas, ctx.HasResources())
+	}
+	if ctx.Config["GIN_MODE"] != "debug" {
+		t.Errorf("GIN_MODE = %q, want debug", ctx.Config["GIN_MODE"])
+	}
+
+	namespaces := make(map[string]string)
+	for _, environment := range []string{models.DeploymentEnvDev, models.DeploymentEnvStaging, models.DeploymentEnvProduction} {
+		envCtx, err := gen.PrepareDeployContext(project, environment, "", nil)
+		if err != nil {
+			t.Fatalf("PrepareDeployContext(%s) error = %v", environment, err)
+		}
+		files, err := gen.GenerateKubernetesManifests(envCtx)
+		if err != nil {
+			t.Fatalf("GenerateKubernetesManifests(%s) error = %v", environment, err)
+		}
+		want := "namespace: shop-" + environment
+		if !strings.Contains(files["deployment.yaml"], want) {
+			t.Errorf("%s deployment.yaml missing %q:\n%s", environment, want, files["deployment.yaml"])
+		}
+		if other, ok := namespaces[envCtx.Namespace]; ok {
+			t.Errorf("%s and %s both deploy to namespace %s", other, environment, envCtx.Namespace)
+		}
+		namespaces[envCtx.Namespace] = environment
+	}
+
+	project.Image = "registry.example.com:5000/shop/orders:stable"
+	ctx, _ = gen.PrepareDeployContext(project, models.DeploymentEnvDev, "2.0.0", nil)
+	if ctx.Image != "registry.example.com:5000/shop/orders:stable" {
+		t.Errorf("Image = %q, want explicit tag to be kept", ctx.Image)
+	}
+}
+
+func TestCodeGenerator_GenerateCompose(t *testing.T) {
+	gen := NewCodeGenerator()
+	project := &models.Project{Name: "Orders", Namespace: "shop", Port: 8081}
+	config := &models.EnvironmentConfig{
+		Variables:  map[string]string{"DB_USERNAME": "orders"},
+		SecretKeys: []string{"PAYMENT_API_KEY"},
+	}
+
+	ctx, err := gen.PrepareDeployContext(project, ComposeEnvironment, "", config)
+	if err != nil {
+		t.Fatalf("PrepareDeployContext() error = %v", err)
+	}
+
+	files, err := gen.GenerateCompose(ctx)
+	if err != nil {
+		t.Fatalf("GenerateCompose() error = %v", err)
+	}
+
+	compose := files["docker-compose.yml"]
+	if strings.Contains(compose, "root_password") || !strings.Contains(compose, "${DB_ROOT_PASSWORD:?") {
+		t.Errorf("docker-compose.yml should reference credentials from the env file:\n%s", compose)
+	}
+	if !strings.Contains(compose, `"8081:8081"`) || !strings.Contains(compose, "- .env") {
+		t.Errorf("unexpected docker-compose.yml:\n%s", compose)
+	}
+
+	env := files[".env.example"]
+	for _, want := range []string{"DB_USERNAME=orders\n", "PAYMENT_API_KEY=\n", "DB_PASSWORD=\n", "DB_ROOT_PASSWORD=\n"} {
+		if !strings.Contains(env, want) {
+			t.Errorf(".env.example missing %q:\n%s", want, env)
+		}
+	}
+	if strings.Count(env, "DB_USERNAME=") != 1 {
+		t.Errorf(".env.example should list DB_USERNAME once:\n%s", env)
+	}
+}
+
+func TestCodeGenerator_GenerateComposePostgres(t *testing.T) {
+	gen := NewCodeGenerator()
+	project := &models.Project{Name: "Orders", Namespace: "shop", Dialect: models.DialectPostgres}
+
+	ctx, err := gen.PrepareDeployContext(project, ComposeEnvironment, "", nil)
+	if err != nil {
+		t.Fatalf("PrepareDeployContext() error = %v", err)
+	}
+	if ctx.Config["DB_DRIVERNAME"] != "postgres" {
+		t.Errorf("DB_DRIVERNAME = %q, want postgres", ctx.Config["DB_DRIVERNAME"])
+	}
+
+	files, err := gen.GenerateCompose(ctx)
+	if err != nil {
+		t.Fatalf("GenerateCompose() error = %v", err)
+	}
+
+	compose := files["docker-compose.yml"]
+	for _, want := range []string{"image: postgres:16", "POSTGRES_PASSWORD: ${DB_PASSWORD:?", `DB_PORT: "5432"`} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml missing %q:\n%s", want, compose)
+		}
+	}
+	if strings.Contains(compose, "mysql") {
+		t.Errorf("docker-compose.yml should not use MySQL:\n%s", compose)
+	}
+}
@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// RouterContext holds the data of the generated router and auth middleware
+type RouterContext struct {
+	Name       string
+	ModulePath string
+	Entities   []RouterEntity
+	Routes     []RouteContext
+	HasAuth    bool // Some route requires auth
+}
+
+// RouterEntity is an entity whose handler the router wires
+type RouterEntity struct {
+	Name    string
+	VarName string
+}
+
+// RouteContext is one endpoint registered on the generated router
+type RouteContext struct {
+	Name        string
+	Method      string
+	Path        string
+	Handler     string
+	RequireAuth bool
+	IDParam     string // Trailing path parameter aliased to "id" for the handler, empty when named id
+}
+
+// Module returns the Go module path imports of the router start with
+func (c *RouterContext) Module() string {
+	if c.ModulePath != "" {
+		return c.ModulePath
+	}
+	return DefaultModulePath
+}
+
+// PrepareRouterContext maps the endpoints of a project to the handlers generated for their entities
+func (g *CodeGenerator) PrepareRouterContext(project *models.Project, entities []models.Entity, endpoints []models.Endpoint) (*RouterContext, error) {
+	ctx := &RouterContext{
+		Name:       project.Name,
+		ModulePath: project.ModulePath,
+	}
+
+	byID := make(map[int64]*models.Entity, len(entities))
+	for i := range entities {
+		byID[entities[i].ID] = &entities[i]
+	}
+
+	wired := make(map[int64]bool)
+	for _, endpoint := range endpoints {
+		entity, ok := byID[endpoint.EntityID]
+		if !ok {
+			return nil, fmt.Errorf("endpoint %s %s references an unknown entity", endpoint.Method, endpoint.Path)
+		}
+
+		route, err := routeFor(entity, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		ctx.Routes = append(ctx.Routes, route)
+		ctx.HasAuth = ctx.HasAuth || route.RequireAuth
+
+		if !wired[entity.ID] {
+			wired[entity.ID] = true
+			ctx.Entities = append(ctx.Entities, RouterEntity{
+				Name:    entity.Name,
+				VarName: toCamelCase(entity.Name) + "Handler",
+			})
+		}
+	}
+
+	return ctx, nil
+}
+
+// routeFor picks the generated handler serving an endpoint from its method and path
+func routeFor(entity *models.Entity, endpoint models.Endpoint) (RouteContext, error) {
+	route := RouteContext{
+		Name:        endpoint.Name,
+		Method:      strings.ToUpper(endpoint.Method),
+		Path:        endpoint.Path,
+		RequireAuth: endpoint.RequireAuth,
+	}
+
+	idParam := trailingParam(endpoint.Path)
+	var action string
+	switch route.Method {
+	case http.MethodPost:
+		action = "Create" + entity.Name
+		idParam = ""
+	case http.MethodGet:
+		if idParam != "" {
+			action = "Get" + entity.Name
+		} else {
+			action = "List" + pluralize(entity.Name)
+		}
+	case http.MethodPut, http.MethodPatch:
+		action = "Update" + entity.Name
+	case http.MethodDelete:
+		action = "Delete" + entity.Name
+	default:
+		return route, fmt.Errorf("unsupported method %s for endpoint %s", endpoint.Method, endpoint.Path)
+	}
+
+	if (route.Method != http.MethodGet && route.Method != http.MethodPost) && idParam == "" {
+		return route, fmt.Errorf("endpoint %s %s needs a trailing path parameter", endpoint.Method, endpoint.Path)
+	}
+	if idParam != "id" {
+		route.IDParam = idParam
+	}
+
+	route.Handler = toCamelCase(entity.Name) + "Handler." + action
+	return route, nil
+}
+
+// trailingParam returns the name of the last path segment when it is a parameter
+func trailingParam(path string) string {
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	last := segments[len(segments)-1]
+	if strings.HasPrefix(last, ":") {
+		return strings.TrimPrefix(last, ":")
+	}
+	return ""
+}
+
+// GenerateRouter renders the router and auth middleware of a service keyed by their path
+func (g *CodeGenerator) GenerateRouter(ctx *RouterContext) (map[string]string, error) {
+	router, err := g.engine.Render(routerTemplate, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate router: %w", err)
+	}
+
+	auth, err := g.engine.Render(authMiddlewareTemplate, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate auth middleware: %w", err)
+	}
+
+	return map[string]string{
+		filepath.Join("api", "router", "router.go"):   router,
+		filepath.Join("api", "middleware", "auth.go"): auth,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+func routerFixture() (*models.Project, []models.Entity, []models.Endpoint) {
+	project := &models.Project{Name: "shop", ModulePath: "example.com/acme/shop"}
+	entities := []models.Entity{
+		{BaseEntity: models.BaseEntity{ID: 1}, Name: "User"},
+		{BaseEntity: models.BaseEntity{ID: 2}, Name: "Order"},
+	}
+	endpoints := []models.Endpoint{
+		{EntityID: 1, Name: "List users", Method: "GET", Path: "/users", RequireAuth: false},
+		{EntityID: 1, Name: "Get user", Method: "GET", Path: "/users/:id", RequireAuth: true},
+		{EntityID: 1, Name: "Create user", Method: "POST", Path: "/users", RequireAuth: false},
+		{EntityID: 1, Name: "Delete user", Method: "DELETE", Path: "/users/:userId", RequireAuth: true},
+	}
+	return project, entities, endpoints
+}
+
+func TestCodeGenerator_PrepareRouterContext(t *testing.T) {
+	gen := NewCodeGenerator()
+	project, entities, endpoints := routerFixture()
+
+	ctx, err := gen.PrepareRouterContext(project, entities, endpoints)
+	if err != nil {
+		t.Fatalf("PrepareRouterContext() error = %v", err)
+	}
+
+	if !ctx.HasAuth {
+		t.Error("HasAuth = false, want true")
+	}
+	if len(ctx.Entities) != 1 || ctx.Entities[0].VarName != "userHandler" {
+		t.Errorf("Entities = %+v, want only the user handler", ctx.Entities)
+	}
+
+	tests := []struct {
+		handler     string
+		requireAuth bool
+		idParam     string
+	}{
+		{"userHandler.ListUsers", false, ""},
+		{"userHandler.GetUser", true, ""},
+		{"userHandler.CreateUser", false, ""},
+		{"userHandler.DeleteUser", true, "userId"},
+	}
+	for i, tt := range tests {
+		route := ctx.Routes[i]
+		if route.Handler != tt.handler || route.RequireAuth != tt.requireAuth || route.IDParam != tt.idParam {
+			t.Errorf("Routes[%d] = %+v, want handler %s, auth %v, id param %q", i, route, tt.handler, tt.requireAuth, tt.idParam)
+		}
+	}
+}
+
+func TestCodeGenerator_PrepareRouterContextErrors(t *testing.T) {
+	gen := NewCodeGenerator()
+	project, entities, _ := routerFixture()
+
+	tests := []struct {
+		name     string
+		endpoint models.Endpoint
+	}{
+		{"unknown entity", models.Endpoint{EntityID: 9, Method: "GET", Path: "/things"}},
+		{"update without id", models.Endpoint{EntityID: 1, Method: "PUT", Path: "/users"}},
+		{"unsupported method", models.Endpoint{EntityID: 1, Method: "OPTIONS", Path: "/users"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := gen.PrepareRouterContext(project, entities, []models.Endpoint{tt.endpoint}); err == nil {
+				t.Error("PrepareRouterContext() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestCodeGenerator_GenerateRouter(t *testing.T) {
+	gen := NewCodeGenerator()
+	project, entities, endpoints := routerFixture()
+
+	ctx, err := gen.PrepareRouterContext(project, entities, endpoints)
+	if err != nil {
+		t.Fatalf("PrepareRouterContext() error = %v", err)
+	}
+
+	files, err := gen.GenerateRouter(ctx)
+	if err != nil {
+		t.Fatalf("GenerateRouter() error = %v", err)
+	}
+
+	for path, code := range files {
+		if _, err := parser.ParseFile(token.NewFileSet(), path, code, parser.AllErrors); err != nil {
+			t.Errorf("%s does not parse: %v\n%s", path, err, code)
+		}
+	}
+
+	router := files[filepath.Join("api", "router", "router.go")]
+	for _, want := range []string{
+		`"example.com/acme/shop/internal/api/middleware"`,
+		`auth := middleware.RequireAuth(verifier)`,
+		`router.GET("/users", userHandler.ListUsers)`,
+		`router.GET("/users/:id", auth, userHandler.GetUser)`,
+		`router.POST("/users", userHandler.CreateUser)`,
+		`router.DELETE("/users/:userId", auth, middleware.ParamAlias("userId", "id"), userHandler.DeleteUser)`,
+	} {
+		if !strings.Contains(router, want) {
+			t.Errorf("router missing %q\n%s", want, router)
+		}
+	}
+
+	auth := files[filepath.Join("api", "middleware", "auth.go")]
+	for _, want := range []string{"func NewHMACVerifier(", "func NewJWKSVerifier(", "AUTH_JWKS_URL", "func PrincipalFrom("} {
+		if !strings.Contains(auth, want) {
+			t.Errorf("auth middleware missing %q", want)
+		}
+	}
+}
+
+func TestCodeGenerator_GenerateRouterWithoutAuth(t *testing.T) {
+	gen := NewCodeGenerator()
+	project, entities, endpoints := routerFixture()
+
+	ctx, err := gen.PrepareRouterContext(project, entities, endpoints[:1])
+	if err != nil {
+		t.Fatalf("PrepareRouterContext() error = %v", err)
+	}
+
+	files, err := gen.GenerateRouter(ctx)
+	if err != nil {
+		t.Fatalf("GenerateRouter() error = %v", err)
+	}
+
+	router := files[filepath.Join("api", "router", "router.go")]
+	if strings.Contains(router, "auth :=") {
+		t.Errorf("router declares auth without routes requiring it\n%s", router)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "router.go", router, parser.AllErrors); err != nil {
+		t.Errorf("router does not parse: %v", err)
+	}
+}
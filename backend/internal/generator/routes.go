@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RouteConflict explains why the generated Gin router cannot serve two routes, or returns "" when
+// they can be registered together. Gin keeps a routing tree per method, so routes of different
+// methods never conflict. Within a method, paths must not be identical, must use the same
+// parameter name wherever both have a parameter, and must not put a static segment where the
+// other has a parameter or catch-all: Gin before v1.8 panics on such routes and later versions
+// silently prefer the static one.
+func RouteConflict(method, path, otherMethod, otherPath string) string {
+	if !strings.EqualFold(method, otherMethod) {
+		return ""
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	others := strings.Split(strings.Trim(otherPath, "/"), "/")
+	for i := 0; i < len(segments) && i < len(others); i++ {
+		segment, other := segments[i], others[i]
+		switch {
+		case isWildcard(segment) && isWildcard(other):
+			if segment != other {
+				return fmt.Sprintf("%s and %s are different parameters at position %d of the same path", segment, other, i+1)
+			}
+			if segment[0] == '*' {
+				return fmt.Sprintf("both routes use the catch-all %s", segment)
+			}
+		case isWildcard(segment) || isWildcard(other):
+			wildcard, static := segment, other
+			if isWildcard(other) {
+				wildcard, static = other, segment
+			}
+			return fmt.Sprintf("the static segment %q is at the same position as the parameter %s", static, wildcard)
+		case segment != other:
+			return ""
+		}
+	}
+
+	if len(segments) == len(others) && strings.HasSuffix(path, "/") == strings.HasSuffix(otherPath, "/") {
+		return "the routes are identical"
+	}
+	return ""
+}
+
+// isWildcard reports whether a path segment is a Gin parameter or catch-all
+func isWildcard(segment string) bool {
+	return strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*")
+}
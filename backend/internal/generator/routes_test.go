@@ -0,0 +1,33 @@
+package generator
+
+import "testing"
+
+func TestRouteConflict(t *testing.T) {
+	tests := []struct {
+		name                   string
+		method, path           string
+		otherMethod, otherPath string
+		conflict               bool
+	}{
+		{"identical", "GET", "/users/:id", "GET", "/users/:id", true},
+		{"identical other case method", "get", "/users", "GET", "/users", true},
+		{"other method", "GET", "/users/:id", "DELETE", "/users/:userId", false},
+		{"static next to parameter", "GET", "/users/me", "GET", "/users/:id", true},
+		{"parameter next to static", "GET", "/users/:id", "GET", "/users/me", true},
+		{"parameter names differ", "GET", "/users/:id", "GET", "/users/:userId", true},
+		{"parameter names differ in longer path", "GET", "/users/:userId/orders", "GET", "/users/:id", true},
+		{"same parameter deeper path", "GET", "/users/:id", "GET", "/users/:id/orders", false},
+		{"different static segments", "GET", "/users/:id", "GET", "/orders/:id", false},
+		{"catch-all next to static", "GET", "/files/*path", "GET", "/files/readme", true},
+		{"nested resources", "GET", "/users/:id/orders", "GET", "/users/:id/payments", false},
+		{"trailing slash", "GET", "/users", "GET", "/users/", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := RouteConflict(tt.method, tt.path, tt.otherMethod, tt.otherPath)
+			if (reason != "") != tt.conflict {
+				t.Errorf("RouteConflict(%s %s, %s %s) = %q, want conflict %v", tt.method, tt.path, tt.otherMethod, tt.otherPath, reason, tt.conflict)
+			}
+		})
+	}
+}
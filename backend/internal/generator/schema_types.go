@@ -0,0 +1,296 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/schema"
+)
+
+// SchemaTypesContext holds the Go types generated from the request and response schemas of the
+// endpoints of a project
+type SchemaTypesContext struct {
+	Types   []SchemaType
+	Imports []string
+}
+
+// SchemaType is a named Go type of the dto package, a struct when it has fields
+type SchemaType struct {
+	Name       string
+	Comment    string
+	Underlying string // Type of non-struct types, e.g. []OrderItem
+	Fields     []SchemaField
+}
+
+// SchemaField is a field of a generated struct
+type SchemaField struct {
+	Name   string
+	GoType string
+	Tag    string
+}
+
+// PrepareSchemaTypesContext maps the request and response schemas of endpoints to Go types. Request
+// bodies get binding tags from required properties and constraints; references to an entity use
+// the DTO generated for it.
+func (g *CodeGenerator) PrepareSchemaTypesContext(entities []models.Entity, endpoints []models.Endpoint) (*SchemaTypesContext, error) {
+	entitySchemas, err := schema.EntitySchemas(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &schemaTypeBuilder{
+		used:    make(map[string]bool),
+		imports: make(map[string]bool),
+	}
+	for i := range entities {
+		b.used["Create"+entities[i].Name+"Request"] = true
+		b.used["Update"+entities[i].Name+"Request"] = true
+		b.used[entities[i].Name+"Response"] = true
+	}
+
+	for _, endpoint := range endpoints {
+		bodies := []struct {
+			raw     []byte
+			suffix  string
+			request bool
+		}{
+			{endpoint.RequestSchema, "RequestBody", true},
+			{endpoint.ResponseSchema, "ResponseBody", false},
+		}
+		for _, body := range bodies {
+			if body.request && (endpoint.Method == http.MethodGet || endpoint.Method == http.MethodDelete) {
+				continue
+			}
+			s, err := schema.Parse(body.raw, entitySchemas)
+			if err != nil {
+				return nil, fmt.Errorf("invalid schema of endpoint %s %s: %w", endpoint.Method, endpoint.Path, err)
+			}
+			if s.IsEmpty() {
+				continue
+			}
+
+			kind := "response"
+			if body.request {
+				kind = "request"
+			}
+			b.rootName = b.name(toPascalCase(endpoint.Name) + body.suffix)
+			b.request = body.request
+			b.defs = make(map[*schema.Schema]string)
+			b.define(b.rootName, fmt.Sprintf("%s is the %s body of %s (%s %s)", b.rootName, kind, endpoint.Name, endpoint.Method, endpoint.Path), s)
+		}
+	}
+
+	ctx := &SchemaTypesContext{Types: b.types}
+	for imp := range b.imports {
+		ctx.Imports = append(ctx.Imports, imp)
+	}
+	sort.Strings(ctx.Imports)
+
+	return ctx, nil
+}
+
+// GenerateSchemaTypes renders the request and response body types of a service keyed by their path,
+// nothing when no endpoint has a schema
+func (g *CodeGenerator) GenerateSchemaTypes(ctx *SchemaTypesContext) (map[string]string, error) {
+	if len(ctx.Types) == 0 {
+		return map[string]string{}, nil
+	}
+
+	code, err := g.engine.Render(schemaTypesTemplate, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate endpoint DTOs: %w", err)
+	}
+
+	return map[string]string{filepath.Join("api", "dto", "endpoints.go"): code}, nil
+}
+
+// schemaTypeBuilder collects the types of the schemas of one project
+type schemaTypeBuilder struct {
+	types   []SchemaType
+	used    map[string]bool
+	imports map[string]bool
+
+	rootName string                    // Type of the body being built, the prefix of its nested types
+	request  bool                      // Whether the body is a request body
+	defs     map[*schema.Schema]string // Type names of the $defs of the body already defined
+}
+
+// name returns an unused type name based on name
+func (b *schemaTypeBuilder) name(name string) string {
+	candidate := name
+	for i := 2; b.used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	b.used[candidate] = true
+	return candidate
+}
+
+// define adds the named type of a schema
+func (b *schemaTypeBuilder) define(name, comment string, s *schema.Schema) {
+	index := len(b.types)
+	b.types = append(b.types, SchemaType{Name: name, Comment: comment})
+
+	resolved := s.Resolve()
+	if s.Ref == "" && resolved.Type == schema.TypeObject && len(resolved.Properties) > 0 {
+		b.types[index].Fields = b.fields(name, resolved)
+		return
+	}
+	goType, _ := b.goType(name, s)
+	b.types[index].Underlying = goType
+}
+
+// fields maps the properties of an object schema to struct fields
+func (b *schemaTypeBuilder) fields(parent string, s *schema.Schema) []SchemaField {
+	var fields []SchemaField
+	for _, property := range s.PropertyNames() {
+		propertySchema := s.Properties[property]
+		required := s.IsRequired(property)
+
+		goType, rules := b.goType(parent+toPascalCase(property), propertySchema)
+		optional := !required && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[") && goType != "interface{}"
+		if optional || (required && hasValidZero(propertySchema)) {
+			goType = "*" + goType
+		}
+
+		tag := fmt.Sprintf(`json:"%s"`, property)
+		if !required {
+			tag = fmt.Sprintf(`json:"%s,omitempty"`, property)
+		}
+		if b.request {
+			switch {
+			case required:
+				rules = append([]string{"required"}, rules...)
+			case len(rules) > 0:
+				rules = append([]string{"omitempty"}, rules...)
+			}
+			if len(rules) > 0 {
+				tag += fmt.Sprintf(` binding:"%s"`, strings.Join(rules, ","))
+			}
+		}
+
+		fields = append(fields, SchemaField{Name: toPascalCase(property), GoType: goType, Tag: tag})
+	}
+	return fields
+}
+
+// hasValidZero reports whether the zero value of a schema's Go type is a valid value. Required
+// fields of these types are pointers, since the validator treats a zero value as missing.
+func hasValidZero(s *schema.Schema) bool {
+	switch s.Resolve().Type {
+	case schema.TypeBoolean, schema.TypeInteger, schema.TypeNumber:
+		return true
+	}
+	return false
+}
+
+// goType returns the Go type of a schema and the binding rules of its constraints. Objects with
+// properties become named types called name.
+func (b *schemaTypeBuilder) goType(name string, s *schema.Schema) (string, []string) {
+	if entity := s.EntityRef(); entity != "" {
+		if b.request {
+			return "Create" + entity + "Request", nil
+		}
+		return entity + "Response", nil
+	}
+	if def := s.DefRef(); def != "" {
+		target := s.Resolve()
+		if existing, ok := b.defs[target]; ok {
+			return existing, nil
+		}
+		defName := b.name(b.rootName + toPascalCase(def))
+		b.defs[target] = defName
+		b.define(defName, fmt.Sprintf("%s is the %s definition of %s", defName, def, b.rootName), target)
+		return defName, nil
+	}
+
+	var rules []string
+	switch s.Type {
+	case schema.TypeObject:
+		if len(s.Properties) == 0 {
+			return "map[string]interface{}", nil
+		}
+		typeName := b.name(name)
+		b.define(typeName, fmt.Sprintf("%s is a nested object of %s", typeName, b.rootName), s)
+		return typeName, nil
+
+	case schema.TypeArray:
+		if s.MinItems != nil {
+			rules = append(rules, fmt.Sprintf("min=%d", *s.MinItems))
+		}
+		if s.MaxItems != nil {
+			rules = append(rules, fmt.Sprintf("max=%d", *s.MaxItems))
+		}
+		if s.Items == nil {
+			return "[]interface{}", rules
+		}
+		elem, elemRules := b.goType(name+"Item", s.Items)
+		if resolved := s.Items.Resolve(); resolved.Type == schema.TypeObject || len(elemRules) > 0 {
+			rules = append(rules, "dive")
+			rules = append(rules, elemRules...)
+		}
+		return "[]" + elem, rules
+
+	case schema.TypeString:
+		if s.MinLength != nil {
+			rules = append(rules, fmt.Sprintf("min=%d", *s.MinLength))
+		}
+		if s.MaxLength != nil {
+			rules = append(rules, fmt.Sprintf("max=%d", *s.MaxLength))
+		}
+		if oneOf := enumRule(s.Enum); oneOf != "" {
+			rules = append(rules, oneOf)
+		}
+		switch s.Format {
+		case "date-time":
+			b.imports["time"] = true
+			return "time.Time", nil
+		case "email":
+			rules = append(rules, "email")
+		case "uuid":
+			rules = append(rules, "uuid")
+		case "uri":
+			rules = append(rules, "url")
+		}
+		return "string", rules
+
+	case schema.TypeInteger, schema.TypeNumber:
+		if s.Minimum != nil {
+			rules = append(rules, fmt.Sprintf("gte=%v", *s.Minimum))
+		}
+		if s.Maximum != nil {
+			rules = append(rules, fmt.Sprintf("lte=%v", *s.Maximum))
+		}
+		if oneOf := enumRule(s.Enum); oneOf != "" {
+			rules = append(rules, oneOf)
+		}
+		if s.Type == schema.TypeInteger {
+			return "int64", rules
+		}
+		return "float64", rules
+
+	case schema.TypeBoolean:
+		return "bool", nil
+	}
+
+	return "interface{}", nil
+}
+
+// enumRule maps enum values to a oneof rule, empty when a value cannot be expressed in one
+func enumRule(values []interface{}) string {
+	if len(values) == 0 {
+		return ""
+	}
+	words := make([]string, 0, len(values))
+	for _, value := range values {
+		word := fmt.Sprint(value)
+		if value == nil || word == "" || strings.ContainsAny(word, " ,'\"|") {
+			return ""
+		}
+		words = append(words, word)
+	}
+	return "oneof=" + strings.Join(words, " ")
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+func TestCodeGenerator_GenerateSchemaTypes(t *testing.T) {
+	gen := NewCodeGenerator()
+	entities := []models.Entity{
+		{BaseEntity: models.BaseEntity{ID: 1}, Name: "User", Fields: json.RawMessage(`[{"name":"email","type":"string","required":true}]`)},
+	}
+	endpoints := []models.Endpoint{
+		{
+			EntityID: 1, Name: "Create order", Method: "POST", Path: "/orders",
+			RequestSchema: json.RawMessage(`{
+				"type": "object",
+				"required": ["status", "items", "paid"],
+				"properties": {
+					"status": {"type": "string", "enum": ["pending", "paid"]},
+					"note": {"type": "string", "maxLength": 200},
+					"paid": {"type": "boolean"},
+					"buyer": {"$ref": "#/entities/User"},
+					"items": {"type": "array", "minItems": 1, "items": {"$ref": "#/$defs/line"}}
+				},
+				"$defs": {
+					"line": {
+						"type": "object",
+						"required": ["sku", "price"],
+						"properties": {
+							"sku": {"type": "string"},
+							"quantity": {"type": "integer", "minimum": 1},
+							"price": {"type": "number", "minimum": 0}
+						}
+					}
+				}
+			}`),
+			ResponseSchema: json.RawMessage(`{
+				"type": "object",
+				"required": ["id"],
+				"properties": {
+					"id": {"type": "string", "format": "uuid"},
+					"created_at": {"type": "string", "format": "date-time"},
+					"shipping": {"type": "object", "properties": {"city": {"type": "string"}}}
+				}
+			}`),
+		},
+		{EntityID: 1, Name: "List users", Method: "GET", Path: "/users", ResponseSchema: json.RawMessage(`{"type": "array", "items": {"$ref": "#/entities/User"}}`)},
+		{EntityID: 1, Name: "Delete user", Method: "DELETE", Path: "/users/:id", RequestSchema: json.RawMessage(`{}`)},
+	}
+
+	ctx, err := gen.PrepareSchemaTypesContext(entities, endpoints)
+	if err != nil {
+		t.Fatalf("PrepareSchemaTypesContext() error = %v", err)
+	}
+
+	files, err := gen.GenerateSchemaTypes(ctx)
+	if err != nil {
+		t.Fatalf("GenerateSchemaTypes() error = %v", err)
+	}
+
+	path := filepath.Join("api", "dto", "endpoints.go")
+	code := files[path]
+	if _, err := parser.ParseFile(token.NewFileSet(), path, code, parser.AllErrors); err != nil {
+		t.Fatalf("%s does not parse: %v\n%s", path, err, code)
+	}
+
+	for _, want := range []string{
+		`"time"`,
+		"type CreateOrderRequestBody struct {",
+		"Status string `json:\"status\" binding:\"required,oneof=pending paid\"`",
+		"Note *string `json:\"note,omitempty\" binding:\"omitempty,max=200\"`",
+		"Paid *bool `json:\"paid\" binding:\"required\"`",
+		"Buyer *CreateUserRequest `json:\"buyer,omitempty\"`",
+		"Items []CreateOrderRequestBodyLine `json:\"items\" binding:\"required,min=1,dive\"`",
+		"type CreateOrderRequestBodyLine struct {",
+		"Quantity *int64 `json:\"quantity,omitempty\" binding:\"omitempty,gte=1\"`",
+		"Price *float64 `json:\"price\" binding:\"required,gte=0\"`",
+		"type CreateOrderResponseBody struct {",
+		"Id string `json:\"id\"`",
+		"CreatedAt *time.Time `json:\"created_at,omitempty\"`",
+		"Shipping *CreateOrderResponseBodyShipping `json:\"shipping,omitempty\"`",
+		"type ListUsersResponseBody []UserResponse",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("endpoint DTOs missing %q\n%s", want, code)
+		}
+	}
+	if strings.Contains(code, "DeleteUser") {
+		t.Errorf("endpoint DTOs define a type for an empty schema\n%s", code)
+	}
+}
+
+func TestCodeGenerator_GenerateSchemaTypesWithoutSchemas(t *testing.T) {
+	gen := NewCodeGenerator()
+
+	ctx, err := gen.PrepareSchemaTypesContext(nil, []models.Endpoint{{Name: "List users", Method: "GET", Path: "/users"}})
+	if err != nil {
+		t.Fatalf("PrepareSchemaTypesContext() error = %v", err)
+	}
+	files, err := gen.GenerateSchemaTypes(ctx)
+	if err != nil {
+		t.Fatalf("GenerateSchemaTypes() error = %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("GenerateSchemaTypes() = %d files, want none", len(files))
+	}
+}
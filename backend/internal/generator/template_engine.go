@@ -49,6 +49,12 @@ func NewTemplateEngine() *TemplateEngine {
 	}
 }
 
+// Parse checks that a template parses with the helper functions, without rendering it
+func (te *TemplateEngine) Parse(templateStr string) error {
+	_, err := template.New("template").Funcs(te.funcMap).Parse(templateStr)
+	return err
+}
+
 // Render renders a template with the given data
 func (te *TemplateEngine) Render(templateStr string, data interface{}) (string, error) {
 	tmpl, err := template.New("template").Funcs(te.funcMap).Parse(templateStr)
@@ -123,6 +129,11 @@ func toKebabCase(s string) string {
 	return strings.Join(words, "-")
 }
 
+// ToKebabCase converts string to kebab-case (exported version)
+func ToKebabCase(s string) string {
+	return toKebabCase(s)
+}
+
 // splitWords splits a string into words
 func splitWords(s string) []string {
 	s = strings.TrimSpace(s)
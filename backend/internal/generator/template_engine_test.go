@@ -163,6 +163,30 @@ func TestTemplateEngine_Render(t *testing.T) {
 	}
 }
 
+func TestTemplateEngine_Parse(t *testing.T) {
+	engine := NewTemplateEngine()
+
+	tests := []struct {
+		name     string
+		template string
+		wantErr  bool
+	}{
+		{"plain text", "package models", false},
+		{"helper functions", "type {{ toPascal .Entity.Name }} struct{}", false},
+		{"unclosed action", "type {{ .Name struct{}", true},
+		{"unknown function", "{{ shout .Name }}", true},
+		{"unclosed block", "{{ range .Fields }}{{ .Name }}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := engine.Parse(tt.template); (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestCaseConversions(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -46,7 +46,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	"github.com/yourusername/lambra/internal/models"
+	"{{ .Module }}/internal/models"
 )
 
 // {{ .EntityName }}Repository handles {{ toLower .EntityName }} data operations
@@ -79,9 +79,20 @@ func (r *{{ .EntityName }}Repository) Create(ctx context.Context, {{ .EntityName
 			:{{ toSnake .Name }},
 {{- end }}
 			:created_at, :updated_at
-		) RETURNING id
+		){{ if not .IsMySQL }} RETURNING id{{ end }}
 	` + "`" + `
+{{ if .IsMySQL }}
+	result, err := r.db.NamedExecContext(ctx, query, {{ .EntityNameLC }})
+	if err != nil {
+		return fmt.Errorf("failed to create {{ toLower .EntityName }}: %w", err)
+	}
 
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get id: %w", err)
+	}
+	{{ .EntityNameLC }}.ID = id
+{{ else }}
 	rows, err := r.db.NamedQueryContext(ctx, query, {{ .EntityNameLC }})
 	if err != nil {
 		return fmt.Errorf("failed to create {{ toLower .EntityName }}: %w", err)
@@ -93,14 +104,14 @@ func (r *{{ .EntityName }}Repository) Create(ctx context.Context, {{ .EntityName
 			return fmt.Errorf("failed to scan id: %w", err)
 		}
 	}
-
+{{ end }}
 	return nil
 }
 
 // GetByID retrieves a {{ toLower .EntityName }} by ID
 func (r *{{ .EntityName }}Repository) GetByID(ctx context.Context, id int64) (*models.{{ .EntityName }}, error) {
 	var {{ .EntityNameLC }} models.{{ .EntityName }}
-	query := ` + "`" + `SELECT * FROM {{ .TableName }} WHERE id = $1 AND deleted_at IS NULL` + "`" + `
+	query := ` + "`" + `SELECT * FROM {{ .TableName }} WHERE id = {{ .Bind 1 }} AND deleted_at IS NULL` + "`" + `
 
 	if err := r.db.GetContext(ctx, &{{ .EntityNameLC }}, query, id); err != nil {
 		if err == sql.ErrNoRows {
@@ -115,7 +126,7 @@ func (r *{{ .EntityName }}Repository) GetByID(ctx context.Context, id int64) (*m
 // GetByUUID retrieves a {{ toLower .EntityName }} by UUID
 func (r *{{ .EntityName }}Repository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*models.{{ .EntityName }}, error) {
 	var {{ .EntityNameLC }} models.{{ .EntityName }}
-	query := ` + "`" + `SELECT * FROM {{ .TableName }} WHERE uuid = $1 AND deleted_at IS NULL` + "`" + `
+	query := ` + "`" + `SELECT * FROM {{ .TableName }} WHERE uuid = {{ .Bind 1 }} AND deleted_at IS NULL` + "`" + `
 
 	if err := r.db.GetContext(ctx, &{{ .EntityNameLC }}, query, uuid); err != nil {
 		if err == sql.ErrNoRows {
@@ -134,7 +145,7 @@ func (r *{{ .EntityName }}Repository) List(ctx context.Context, limit, offset in
 		SELECT * FROM {{ .TableName }}
 		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
+		LIMIT {{ .Bind 1 }} OFFSET {{ .Bind 2 }}
 	` + "`" + `
 
 	if err := r.db.SelectContext(ctx, &{{ .EntityNameLC }}s, query, limit, offset); err != nil {
@@ -176,7 +187,7 @@ func (r *{{ .EntityName }}Repository) Update(ctx context.Context, {{ .EntityName
 
 // Delete soft deletes a {{ toLower .EntityName }}
 func (r *{{ .EntityName }}Repository) Delete(ctx context.Context, id int64) error {
-	query := ` + "`" + `UPDATE {{ .TableName }} SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL` + "`" + `
+	query := ` + "`" + `UPDATE {{ .TableName }} SET deleted_at = {{ .Bind 1 }} WHERE id = {{ .Bind 2 }} AND deleted_at IS NULL` + "`" + `
 
 	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
 	if err != nil {
@@ -216,8 +227,8 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
-	"github.com/yourusername/lambra/internal/models"
-	"github.com/yourusername/lambra/internal/repository"
+	"{{ .Module }}/internal/models"
+	"{{ .Module }}/internal/repository"
 )
 
 // {{ .EntityName }}Service handles business logic for {{ pluralize (toLower .EntityName) }}
@@ -324,9 +335,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/yourusername/lambra/internal/api/dto"
-	"github.com/yourusername/lambra/internal/models"
-	"github.com/yourusername/lambra/internal/service"
+	"{{ .Module }}/internal/api/dto"
+	"{{ .Module }}/internal/models"
+	"{{ .Module }}/internal/service"
 )
 
 // {{ .EntityName }}Handler handles HTTP requests for {{ pluralize (toLower .EntityName) }}
@@ -458,7 +469,7 @@ const dtoTemplate = `package dto
 
 import (
 	"github.com/google/uuid"
-	"github.com/yourusername/lambra/internal/models"
+	"{{ .Module }}/internal/models"
 	"time"
 )
 
@@ -521,6 +532,29 @@ func (r {{ .EntityName }}Response) FromModel({{ .EntityNameLC }} *models.{{ .Ent
 }
 `
 
+// Request and response body types of the endpoints, generated from their schemas
+const schemaTypesTemplate = `package dto
+{{ if .Imports }}
+import (
+{{- range .Imports }}
+	"{{ . }}"
+{{- end }}
+)
+{{ end }}
+{{- range .Types }}
+// {{ .Comment }}
+{{- if .Fields }}
+type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ .Name }} {{ .GoType }} ` + "`" + `{{ .Tag }}` + "`" + `
+{{- end }}
+}
+{{- else }}
+type {{ .Name }} {{ .Underlying }}
+{{- end }}
+{{ end -}}
+`
+
 // Migration up template
 const migrationUpTemplate = `-- Create {{ .TableName }} table
 CREATE TABLE IF NOT EXISTS {{ .TableName }} (
@@ -540,7 +574,858 @@ CREATE INDEX idx_{{ .TableName }}_deleted_at ON {{ .TableName }}(deleted_at);
 CREATE INDEX idx_{{ .TableName }}_created_at ON {{ .TableName }}(created_at);
 `
 
+// MySQL migration up template
+const mysqlMigrationUpTemplate = `-- Create {{ .TableName }} table
+CREATE TABLE IF NOT EXISTS {{ .TableName }} (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+{{- range .Fields }}
+    {{ toSnake .Name }} {{ .Type }}{{ if .Required }} NOT NULL{{ end }}{{ if .DefaultValue }} DEFAULT {{ .DefaultValue }}{{ end }},
+{{- end }}
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    INDEX idx_{{ .TableName }}_deleted_at (deleted_at),
+    INDEX idx_{{ .TableName }}_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+`
+
 // Migration down template
 const migrationDownTemplate = `-- Drop {{ .TableName }} table
 DROP TABLE IF EXISTS {{ .TableName }};
 `
+
+// Kubernetes Deployment template
+const k8sDeploymentTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Name }}
+  namespace: {{ .Namespace }}
+  labels:
+    app.kubernetes.io/name: {{ .Name }}
+    app.kubernetes.io/version: {{ quote .Version }}
+    app.kubernetes.io/managed-by: lambra
+    environment: {{ .Environment }}
+spec:
+  replicas: {{ .Settings.Replicas }}
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: {{ .Name }}
+  strategy:
+    type: RollingUpdate
+    rollingUpdate:
+      maxSurge: 1
+      maxUnavailable: 0
+  revisionHistoryLimit: 5
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: {{ .Name }}
+        app.kubernetes.io/version: {{ quote .Version }}
+        environment: {{ .Environment }}
+    spec:
+      containers:
+        - name: {{ .Name }}
+          image: {{ .Image }}
+          imagePullPolicy: IfNotPresent
+          ports:
+            - name: http-api
+              containerPort: {{ .Port }}
+          envFrom:
+            - configMapRef:
+                name: {{ .ConfigMapName }}
+{{- if .SecretKeys }}
+          env:
+{{- range .SecretKeys }}
+            - name: {{ . }}
+              valueFrom:
+                secretKeyRef:
+                  name: {{ $.SecretName }}
+                  key: {{ . }}
+{{- end }}
+{{- end }}
+{{- if .HasResources }}
+          resources:
+{{- if or .Settings.CPURequest .Settings.MemoryRequest }}
+            requests:
+{{- if .Settings.CPURequest }}
+              cpu: {{ .Settings.CPURequest }}
+{{- end }}
+{{- if .Settings.MemoryRequest }}
+              memory: {{ .Settings.MemoryRequest }}
+{{- end }}
+{{- end }}
+{{- if or .Settings.CPULimit .Settings.MemoryLimit }}
+            limits:
+{{- if .Settings.CPULimit }}
+              cpu: {{ .Settings.CPULimit }}
+{{- end }}
+{{- if .Settings.MemoryLimit }}
+              memory: {{ .Settings.MemoryLimit }}
+{{- end }}
+{{- end }}
+{{- end }}
+`
+
+// Kubernetes Service template
+const k8sServiceTemplate = `apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Name }}
+  namespace: {{ .Namespace }}
+  labels:
+    app.kubernetes.io/name: {{ .Name }}
+    app.kubernetes.io/managed-by: lambra
+    environment: {{ .Environment }}
+spec:
+  type: ClusterIP
+  selector:
+    app.kubernetes.io/name: {{ .Name }}
+  ports:
+    - name: http-api
+      port: 80
+      targetPort: http-api
+      protocol: TCP
+`
+
+// Kubernetes ConfigMap template
+const k8sConfigMapTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .ConfigMapName }}
+  namespace: {{ .Namespace }}
+  labels:
+    app.kubernetes.io/name: {{ .Name }}
+    app.kubernetes.io/managed-by: lambra
+    environment: {{ .Environment }}
+data:
+{{- range $key, $value := .Config }}
+  {{ $key }}: {{ quote $value }}
+{{- end }}
+`
+
+// Helm Chart.yaml template
+const helmChartTemplate = `apiVersion: v2
+name: {{ .Name }}
+description: {{ quote .Description }}
+type: application
+version: {{ .ChartVersion }}
+appVersion: {{ quote .AppVersion }}
+`
+
+// Helm values.yaml template, environment values files override it
+const helmValuesTemplate = `# Default values for {{ .Name }}.
+# Environment specific overrides live in values-<environment>.yaml.
+environment: dev
+
+replicaCount: 1
+
+image:
+  repository: {{ .ImageRepository }}
+  tag: {{ quote .ImageTag }}
+  pullPolicy: IfNotPresent
+
+imagePullSecrets: []
+
+# Secret holding credentials, defaults to <chart name>-secrets.
+# Only key names are listed here, the Secret itself is created at deploy time.
+secretName: ""
+secrets: []
+
+service:
+  type: ClusterIP
+  port: 80
+  targetPort: {{ .Port }}
+
+ingress:
+  enabled: false
+  className: ""
+  host: ""
+  path: /
+  tlsSecretName: ""
+
+autoscaling:
+  enabled: false
+  minReplicas: 1
+  maxReplicas: 3
+  targetCPUUtilizationPercentage: 80
+
+probes:
+  liveness:
+    enabled: true
+    path: /health
+    initialDelaySeconds: 10
+    periodSeconds: 10
+  readiness:
+    enabled: true
+    path: /ready
+    initialDelaySeconds: 5
+    periodSeconds: 5
+
+resources: {}
+
+config: {}
+`
+
+// Helm per-environment values template
+const helmEnvironmentValuesTemplate = `# {{ .Environment }} values for {{ .Name }}, install with:
+#   helm upgrade --install {{ .Name }} . --namespace {{ .Namespace }} -f values-{{ .ValuesSuffix }}.yaml
+environment: {{ .Environment }}
+
+replicaCount: {{ .Settings.Replicas }}
+{{- if .HasResources }}
+
+resources:
+{{- if or .Settings.CPURequest .Settings.MemoryRequest }}
+  requests:
+{{- if .Settings.CPURequest }}
+    cpu: {{ .Settings.CPURequest }}
+{{- end }}
+{{- if .Settings.MemoryRequest }}
+    memory: {{ .Settings.MemoryRequest }}
+{{- end }}
+{{- end }}
+{{- if or .Settings.CPULimit .Settings.MemoryLimit }}
+  limits:
+{{- if .Settings.CPULimit }}
+    cpu: {{ .Settings.CPULimit }}
+{{- end }}
+{{- if .Settings.MemoryLimit }}
+    memory: {{ .Settings.MemoryLimit }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .Settings.Host }}
+
+ingress:
+  enabled: true
+  host: {{ .Settings.Host }}
+{{- end }}
+{{- if .HasAutoscaling }}
+
+autoscaling:
+  enabled: true
+  minReplicas: {{ .Settings.Replicas }}
+  maxReplicas: {{ .Settings.MaxReplicas }}
+{{- if .Settings.TargetCPU }}
+  targetCPUUtilizationPercentage: {{ .Settings.TargetCPU }}
+{{- end }}
+{{- end }}
+
+config:
+{{- range $key, $value := .Config }}
+  {{ $key }}: {{ quote $value }}
+{{- end }}
+{{- if .SecretKeys }}
+
+secrets:
+{{- range .SecretKeys }}
+  - {{ . }}
+{{- end }}
+{{- end }}
+`
+
+// Helm chart templates are copied verbatim, Helm renders them at install time
+var helmChartFiles = map[string]string{
+	"templates/_helpers.tpl": `{{/* Chart name, used for every resource */}}
+{{- define "chart.name" -}}
+{{- .Chart.Name | trunc 63 | trimSuffix "-" }}
+{{- end }}
+
+{{/* Selector labels */}}
+{{- define "chart.selectorLabels" -}}
+app.kubernetes.io/name: {{ include "chart.name" . }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end }}
+
+{{/* Common labels */}}
+{{- define "chart.labels" -}}
+{{ include "chart.selectorLabels" . }}
+app.kubernetes.io/version: {{ .Values.image.tag | quote }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+helm.sh/chart: {{ printf "%s-%s" .Chart.Name .Chart.Version | replace "+" "_" }}
+environment: {{ .Values.environment }}
+{{- end }}
+`,
+	"templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "chart.name" . }}
+  labels:
+    {{- include "chart.labels" . | nindent 4 }}
+spec:
+  {{- if not .Values.autoscaling.enabled }}
+  replicas: {{ .Values.replicaCount }}
+  {{- end }}
+  selector:
+    matchLabels:
+      {{- include "chart.selectorLabels" . | nindent 6 }}
+  strategy:
+    type: RollingUpdate
+    rollingUpdate:
+      maxSurge: 1
+      maxUnavailable: 0
+  revisionHistoryLimit: 5
+  template:
+    metadata:
+      labels:
+        {{- include "chart.labels" . | nindent 8 }}
+      annotations:
+        checksum/config: {{ include (print $.Template.BasePath "/configmap.yaml") . | sha256sum }}
+    spec:
+      {{- with .Values.imagePullSecrets }}
+      imagePullSecrets:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
+      containers:
+        - name: {{ include "chart.name" . }}
+          image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"
+          imagePullPolicy: {{ .Values.image.pullPolicy }}
+          ports:
+            - name: http-api
+              containerPort: {{ .Values.service.targetPort }}
+              protocol: TCP
+          envFrom:
+            - configMapRef:
+                name: {{ include "chart.name" . }}-config
+          {{- with .Values.secrets }}
+          env:
+            {{- range . }}
+            - name: {{ . }}
+              valueFrom:
+                secretKeyRef:
+                  name: {{ $.Values.secretName | default (printf "%s-secrets" (include "chart.name" $)) }}
+                  key: {{ . }}
+            {{- end }}
+          {{- end }}
+          {{- with .Values.probes.liveness }}
+          {{- if .enabled }}
+          livenessProbe:
+            httpGet:
+              path: {{ .path }}
+              port: http-api
+            initialDelaySeconds: {{ .initialDelaySeconds }}
+            periodSeconds: {{ .periodSeconds }}
+          {{- end }}
+          {{- end }}
+          {{- with .Values.probes.readiness }}
+          {{- if .enabled }}
+          readinessProbe:
+            httpGet:
+              path: {{ .path }}
+              port: http-api
+            initialDelaySeconds: {{ .initialDelaySeconds }}
+            periodSeconds: {{ .periodSeconds }}
+          {{- end }}
+          {{- end }}
+          {{- with .Values.resources }}
+          resources:
+            {{- toYaml . | nindent 12 }}
+          {{- end }}
+`,
+	"templates/service.yaml": `apiVersion: v1
+kind: Service
+metadata:
+  name: {{ include "chart.name" . }}
+  labels:
+    {{- include "chart.labels" . | nindent 4 }}
+spec:
+  type: {{ .Values.service.type }}
+  selector:
+    {{- include "chart.selectorLabels" . | nindent 4 }}
+  ports:
+    - name: http-api
+      port: {{ .Values.service.port }}
+      targetPort: http-api
+      protocol: TCP
+`,
+	"templates/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "chart.name" . }}-config
+  labels:
+    {{- include "chart.labels" . | nindent 4 }}
+data:
+  {{- range $key, $value := .Values.config }}
+  {{ $key }}: {{ $value | quote }}
+  {{- end }}
+`,
+	"templates/ingress.yaml": `{{- if .Values.ingress.enabled -}}
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: {{ include "chart.name" . }}
+  labels:
+    {{- include "chart.labels" . | nindent 4 }}
+spec:
+  {{- with .Values.ingress.className }}
+  ingressClassName: {{ . }}
+  {{- end }}
+  {{- with .Values.ingress.tlsSecretName }}
+  tls:
+    - hosts:
+        - {{ $.Values.ingress.host }}
+      secretName: {{ . }}
+  {{- end }}
+  rules:
+    - host: {{ .Values.ingress.host }}
+      http:
+        paths:
+          - path: {{ .Values.ingress.path }}
+            pathType: Prefix
+            backend:
+              service:
+                name: {{ include "chart.name" . }}
+                port:
+                  name: http-api
+{{- end }}
+`,
+	"templates/hpa.yaml": `{{- if .Values.autoscaling.enabled -}}
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: {{ include "chart.name" . }}
+  labels:
+    {{- include "chart.labels" . | nindent 4 }}
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: {{ include "chart.name" . }}
+  minReplicas: {{ .Values.autoscaling.minReplicas }}
+  maxReplicas: {{ .Values.autoscaling.maxReplicas }}
+  metrics:
+    - type: Resource
+      resource:
+        name: cpu
+        target:
+          type: Utilization
+          averageUtilization: {{ .Values.autoscaling.targetCPUUtilizationPercentage }}
+{{- end }}
+`,
+}
+
+// Docker Compose template, values are interpolated from the .env file next to it
+const composeTemplate = `# Docker Compose for {{ .Name }} ({{ .Environment }})
+# Copy .env.example to .env and fill in the secrets before starting.
+services:
+  {{ .Name }}-db:
+{{- if eq .Dialect "postgres" }}
+    image: postgres:16
+    environment:
+      POSTGRES_DB: ${DB_NAME}
+      POSTGRES_USER: ${DB_USERNAME:?DB_USERNAME must be set in .env}
+      POSTGRES_PASSWORD: ${DB_PASSWORD:?DB_PASSWORD must be set in .env}
+    volumes:
+      - {{ toSnake .Name }}_db_data:/var/lib/postgresql/data
+      - ./migrations:/docker-entrypoint-initdb.d
+    healthcheck:
+      test: ["CMD-SHELL", "pg_isready -U $${POSTGRES_USER}"]
+{{- else }}
+    image: mysql:8.0
+    environment:
+      MYSQL_ROOT_PASSWORD: ${DB_ROOT_PASSWORD:?DB_ROOT_PASSWORD must be set in .env}
+      MYSQL_DATABASE: ${DB_NAME}
+      MYSQL_USER: ${DB_USERNAME:?DB_USERNAME must be set in .env}
+      MYSQL_PASSWORD: ${DB_PASSWORD:?DB_PASSWORD must be set in .env}
+    volumes:
+      - {{ toSnake .Name }}_db_data:/var/lib/mysql
+      - ./migrations:/docker-entrypoint-initdb.d
+    healthcheck:
+      test: ["CMD", "mysqladmin", "ping", "-h", "localhost"]
+{{- end }}
+      interval: 10s
+      timeout: 5s
+      retries: 5
+
+  {{ .Name }}:
+    build:
+      context: .
+      dockerfile: Dockerfile
+    ports:
+      - "{{ .Port }}:{{ .Port }}"
+    env_file:
+      - .env
+    environment:
+      DB_HOST: {{ .Name }}-db
+      DB_PORT: "{{ if eq .Dialect "postgres" }}5432{{ else }}3306{{ end }}"
+    depends_on:
+      {{ .Name }}-db:
+        condition: service_healthy
+    restart: unless-stopped
+
+volumes:
+  {{ toSnake .Name }}_db_data:
+`
+
+// Compose env file example, secrets are listed without values
+const envExampleTemplate = `# {{ .Environment }} configuration for {{ .Name }}
+{{- range $key, $value := .Config }}
+{{ $key }}={{ $value }}
+{{- end }}
+
+# Secrets are stored encrypted in Lambra, fill them in locally
+{{- range .SecretKeys }}
+{{ . }}=
+{{- end }}
+`
+
+// Auth middleware template of generated services, tokens are verified with an HMAC secret or a JWKS URL
+const authMiddlewareTemplate = `package middleware
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrInvalidToken is returned for malformed, forged or expired tokens
+var ErrInvalidToken = errors.New("invalid token")
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Subject string
+	Claims  map[string]interface{}
+}
+
+// Verifier checks a bearer token and returns its principal. Replace it to plug in another scheme.
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Principal, error)
+}
+
+type principalKey struct{}
+
+// principalContextKey stores the principal in the gin context
+const principalContextKey = "principal"
+
+// RequireAuth rejects requests without a valid bearer token and stores the principal for handlers
+func RequireAuth(verifier Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			c.Header("WWW-Authenticate", "Bearer")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
+			return
+		}
+
+		principal, err := verifier.Verify(c.Request.Context(), token)
+		if err != nil {
+			c.Header("WWW-Authenticate", "Bearer error=\"invalid_token\"")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+
+		c.Set(principalContextKey, principal)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), principalKey{}, principal))
+		c.Next()
+	}
+}
+
+// PrincipalFrom returns the principal of an authenticated request
+func PrincipalFrom(c *gin.Context) (*Principal, bool) {
+	principal, ok := c.Get(principalContextKey)
+	if !ok {
+		return nil, false
+	}
+	p, ok := principal.(*Principal)
+	return p, ok
+}
+
+// PrincipalFromContext returns the principal of an authenticated request from its context
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
+
+// ParamAlias exposes a path parameter under another name, for handlers reading a fixed name
+func ParamAlias(from, to string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Params = append(c.Params, gin.Param{Key: to, Value: c.Param(from)})
+		c.Next()
+	}
+}
+
+// NewVerifierFromEnv builds a JWKS verifier when AUTH_JWKS_URL is set and an HMAC verifier from
+// AUTH_JWT_SECRET otherwise. AUTH_ISSUER and AUTH_AUDIENCE are checked when set.
+func NewVerifierFromEnv() (Verifier, error) {
+	policy := ClaimsPolicy{Issuer: os.Getenv("AUTH_ISSUER"), Audience: os.Getenv("AUTH_AUDIENCE")}
+
+	if url := os.Getenv("AUTH_JWKS_URL"); url != "" {
+		return NewJWKSVerifier(url, policy), nil
+	}
+	if secret := os.Getenv("AUTH_JWT_SECRET"); secret != "" {
+		return NewHMACVerifier([]byte(secret), policy), nil
+	}
+
+	return nil, errors.New("AUTH_JWKS_URL or AUTH_JWT_SECRET must be set")
+}
+
+// ClaimsPolicy holds the registered claims every token must satisfy
+type ClaimsPolicy struct {
+	Issuer   string // Required iss when not empty
+	Audience string // Required aud when not empty
+	Leeway   time.Duration
+}
+
+// principal checks the registered claims of a verified payload
+func (p ClaimsPolicy) principal(payload []byte) (*Principal, error) {
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	now := time.Now()
+	if exp, ok := claims["exp"].(float64); !ok || now.After(time.Unix(int64(exp), 0).Add(p.Leeway)) {
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(p.Leeway).Before(time.Unix(int64(nbf), 0)) {
+		return nil, fmt.Errorf("%w: not yet valid", ErrInvalidToken)
+	}
+	if p.Issuer != "" && claims["iss"] != p.Issuer {
+		return nil, fmt.Errorf("%w: issuer", ErrInvalidToken)
+	}
+	if p.Audience != "" && !hasAudience(claims["aud"], p.Audience) {
+		return nil, fmt.Errorf("%w: audience", ErrInvalidToken)
+	}
+
+	subject, _ := claims["sub"].(string)
+	return &Principal{Subject: subject, Claims: claims}, nil
+}
+
+func hasAudience(aud interface{}, audience string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, a := range aud {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jwtHeader is the JOSE header of a token
+type jwtHeader struct {
+	Alg string ` + "`" + `json:"alg"` + "`" + `
+	Kid string ` + "`" + `json:"kid"` + "`" + `
+}
+
+// splitToken decodes the header and returns the signed part and signature of a compact JWT
+func splitToken(token string) (*jwtHeader, []byte, string, []byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, "", nil, ErrInvalidToken
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, nil, "", nil, ErrInvalidToken
+	}
+	var header jwtHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, nil, "", nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, nil, "", nil, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, "", nil, ErrInvalidToken
+	}
+
+	return &header, payload, parts[0] + "." + parts[1], signature, nil
+}
+
+// HMACVerifier verifies HS256 tokens signed with a shared secret
+type HMACVerifier struct {
+	secret []byte
+	policy ClaimsPolicy
+}
+
+func NewHMACVerifier(secret []byte, policy ClaimsPolicy) *HMACVerifier {
+	return &HMACVerifier{secret: secret, policy: policy}
+}
+
+func (v *HMACVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	header, payload, signed, signature, err := splitToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("%w: unexpected algorithm %s", ErrInvalidToken, header.Alg)
+	}
+
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write([]byte(signed))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+
+	return v.policy.principal(payload)
+}
+
+// jwksRefreshInterval limits how often unknown key IDs trigger a JWKS download
+const jwksRefreshInterval = 30 * time.Second
+
+// JWKSVerifier verifies RS256 tokens against the RSA keys published at a JWKS URL
+type JWKSVerifier struct {
+	url    string
+	client *http.Client
+	policy ClaimsPolicy
+
+	mu      sync.Mutex
+	keys    map[string]*rsa.PublicKey
+	fetched time.Time
+}
+
+func NewJWKSVerifier(url string, policy ClaimsPolicy) *JWKSVerifier {
+	return &JWKSVerifier{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+		policy: policy,
+		keys:   map[string]*rsa.PublicKey{},
+	}
+}
+
+func (v *JWKSVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	header, payload, signed, signature, err := splitToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("%w: unexpected algorithm %s", ErrInvalidToken, header.Alg)
+	}
+
+	key, err := v.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256([]byte(signed))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+
+	return v.policy.principal(payload)
+}
+
+// key returns the key of a key ID, downloading the key set again when the ID is unknown
+func (v *JWKSVerifier) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(v.fetched) < jwksRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+	}
+
+	keys, err := v.fetch(ctx)
+	v.fetched = time.Now()
+	if err != nil {
+		return nil, err
+	}
+	v.keys = keys
+
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+}
+
+func (v *JWKSVerifier) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string ` + "`" + `json:"kty"` + "`" + `
+			Kid string ` + "`" + `json:"kid"` + "`" + `
+			N   string ` + "`" + `json:"n"` + "`" + `
+			E   string ` + "`" + `json:"e"` + "`" + `
+		} ` + "`" + `json:"keys"` + "`" + `
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+		e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+		if errN != nil || errE != nil {
+			continue
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	return keys, nil
+}
+`
+
+// Router template of generated services, routes of endpoints requiring auth go through the verifier
+const routerTemplate = `package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"{{ .Module }}/internal/api/handlers"
+	"{{ .Module }}/internal/api/middleware"
+	"{{ .Module }}/internal/repository"
+	"{{ .Module }}/internal/service"
+)
+
+// Setup registers the endpoints of {{ .Name }}. Endpoints that require auth only
+// accept requests with a token the verifier accepts.
+func Setup(db *sqlx.DB, verifier middleware.Verifier) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Logger(), gin.Recovery())
+{{- if .HasAuth }}
+
+	auth := middleware.RequireAuth(verifier)
+{{- end }}
+{{ range .Entities }}
+	{{ .VarName }} := handlers.New{{ .Name }}Handler(service.New{{ .Name }}Service(repository.New{{ .Name }}Repository(db)))
+{{- end }}
+{{ range .Routes }}
+	// {{ .Name }}
+	router.{{ .Method }}({{ quote .Path }}{{ if .RequireAuth }}, auth{{ end }}{{ if .IDParam }}, middleware.ParamAlias({{ quote .IDParam }}, "id"){{ end }}, {{ .Handler }})
+{{- end }}
+
+	return router
+}
+`
@@ -0,0 +1,290 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/yourusername/lambra/internal/config"
+)
+
+// ErrNotFound is returned when GitLab responds with 404
+var ErrNotFound = errors.New("gitlab: resource not found")
+
+// Access levels used for branch protection
+const (
+	AccessLevelNone       = 0
+	AccessLevelDeveloper  = 30
+	AccessLevelMaintainer = 40
+)
+
+// Commit action types accepted by the commits API
+const (
+	CommitActionCreate = "create"
+	CommitActionUpdate = "update"
+	CommitActionDelete = "delete"
+)
+
+// Client is the subset of the GitLab API used by Lambra
+type Client interface {
+	CreateProject(ctx context.Context, req *CreateProjectRequest) (*Project, error)
+	GetProject(ctx context.Context, projectID int64) (*Project, error)
+	DeleteProject(ctx context.Context, projectID int64) error
+	CreateBranch(ctx context.Context, projectID int64, branch, ref string) (*Branch, error)
+	ProtectBranch(ctx context.Context, projectID int64, branch string, pushLevel, mergeLevel int) error
+	GetRawFile(ctx context.Context, projectID int64, filePath, ref string) ([]byte, error)
+	CreateCommit(ctx context.Context, projectID int64, req *CreateCommitRequest) (*Commit, error)
+	CreateMergeRequest(ctx context.Context, projectID int64, req *CreateMergeRequestRequest) (*MergeRequest, error)
+}
+
+// Project represents a GitLab project (repository)
+type Project struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	Path              string `json:"path"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	DefaultBranch     string `json:"default_branch"`
+	WebURL            string `json:"web_url"`
+	HTTPURLToRepo     string `json:"http_url_to_repo"`
+	SSHURLToRepo      string `json:"ssh_url_to_repo"`
+}
+
+// Branch represents a GitLab branch
+type Branch struct {
+	Name      string `json:"name"`
+	Protected bool   `json:"protected"`
+	Commit    Commit `json:"commit"`
+}
+
+// Commit represents a GitLab commit
+type Commit struct {
+	ID      string `json:"id"`
+	ShortID string `json:"short_id"`
+	Title   string `json:"title"`
+	WebURL  string `json:"web_url"`
+}
+
+// MergeRequest represents a GitLab merge request
+type MergeRequest struct {
+	ID           int64  `json:"id"`
+	IID          int64  `json:"iid"`
+	Title        string `json:"title"`
+	State        string `json:"state"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	WebURL       string `json:"web_url"`
+}
+
+// CreateProjectRequest for creating a project under a group
+type CreateProjectRequest struct {
+	Name                 string `json:"name"`
+	Path                 string `json:"path,omitempty"`
+	NamespaceID          string `json:"namespace_id,omitempty"`
+	Description          string `json:"description,omitempty"`
+	Visibility           string `json:"visibility,omitempty"`
+	DefaultBranch        string `json:"default_branch,omitempty"`
+	InitializeWithReadme bool   `json:"initialize_with_readme"`
+}
+
+// CommitAction is a single file change within a commit
+type CommitAction struct {
+	Action   string `json:"action"`
+	FilePath string `json:"file_path"`
+	Content  string `json:"content,omitempty"`
+}
+
+// CreateCommitRequest for committing several files at once
+type CreateCommitRequest struct {
+	Branch        string         `json:"branch"`
+	StartBranch   string         `json:"start_branch,omitempty"`
+	CommitMessage string         `json:"commit_message"`
+	Actions       []CommitAction `json:"actions"`
+}
+
+// CreateMergeRequestRequest for opening a merge request
+type CreateMergeRequestRequest struct {
+	SourceBranch       string `json:"source_branch"`
+	TargetBranch       string `json:"target_branch"`
+	Title              string `json:"title"`
+	Description        string `json:"description,omitempty"`
+	RemoveSourceBranch bool   `json:"remove_source_branch"`
+}
+
+// httpClient implements Client against the GitLab REST API v4
+type httpClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a GitLab client from configuration
+func NewClient(cfg *config.GitLabConfig) Client {
+	return &httpClient{
+		baseURL:    strings.TrimRight(cfg.URL, "/") + "/api/v4",
+		token:      cfg.Token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *httpClient) CreateProject(ctx context.Context, req *CreateProjectRequest) (*Project, error) {
+	var project Project
+	if err := c.do(ctx, http.MethodPost, "/projects", req, &project); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+	return &project, nil
+}
+
+func (c *httpClient) GetProject(ctx context.Context, projectID int64) (*Project, error) {
+	var project Project
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/projects/%d", projectID), nil, &project); err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return &project, nil
+}
+
+// DeleteProject removes a project; GitLab may keep it restorable for a grace period
+func (c *httpClient) DeleteProject(ctx context.Context, projectID int64) error {
+	if err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/projects/%d", projectID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	return nil
+}
+
+func (c *httpClient) CreateBranch(ctx context.Context, projectID int64, branch, ref string) (*Branch, error) {
+	query := url.Values{}
+	query.Set("branch", branch)
+	query.Set("ref", ref)
+
+	var result Branch
+	path := fmt.Sprintf("/projects/%d/repository/branches?%s", projectID, query.Encode())
+	if err := c.do(ctx, http.MethodPost, path, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+	return &result, nil
+}
+
+func (c *httpClient) ProtectBranch(ctx context.Context, projectID int64, branch string, pushLevel, mergeLevel int) error {
+	query := url.Values{}
+	query.Set("name", branch)
+	query.Set("push_access_level", fmt.Sprintf("%d", pushLevel))
+	query.Set("merge_access_level", fmt.Sprintf("%d", mergeLevel))
+
+	path := fmt.Sprintf("/projects/%d/protected_branches?%s", projectID, query.Encode())
+	if err := c.do(ctx, http.MethodPost, path, nil, nil); err != nil {
+		return fmt.Errorf("failed to protect branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+func (c *httpClient) GetRawFile(ctx context.Context, projectID int64, filePath, ref string) ([]byte, error) {
+	path := fmt.Sprintf("/projects/%d/repository/files/%s/raw?ref=%s",
+		projectID, url.PathEscape(filePath), url.QueryEscape(ref))
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file %s: %w", filePath, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (c *httpClient) CreateCommit(ctx context.Context, projectID int64, req *CreateCommitRequest) (*Commit, error) {
+	var commit Commit
+	path := fmt.Sprintf("/projects/%d/repository/commits", projectID)
+	if err := c.do(ctx, http.MethodPost, path, req, &commit); err != nil {
+		return nil, fmt.Errorf("failed to create commit: %w", err)
+	}
+	return &commit, nil
+}
+
+func (c *httpClient) CreateMergeRequest(ctx context.Context, projectID int64, req *CreateMergeRequestRequest) (*MergeRequest, error) {
+	var mr MergeRequest
+	path := fmt.Sprintf("/projects/%d/merge_requests", projectID)
+	if err := c.do(ctx, http.MethodPost, path, req, &mr); err != nil {
+		return nil, fmt.Errorf("failed to create merge request: %w", err)
+	}
+	return &mr, nil
+}
+
+// newRequest builds an authenticated request for the given API path
+func (c *httpClient) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+// do sends a JSON request and decodes the JSON response into out (if not nil)
+func (c *httpClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// checkResponse converts non-2xx responses into errors
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("gitlab: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,221 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/config"
+)
+
+// newTestClient starts a fake GitLab server backed by the given mux
+func newTestClient(t *testing.T, mux *http.ServeMux) Client {
+	t.Helper()
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return NewClient(&config.GitLabConfig{URL: server.URL, Token: "test-token"})
+}
+
+func TestClient_CreateProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if got := r.Header.Get("PRIVATE-TOKEN"); got != "test-token" {
+			t.Errorf("PRIVATE-TOKEN = %q, want test-token", got)
+		}
+
+		var req CreateProjectRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.NamespaceID != "42" {
+			t.Errorf("NamespaceID = %q, want 42", req.NamespaceID)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(Project{ID: 7, Name: req.Name, Path: req.Path, WebURL: "https://gitlab.test/group/user-service"})
+	})
+
+	client := newTestClient(t, mux)
+
+	project, err := client.CreateProject(context.Background(), &CreateProjectRequest{Name: "User Service", Path: "user-service", NamespaceID: "42"})
+	if err != nil {
+		t.Fatalf("CreateProject() error = %v", err)
+	}
+	if project.ID != 7 {
+		t.Errorf("ID = %d, want 7", project.ID)
+	}
+	if project.WebURL != "https://gitlab.test/group/user-service" {
+		t.Errorf("WebURL = %q", project.WebURL)
+	}
+}
+
+func TestClient_DeleteProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/7", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %s, want DELETE", r.Method)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	client := newTestClient(t, mux)
+
+	if err := client.DeleteProject(context.Background(), 7); err != nil {
+		t.Fatalf("DeleteProject() error = %v", err)
+	}
+	if err := client.DeleteProject(context.Background(), 8); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DeleteProject() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_CanceledContext(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/7", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent despite a canceled context")
+	})
+
+	client := newTestClient(t, mux)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetProject(ctx, 7); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetProject() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestClient_BranchesAndProtection(t *testing.T) {
+	var created []string
+	var protected string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/7/repository/branches", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("ref"); got != "main" {
+			t.Errorf("ref = %q, want main", got)
+		}
+		created = append(created, r.URL.Query().Get("branch"))
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(Branch{Name: r.URL.Query().Get("branch")})
+	})
+	mux.HandleFunc("/api/v4/projects/7/protected_branches", func(w http.ResponseWriter, r *http.Request) {
+		protected = r.URL.Query().Get("name")
+		if got := r.URL.Query().Get("push_access_level"); got != "0" {
+			t.Errorf("push_access_level = %q, want 0", got)
+		}
+		if got := r.URL.Query().Get("merge_access_level"); got != "40" {
+			t.Errorf("merge_access_level = %q, want 40", got)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	client := newTestClient(t, mux)
+
+	for _, branch := range []string{"develop", "staging", "production"} {
+		if _, err := client.CreateBranch(context.Background(), 7, branch, "main"); err != nil {
+			t.Fatalf("CreateBranch(%s) error = %v", branch, err)
+		}
+	}
+	if err := client.ProtectBranch(context.Background(), 7, "production", AccessLevelNone, AccessLevelMaintainer); err != nil {
+		t.Fatalf("ProtectBranch() error = %v", err)
+	}
+
+	if len(created) != 3 {
+		t.Errorf("created %d branches, want 3", len(created))
+	}
+	if protected != "production" {
+		t.Errorf("protected = %q, want production", protected)
+	}
+}
+
+func TestClient_GetRawFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/7/repository/files/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() == "/api/v4/projects/7/repository/files/models%2Fuser.go/raw" {
+			w.Write([]byte("package models\n"))
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	client := newTestClient(t, mux)
+
+	content, err := client.GetRawFile(context.Background(), 7, "models/user.go", "develop")
+	if err != nil {
+		t.Fatalf("GetRawFile() error = %v", err)
+	}
+	if string(content) != "package models\n" {
+		t.Errorf("content = %q", content)
+	}
+
+	_, err = client.GetRawFile(context.Background(), 7, "models/missing.go", "develop")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetRawFile() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_CommitAndMergeRequest(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/7/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		var req CreateCommitRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.StartBranch != "develop" || len(req.Actions) != 1 || req.Actions[0].Action != CommitActionCreate {
+			t.Errorf("unexpected commit request: %+v", req)
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(Commit{ID: "abc123"})
+	})
+	mux.HandleFunc("/api/v4/projects/7/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		var req CreateMergeRequestRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(MergeRequest{IID: 1, SourceBranch: req.SourceBranch, TargetBranch: req.TargetBranch, WebURL: "https://gitlab.test/mr/1"})
+	})
+
+	client := newTestClient(t, mux)
+
+	commit, err := client.CreateCommit(context.Background(), 7, &CreateCommitRequest{
+		Branch:        "lambra/generate",
+		StartBranch:   "develop",
+		CommitMessage: "Regenerate",
+		Actions:       []CommitAction{{Action: CommitActionCreate, FilePath: "models/user.go", Content: "package models"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateCommit() error = %v", err)
+	}
+	if commit.ID != "abc123" {
+		t.Errorf("commit ID = %q, want abc123", commit.ID)
+	}
+
+	mr, err := client.CreateMergeRequest(context.Background(), 7, &CreateMergeRequestRequest{SourceBranch: "lambra/generate", TargetBranch: "develop", Title: "Regenerate"})
+	if err != nil {
+		t.Fatalf("CreateMergeRequest() error = %v", err)
+	}
+	if mr.TargetBranch != "develop" || mr.WebURL == "" {
+		t.Errorf("unexpected merge request: %+v", mr)
+	}
+}
+
+func TestClient_ErrorStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":{"name":["has already been taken"]}}`))
+	})
+
+	client := newTestClient(t, mux)
+
+	if _, err := client.CreateProject(context.Background(), &CreateProjectRequest{Name: "dup"}); err == nil {
+		t.Error("CreateProject() expected error for 400 response")
+	}
+}
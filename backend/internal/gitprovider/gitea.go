@@ -0,0 +1,164 @@
+package gitprovider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/config"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// GiteaProvider implements Provider against the Gitea API v1
+type GiteaProvider struct {
+	client        *restClient
+	organization  string
+	webhookSecret string
+}
+
+// NewGiteaProvider creates a Gitea provider from configuration
+func NewGiteaProvider(cfg *config.GiteaConfig) *GiteaProvider {
+	return &GiteaProvider{
+		client:        newRESTClient(strings.TrimRight(cfg.URL, "/")+"/api/v1", "Authorization", "token "+cfg.Token, "application/json"),
+		organization:  cfg.Organization,
+		webhookSecret: cfg.WebhookSecret,
+	}
+}
+
+func (p *GiteaProvider) Name() string {
+	return models.GitProviderGitea
+}
+
+func (p *GiteaProvider) CreateRepository(ctx context.Context, req *CreateRepositoryRequest) (*Repository, error) {
+	path := "/user/repos"
+	if p.organization != "" {
+		path = fmt.Sprintf("/orgs/%s/repos", url.PathEscape(p.organization))
+	}
+
+	body := map[string]interface{}{
+		"name":           toRepoPath(req.Name),
+		"description":    req.Description,
+		"private":        req.Private,
+		"auto_init":      true,
+		"default_branch": req.DefaultBranch,
+	}
+
+	var repo remoteRepository
+	if err := p.client.do(ctx, http.MethodPost, path, body, &repo); err != nil {
+		return nil, fmt.Errorf("failed to create repository: %w", err)
+	}
+
+	return repo.toRepository(), nil
+}
+
+func (p *GiteaProvider) DeleteRepository(ctx context.Context, repo *Repository) error {
+	if err := p.client.do(ctx, http.MethodDelete, p.repoPath(repo, ""), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete repository: %w", err)
+	}
+	return nil
+}
+
+func (p *GiteaProvider) CreateBranch(ctx context.Context, repo *Repository, branch, ref string) error {
+	body := map[string]string{"new_branch_name": branch, "old_branch_name": ref}
+	if err := p.client.do(ctx, http.MethodPost, p.repoPath(repo, "/branches"), body, nil); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+func (p *GiteaProvider) ProtectBranch(ctx context.Context, repo *Repository, branch string) error {
+	body := map[string]interface{}{
+		"branch_name":        branch,
+		"enable_push":        false,
+		"required_approvals": 1,
+	}
+	if err := p.client.do(ctx, http.MethodPost, p.repoPath(repo, "/branch_protections"), body, nil); err != nil {
+		return fmt.Errorf("failed to protect branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+func (p *GiteaProvider) GetFile(ctx context.Context, repo *Repository, path, ref string) (*File, error) {
+	var contents contentsResponse
+	apiPath := p.repoPath(repo, "/contents/"+escapeFilePath(path)+"?ref="+url.QueryEscape(ref))
+	if err := p.client.do(ctx, http.MethodGet, apiPath, nil, &contents); err != nil {
+		return nil, err
+	}
+	return decodeContents(&contents)
+}
+
+// Push uses the multi-file contents API to commit all changes onto a new branch
+func (p *GiteaProvider) Push(ctx context.Context, repo *Repository, req *PushRequest) (*Commit, error) {
+	files := make([]map[string]string, 0, len(req.Changes))
+	for _, change := range req.Changes {
+		file := map[string]string{
+			"operation": change.Operation,
+			"path":      change.Path,
+			"content":   base64.StdEncoding.EncodeToString([]byte(change.Content)),
+		}
+		if change.SHA != "" {
+			file["sha"] = change.SHA
+		}
+		files = append(files, file)
+	}
+
+	body := map[string]interface{}{
+		"branch":     req.BaseBranch,
+		"new_branch": req.Branch,
+		"message":    req.Message,
+		"files":      files,
+	}
+
+	var result struct {
+		Commit struct {
+			SHA     string `json:"sha"`
+			HTMLURL string `json:"html_url"`
+		} `json:"commit"`
+	}
+	if err := p.client.do(ctx, http.MethodPost, p.repoPath(repo, "/contents"), body, &result); err != nil {
+		return nil, fmt.Errorf("failed to push changes: %w", err)
+	}
+
+	return &Commit{SHA: result.Commit.SHA, URL: result.Commit.HTMLURL}, nil
+}
+
+func (p *GiteaProvider) OpenMergeRequest(ctx context.Context, repo *Repository, req *MergeRequestOptions) (*MergeRequest, error) {
+	body := map[string]string{
+		"title": req.Title,
+		"head":  req.SourceBranch,
+		"base":  req.TargetBranch,
+		"body":  req.Description,
+	}
+
+	var pr struct {
+		Number  int64  `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := p.client.do(ctx, http.MethodPost, p.repoPath(repo, "/pulls"), body, &pr); err != nil {
+		return nil, fmt.Errorf("failed to open pull request: %w", err)
+	}
+
+	return &MergeRequest{Number: pr.Number, URL: pr.HTMLURL}, nil
+}
+
+// ParseWebhook verifies the X-Gitea-Signature header and parses push and
+// pull_request events
+func (p *GiteaProvider) ParseWebhook(r *http.Request) (*WebhookEvent, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validHMAC(p.webhookSecret, body, r.Header.Get("X-Gitea-Signature")) {
+		return nil, ErrInvalidSignature
+	}
+
+	return parsePushOrPullRequest(r.Header.Get("X-Gitea-Event"), body)
+}
+
+func (p *GiteaProvider) repoPath(repo *Repository, suffix string) string {
+	return "/repos/" + repo.FullName + suffix
+}
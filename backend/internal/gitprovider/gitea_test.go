@@ -0,0 +1,89 @@
+package gitprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/config"
+)
+
+func TestGiteaProvider_Push(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/acme/users/contents", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "token secret-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+
+		var body struct {
+			Branch    string              `json:"branch"`
+			NewBranch string              `json:"new_branch"`
+			Files     []map[string]string `json:"files"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if body.Branch != "develop" || body.NewBranch != "lambra/generate-1" || len(body.Files) != 2 {
+			t.Errorf("unexpected push request: %+v", body)
+		}
+		if body.Files[1]["sha"] != "old-blob" {
+			t.Errorf("update sha = %q, want old-blob", body.Files[1]["sha"])
+		}
+		if content, _ := base64.StdEncoding.DecodeString(body.Files[0]["content"]); string(content) != "package models" {
+			t.Errorf("content = %q", content)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"commit": map[string]string{"sha": "c0ffee"}})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider := NewGiteaProvider(&config.GiteaConfig{URL: server.URL, Token: "secret-token"})
+
+	commit, err := provider.Push(context.Background(), &Repository{FullName: "acme/users"}, &PushRequest{
+		Branch:     "lambra/generate-1",
+		BaseBranch: "develop",
+		Message:    "Regenerate",
+		Changes: []FileChange{
+			{Operation: FileOperationCreate, Path: "models/user.go", Content: "package models"},
+			{Operation: FileOperationUpdate, Path: "service/user_service.go", Content: "package service", SHA: "old-blob"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if commit.SHA != "c0ffee" {
+		t.Errorf("SHA = %q, want c0ffee", commit.SHA)
+	}
+}
+
+func TestGiteaProvider_ParseWebhook(t *testing.T) {
+	provider := NewGiteaProvider(&config.GiteaConfig{WebhookSecret: "s3cret"})
+	body := []byte(`{"ref":"refs/heads/develop","after":"abc","repository":{"id":3,"full_name":"acme/users"}}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/gitea", bytes.NewReader(body))
+	req.Header.Set("X-Gitea-Event", "push")
+	req.Header.Set("X-Gitea-Signature", sign("s3cret", body))
+
+	event, err := provider.ParseWebhook(req)
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	if event.Type != EventPush || event.RepoID != 3 || event.CommitHash != "abc" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/gitea", bytes.NewReader(body))
+	req.Header.Set("X-Gitea-Event", "push")
+	req.Header.Set("X-Gitea-Signature", sign("other", body))
+
+	if _, err := provider.ParseWebhook(req); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("ParseWebhook() error = %v, want ErrInvalidSignature", err)
+	}
+}
@@ -0,0 +1,226 @@
+package gitprovider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/config"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// GitHubProvider implements Provider against the GitHub REST API
+type GitHubProvider struct {
+	client        *restClient
+	organization  string
+	webhookSecret string
+}
+
+// NewGitHubProvider creates a GitHub provider from configuration
+func NewGitHubProvider(cfg *config.GitHubConfig) *GitHubProvider {
+	return &GitHubProvider{
+		client:        newRESTClient(cfg.APIURL, "Authorization", "Bearer "+cfg.Token, "application/vnd.github+json"),
+		organization:  cfg.Organization,
+		webhookSecret: cfg.WebhookSecret,
+	}
+}
+
+func (p *GitHubProvider) Name() string {
+	return models.GitProviderGitHub
+}
+
+func (p *GitHubProvider) CreateRepository(ctx context.Context, req *CreateRepositoryRequest) (*Repository, error) {
+	path := "/user/repos"
+	if p.organization != "" {
+		path = fmt.Sprintf("/orgs/%s/repos", url.PathEscape(p.organization))
+	}
+
+	body := map[string]interface{}{
+		"name":        toRepoPath(req.Name),
+		"description": req.Description,
+		"private":     req.Private,
+		"auto_init":   true,
+	}
+
+	var repo remoteRepository
+	if err := p.client.do(ctx, http.MethodPost, path, body, &repo); err != nil {
+		return nil, fmt.Errorf("failed to create repository: %w", err)
+	}
+
+	return repo.toRepository(), nil
+}
+
+func (p *GitHubProvider) DeleteRepository(ctx context.Context, repo *Repository) error {
+	if err := p.client.do(ctx, http.MethodDelete, p.repoPath(repo, ""), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete repository: %w", err)
+	}
+	return nil
+}
+
+func (p *GitHubProvider) CreateBranch(ctx context.Context, repo *Repository, branch, ref string) error {
+	sha, err := p.branchSHA(ctx, repo, ref)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]string{"ref": "refs/heads/" + branch, "sha": sha}
+	if err := p.client.do(ctx, http.MethodPost, p.repoPath(repo, "/git/refs"), body, nil); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+func (p *GitHubProvider) ProtectBranch(ctx context.Context, repo *Repository, branch string) error {
+	body := map[string]interface{}{
+		"required_status_checks": nil,
+		"enforce_admins":         true,
+		"required_pull_request_reviews": map[string]interface{}{
+			"required_approving_review_count": 1,
+		},
+		"restrictions": nil,
+	}
+
+	path := p.repoPath(repo, "/branches/"+url.PathEscape(branch)+"/protection")
+	if err := p.client.do(ctx, http.MethodPut, path, body, nil); err != nil {
+		return fmt.Errorf("failed to protect branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+func (p *GitHubProvider) GetFile(ctx context.Context, repo *Repository, path, ref string) (*File, error) {
+	var contents contentsResponse
+	apiPath := p.repoPath(repo, "/contents/"+escapeFilePath(path)+"?ref="+url.QueryEscape(ref))
+	if err := p.client.do(ctx, http.MethodGet, apiPath, nil, &contents); err != nil {
+		return nil, err
+	}
+	return decodeContents(&contents)
+}
+
+// Push creates blobs, a tree and a commit through the git data API and points
+// a new branch at it
+func (p *GitHubProvider) Push(ctx context.Context, repo *Repository, req *PushRequest) (*Commit, error) {
+	baseSHA, err := p.branchSHA(ctx, repo, req.BaseBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseCommit struct {
+		Tree struct {
+			SHA string `json:"sha"`
+		} `json:"tree"`
+	}
+	if err := p.client.do(ctx, http.MethodGet, p.repoPath(repo, "/git/commits/"+baseSHA), nil, &baseCommit); err != nil {
+		return nil, fmt.Errorf("failed to get base commit: %w", err)
+	}
+
+	entries := make([]map[string]string, 0, len(req.Changes))
+	for _, change := range req.Changes {
+		entries = append(entries, map[string]string{
+			"path":    change.Path,
+			"mode":    "100644",
+			"type":    "blob",
+			"content": change.Content,
+		})
+	}
+
+	var tree struct {
+		SHA string `json:"sha"`
+	}
+	treeBody := map[string]interface{}{"base_tree": baseCommit.Tree.SHA, "tree": entries}
+	if err := p.client.do(ctx, http.MethodPost, p.repoPath(repo, "/git/trees"), treeBody, &tree); err != nil {
+		return nil, fmt.Errorf("failed to create tree: %w", err)
+	}
+
+	var commit struct {
+		SHA     string `json:"sha"`
+		HTMLURL string `json:"html_url"`
+	}
+	commitBody := map[string]interface{}{"message": req.Message, "tree": tree.SHA, "parents": []string{baseSHA}}
+	if err := p.client.do(ctx, http.MethodPost, p.repoPath(repo, "/git/commits"), commitBody, &commit); err != nil {
+		return nil, fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	refBody := map[string]string{"ref": "refs/heads/" + req.Branch, "sha": commit.SHA}
+	if err := p.client.do(ctx, http.MethodPost, p.repoPath(repo, "/git/refs"), refBody, nil); err != nil {
+		return nil, fmt.Errorf("failed to create branch %s: %w", req.Branch, err)
+	}
+
+	return &Commit{SHA: commit.SHA, URL: commit.HTMLURL}, nil
+}
+
+func (p *GitHubProvider) OpenMergeRequest(ctx context.Context, repo *Repository, req *MergeRequestOptions) (*MergeRequest, error) {
+	body := map[string]string{
+		"title": req.Title,
+		"head":  req.SourceBranch,
+		"base":  req.TargetBranch,
+		"body":  req.Description,
+	}
+
+	var pr struct {
+		Number  int64  `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := p.client.do(ctx, http.MethodPost, p.repoPath(repo, "/pulls"), body, &pr); err != nil {
+		return nil, fmt.Errorf("failed to open pull request: %w", err)
+	}
+
+	return &MergeRequest{Number: pr.Number, URL: pr.HTMLURL}, nil
+}
+
+// ParseWebhook verifies the X-Hub-Signature-256 header and parses push and
+// pull_request events
+func (p *GitHubProvider) ParseWebhook(r *http.Request) (*WebhookEvent, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := strings.TrimPrefix(r.Header.Get("X-Hub-Signature-256"), "sha256=")
+	if !validHMAC(p.webhookSecret, body, signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	return parsePushOrPullRequest(r.Header.Get("X-GitHub-Event"), body)
+}
+
+func (p *GitHubProvider) branchSHA(ctx context.Context, repo *Repository, branch string) (string, error) {
+	var ref struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	if err := p.client.do(ctx, http.MethodGet, p.repoPath(repo, "/git/ref/heads/"+url.PathEscape(branch)), nil, &ref); err != nil {
+		return "", fmt.Errorf("failed to resolve branch %s: %w", branch, err)
+	}
+	return ref.Object.SHA, nil
+}
+
+func (p *GitHubProvider) repoPath(repo *Repository, suffix string) string {
+	return "/repos/" + repo.FullName + suffix
+}
+
+// escapeFilePath escapes each segment of a repository file path
+func escapeFilePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// decodeContents decodes a base64 contents API response
+func decodeContents(contents *contentsResponse) (*File, error) {
+	if contents.Encoding != "" && contents.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupported content encoding %q", contents.Encoding)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(contents.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file content: %w", err)
+	}
+
+	return &File{Content: data, SHA: contents.SHA}, nil
+}
@@ -0,0 +1,164 @@
+package gitprovider
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/config"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestGitHubProvider_Push(t *testing.T) {
+	var refs []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/users/git/ref/heads/develop", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"object": map[string]string{"sha": "base-sha"}})
+	})
+	mux.HandleFunc("/repos/acme/users/git/commits/base-sha", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"tree": map[string]string{"sha": "base-tree"}})
+	})
+	mux.HandleFunc("/repos/acme/users/git/trees", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			BaseTree string              `json:"base_tree"`
+			Tree     []map[string]string `json:"tree"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.BaseTree != "base-tree" || len(body.Tree) != 2 {
+			t.Errorf("unexpected tree request: %+v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"sha": "new-tree"})
+	})
+	mux.HandleFunc("/repos/acme/users/git/commits", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Tree    string   `json:"tree"`
+			Parents []string `json:"parents"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Tree != "new-tree" || len(body.Parents) != 1 || body.Parents[0] != "base-sha" {
+			t.Errorf("unexpected commit request: %+v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"sha": "new-commit"})
+	})
+	mux.HandleFunc("/repos/acme/users/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		refs = append(refs, body["ref"]+"@"+body["sha"])
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider := NewGitHubProvider(&config.GitHubConfig{APIURL: server.URL, Token: "token"})
+	repo := &Repository{FullName: "acme/users"}
+
+	commit, err := provider.Push(context.Background(), repo, &PushRequest{
+		Branch:     "lambra/generate-1",
+		BaseBranch: "develop",
+		Message:    "Regenerate",
+		Changes: []FileChange{
+			{Operation: FileOperationCreate, Path: "models/user.go", Content: "package models"},
+			{Operation: FileOperationUpdate, Path: "service/user_service.go", Content: "package service"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if commit.SHA != "new-commit" {
+		t.Errorf("SHA = %q, want new-commit", commit.SHA)
+	}
+	if len(refs) != 1 || refs[0] != "refs/heads/lambra/generate-1@new-commit" {
+		t.Errorf("refs = %v", refs)
+	}
+}
+
+func TestGitHubProvider_GetFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/users/contents/models/user.go", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"sha": "blob", "encoding": "base64", "content": "cGFja2FnZSBt\nb2RlbHM=\n"})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider := NewGitHubProvider(&config.GitHubConfig{APIURL: server.URL, Token: "token"})
+	repo := &Repository{FullName: "acme/users"}
+
+	file, err := provider.GetFile(context.Background(), repo, "models/user.go", "develop")
+	if err != nil {
+		t.Fatalf("GetFile() error = %v", err)
+	}
+	if string(file.Content) != "package models" || file.SHA != "blob" {
+		t.Errorf("unexpected file: %q %q", file.Content, file.SHA)
+	}
+
+	if _, err := provider.GetFile(context.Background(), repo, "missing.go", "develop"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetFile() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGitHubProvider_ParseWebhook(t *testing.T) {
+	provider := NewGitHubProvider(&config.GitHubConfig{WebhookSecret: "s3cret"})
+
+	pushBody := []byte(`{"ref":"refs/heads/develop","after":"abc","repository":{"id":9,"full_name":"acme/users"}}`)
+	mergeBody := []byte(`{"action":"closed","pull_request":{"merged":true,"merge_commit_sha":"def","base":{"ref":"develop"},"head":{"ref":"lambra/generate-1"}},"repository":{"id":9}}`)
+	closedBody := []byte(`{"action":"closed","pull_request":{"merged":false,"base":{"ref":"develop"},"head":{"ref":"lambra/generate-1"}},"repository":{"id":9}}`)
+	openedBody := []byte(`{"action":"opened","pull_request":{"merged":false},"repository":{"id":9}}`)
+
+	tests := []struct {
+		name      string
+		event     string
+		body      []byte
+		signature string
+		wantErr   error
+		wantType  string
+		wantHash  string
+	}{
+		{name: "push", event: "push", body: pushBody, signature: "sha256=" + sign("s3cret", pushBody), wantType: EventPush, wantHash: "abc"},
+		{name: "merged pull request", event: "pull_request", body: mergeBody, signature: "sha256=" + sign("s3cret", mergeBody), wantType: EventMerge, wantHash: "def"},
+		{name: "pull request closed without merging", event: "pull_request", body: closedBody, signature: "sha256=" + sign("s3cret", closedBody), wantType: EventClose},
+		{name: "opened pull request is ignored", event: "pull_request", body: openedBody, signature: "sha256=" + sign("s3cret", openedBody)},
+		{name: "bad signature", event: "push", body: pushBody, signature: "sha256=" + sign("wrong", pushBody), wantErr: ErrInvalidSignature},
+		{name: "missing signature", event: "push", body: pushBody, wantErr: ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/github", bytes.NewReader(tt.body))
+			req.Header.Set("X-GitHub-Event", tt.event)
+			if tt.signature != "" {
+				req.Header.Set("X-Hub-Signature-256", tt.signature)
+			}
+
+			event, err := provider.ParseWebhook(req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseWebhook() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantType == "" {
+				if event != nil {
+					t.Errorf("ParseWebhook() event = %+v, want nil", event)
+				}
+				return
+			}
+			if event.Type != tt.wantType || event.CommitHash != tt.wantHash || event.RepoID != 9 || event.Branch != "develop" {
+				t.Errorf("unexpected event: %+v", event)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package gitprovider
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/gitlab"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// GitLabProvider adapts the GitLab client to the Provider interface
+type GitLabProvider struct {
+	client        gitlab.Client
+	groupID       string
+	webhookSecret string
+}
+
+// NewGitLabProvider creates a GitLab provider creating repositories under groupID
+func NewGitLabProvider(client gitlab.Client, groupID, webhookSecret string) *GitLabProvider {
+	return &GitLabProvider{
+		client:        client,
+		groupID:       groupID,
+		webhookSecret: webhookSecret,
+	}
+}
+
+func (p *GitLabProvider) Name() string {
+	return models.GitProviderGitLab
+}
+
+func (p *GitLabProvider) CreateRepository(ctx context.Context, req *CreateRepositoryRequest) (*Repository, error) {
+	visibility := "public"
+	if req.Private {
+		visibility = "private"
+	}
+
+	project, err := p.client.CreateProject(ctx, &gitlab.CreateProjectRequest{
+		Name:                 req.Name,
+		Path:                 toRepoPath(req.Name),
+		NamespaceID:          p.groupID,
+		Description:          req.Description,
+		Visibility:           visibility,
+		DefaultBranch:        req.DefaultBranch,
+		InitializeWithReadme: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{
+		ID:            project.ID,
+		Name:          project.Name,
+		FullName:      project.PathWithNamespace,
+		DefaultBranch: project.DefaultBranch,
+		WebURL:        project.WebURL,
+	}, nil
+}
+
+func (p *GitLabProvider) DeleteRepository(ctx context.Context, repo *Repository) error {
+	return p.client.DeleteProject(ctx, repo.ID)
+}
+
+func (p *GitLabProvider) CreateBranch(ctx context.Context, repo *Repository, branch, ref string) error {
+	_, err := p.client.CreateBranch(ctx, repo.ID, branch, ref)
+	return err
+}
+
+// ProtectBranch blocks direct pushes and only lets maintainers merge
+func (p *GitLabProvider) ProtectBranch(ctx context.Context, repo *Repository, branch string) error {
+	return p.client.ProtectBranch(ctx, repo.ID, branch, gitlab.AccessLevelNone, gitlab.AccessLevelMaintainer)
+}
+
+func (p *GitLabProvider) GetFile(ctx context.Context, repo *Repository, path, ref string) (*File, error) {
+	content, err := p.client.GetRawFile(ctx, repo.ID, path, ref)
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &File{Content: content}, nil
+}
+
+func (p *GitLabProvider) Push(ctx context.Context, repo *Repository, req *PushRequest) (*Commit, error) {
+	actions := make([]gitlab.CommitAction, 0, len(req.Changes))
+	for _, change := range req.Changes {
+		action := gitlab.CommitActionUpdate
+		if change.Operation == FileOperationCreate {
+			action = gitlab.CommitActionCreate
+		}
+		actions = append(actions, gitlab.CommitAction{Action: action, FilePath: change.Path, Content: change.Content})
+	}
+
+	commit, err := p.client.CreateCommit(ctx, repo.ID, &gitlab.CreateCommitRequest{
+		Branch:        req.Branch,
+		StartBranch:   req.BaseBranch,
+		CommitMessage: req.Message,
+		Actions:       actions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Commit{SHA: commit.ID, URL: commit.WebURL}, nil
+}
+
+func (p *GitLabProvider) OpenMergeRequest(ctx context.Context, repo *Repository, req *MergeRequestOptions) (*MergeRequest, error) {
+	mr, err := p.client.CreateMergeRequest(ctx, repo.ID, &gitlab.CreateMergeRequestRequest{
+		SourceBranch:       req.SourceBranch,
+		TargetBranch:       req.TargetBranch,
+		Title:              req.Title,
+		Description:        req.Description,
+		RemoveSourceBranch: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MergeRequest{Number: mr.IID, URL: mr.WebURL}, nil
+}
+
+// ParseWebhook verifies the X-Gitlab-Token header and parses push events and
+// merge request events that merge or close the merge request
+func (p *GitLabProvider) ParseWebhook(r *http.Request) (*WebhookEvent, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	token := r.Header.Get("X-Gitlab-Token")
+	if p.webhookSecret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(p.webhookSecret)) != 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	var payload struct {
+		Ref     string `json:"ref"`
+		After   string `json:"after"`
+		Project struct {
+			ID                int64  `json:"id"`
+			PathWithNamespace string `json:"path_with_namespace"`
+		} `json:"project"`
+		ObjectAttributes struct {
+			State          string `json:"state"`
+			SourceBranch   string `json:"source_branch"`
+			TargetBranch   string `json:"target_branch"`
+			MergeCommitSHA string `json:"merge_commit_sha"`
+		} `json:"object_attributes"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	switch r.Header.Get("X-Gitlab-Event") {
+	case "Push Hook":
+		if !strings.HasPrefix(payload.Ref, "refs/heads/") {
+			return nil, nil
+		}
+		return &WebhookEvent{
+			Type:         EventPush,
+			RepoID:       payload.Project.ID,
+			RepoFullName: payload.Project.PathWithNamespace,
+			Branch:       strings.TrimPrefix(payload.Ref, "refs/heads/"),
+			CommitHash:   payload.After,
+		}, nil
+
+	case "Merge Request Hook":
+		eventType := ""
+		switch payload.ObjectAttributes.State {
+		case "merged":
+			eventType = EventMerge
+		case "closed":
+			eventType = EventClose
+		default:
+			return nil, nil
+		}
+		return &WebhookEvent{
+			Type:         eventType,
+			RepoID:       payload.Project.ID,
+			RepoFullName: payload.Project.PathWithNamespace,
+			Branch:       payload.ObjectAttributes.TargetBranch,
+			SourceBranch: payload.ObjectAttributes.SourceBranch,
+			CommitHash:   payload.ObjectAttributes.MergeCommitSHA,
+		}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,101 @@
+package gitprovider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/gitlab"
+)
+
+// fakeGitLabClient records commits and serves files from memory
+type fakeGitLabClient struct {
+	gitlab.Client
+	files   map[string]string
+	commits []*gitlab.CreateCommitRequest
+}
+
+func (f *fakeGitLabClient) GetRawFile(ctx context.Context, projectID int64, filePath, ref string) ([]byte, error) {
+	content, ok := f.files[filePath]
+	if !ok {
+		return nil, gitlab.ErrNotFound
+	}
+	return []byte(content), nil
+}
+
+func (f *fakeGitLabClient) CreateCommit(ctx context.Context, projectID int64, req *gitlab.CreateCommitRequest) (*gitlab.Commit, error) {
+	f.commits = append(f.commits, req)
+	return &gitlab.Commit{ID: "abc123"}, nil
+}
+
+func TestGitLabProvider_GetFileAndPush(t *testing.T) {
+	client := &fakeGitLabClient{files: map[string]string{"models/user.go": "package models"}}
+	provider := NewGitLabProvider(client, "42", "")
+	repo := &Repository{ID: 7}
+
+	if _, err := provider.GetFile(context.Background(), repo, "models/missing.go", "develop"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetFile() error = %v, want ErrNotFound", err)
+	}
+
+	commit, err := provider.Push(context.Background(), repo, &PushRequest{
+		Branch:     "lambra/generate-1",
+		BaseBranch: "develop",
+		Message:    "Regenerate",
+		Changes: []FileChange{
+			{Operation: FileOperationCreate, Path: "models/order.go", Content: "package models"},
+			{Operation: FileOperationUpdate, Path: "models/user.go", Content: "package models // v2"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if commit.SHA != "abc123" {
+		t.Errorf("SHA = %q, want abc123", commit.SHA)
+	}
+
+	sent := client.commits[0]
+	if sent.StartBranch != "develop" || sent.Actions[0].Action != gitlab.CommitActionCreate || sent.Actions[1].Action != gitlab.CommitActionUpdate {
+		t.Errorf("unexpected commit request: %+v", sent)
+	}
+}
+
+func TestGitLabProvider_ParseWebhook(t *testing.T) {
+	provider := NewGitLabProvider(&fakeGitLabClient{}, "42", "s3cret")
+	mergeBody := []byte(`{"project":{"id":7},"object_attributes":{"state":"merged","source_branch":"lambra/generate-1","target_branch":"develop","merge_commit_sha":"def"}}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/gitlab", bytes.NewReader(mergeBody))
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Token", "s3cret")
+
+	event, err := provider.ParseWebhook(req)
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	if event.Type != EventMerge || event.RepoID != 7 || event.SourceBranch != "lambra/generate-1" || event.CommitHash != "def" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	closeBody := []byte(`{"project":{"id":7},"object_attributes":{"state":"closed","source_branch":"lambra/generate-1","target_branch":"develop"}}`)
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/gitlab", bytes.NewReader(closeBody))
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Token", "s3cret")
+
+	event, err = provider.ParseWebhook(req)
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	if event.Type != EventClose || event.Branch != "develop" || event.SourceBranch != "lambra/generate-1" {
+		t.Errorf("unexpected close event: %+v", event)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/gitlab", bytes.NewReader(mergeBody))
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Token", "wrong")
+
+	if _, err := provider.ParseWebhook(req); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("ParseWebhook() error = %v, want ErrInvalidSignature", err)
+	}
+}
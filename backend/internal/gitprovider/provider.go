@@ -0,0 +1,176 @@
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/config"
+	"github.com/yourusername/lambra/internal/gitlab"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+var (
+	// ErrNotFound is returned when the requested remote resource does not exist
+	ErrNotFound = errors.New("git provider: resource not found")
+
+	// ErrInvalidSignature is returned when a webhook cannot be authenticated
+	ErrInvalidSignature = errors.New("git provider: invalid webhook signature")
+)
+
+// File change operations used by Push
+const (
+	FileOperationCreate = "create"
+	FileOperationUpdate = "update"
+)
+
+// Webhook event types understood by Lambra
+const (
+	EventPush  = "push"
+	EventMerge = "merge"
+	EventClose = "close" // A merge/pull request was closed without merging
+)
+
+var repoPathPattern = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// Provider is a git hosting service Lambra can provision repositories on
+type Provider interface {
+	// Name returns the provider identifier (gitlab, github, gitea)
+	Name() string
+	CreateRepository(ctx context.Context, req *CreateRepositoryRequest) (*Repository, error)
+	DeleteRepository(ctx context.Context, repo *Repository) error
+	CreateBranch(ctx context.Context, repo *Repository, branch, ref string) error
+	ProtectBranch(ctx context.Context, repo *Repository, branch string) error
+	GetFile(ctx context.Context, repo *Repository, path, ref string) (*File, error)
+	// Push commits the given changes to Branch, creating it from BaseBranch
+	Push(ctx context.Context, repo *Repository, req *PushRequest) (*Commit, error)
+	OpenMergeRequest(ctx context.Context, repo *Repository, req *MergeRequestOptions) (*MergeRequest, error)
+	// ParseWebhook verifies the request signature and extracts the event.
+	// It returns nil, nil for event types Lambra does not act on.
+	ParseWebhook(r *http.Request) (*WebhookEvent, error)
+}
+
+// Repository identifies a remote repository
+type Repository struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"` // owner/name (or group/path on GitLab)
+	DefaultBranch string `json:"default_branch"`
+	WebURL        string `json:"web_url"`
+}
+
+// CreateRepositoryRequest for creating a remote repository
+type CreateRepositoryRequest struct {
+	Name          string
+	Description   string
+	DefaultBranch string
+	Private       bool
+}
+
+// File is the content of a file at a given ref
+type File struct {
+	Content []byte
+	SHA     string // blob SHA, required by some providers to update the file
+}
+
+// FileChange is a single file modification within a push
+type FileChange struct {
+	Operation string
+	Path      string
+	Content   string
+	SHA       string
+}
+
+// PushRequest describes a commit pushed to a new branch
+type PushRequest struct {
+	Branch     string
+	BaseBranch string
+	Message    string
+	Changes    []FileChange
+}
+
+// Commit is a commit created by Push
+type Commit struct {
+	SHA string `json:"sha"`
+	URL string `json:"url,omitempty"`
+}
+
+// MergeRequestOptions for opening a merge/pull request
+type MergeRequestOptions struct {
+	SourceBranch string
+	TargetBranch string
+	Title        string
+	Description  string
+}
+
+// MergeRequest is an opened merge/pull request
+type MergeRequest struct {
+	Number int64  `json:"number"`
+	URL    string `json:"url"`
+}
+
+// WebhookEvent is the normalized form of an inbound push or merge event
+type WebhookEvent struct {
+	Type         string
+	RepoID       int64
+	RepoFullName string
+	Branch       string // pushed branch, or merge target branch
+	SourceBranch string // merge source branch (merge and close events only)
+	CommitHash   string
+}
+
+// Registry holds the configured providers by name
+type Registry struct {
+	providers map[string]Provider
+}
+
+// NewRegistry creates a registry with every provider that has credentials configured
+func NewRegistry(cfg *config.Config) *Registry {
+	registry := &Registry{providers: make(map[string]Provider)}
+
+	if cfg.GitLab.Token != "" {
+		registry.Register(NewGitLabProvider(gitlab.NewClient(&cfg.GitLab), cfg.GitLab.GroupID, cfg.GitLab.WebhookSecret))
+	}
+	if cfg.GitHub.Token != "" {
+		registry.Register(NewGitHubProvider(&cfg.GitHub))
+	}
+	if cfg.Gitea.Token != "" {
+		registry.Register(NewGiteaProvider(&cfg.Gitea))
+	}
+
+	return registry
+}
+
+// Register adds or replaces a provider
+func (r *Registry) Register(provider Provider) {
+	r.providers[provider.Name()] = provider
+}
+
+// Get returns the provider with the given name
+func (r *Registry) Get(name string) (Provider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("git provider %q is not configured", name)
+	}
+	return provider, nil
+}
+
+// RepositoryFromModel builds a provider repository reference from the stored record
+func RepositoryFromModel(repo *models.GitRepository) *Repository {
+	return &Repository{
+		ID:            repo.GitLabRepoID,
+		Name:          repo.RepoName,
+		FullName:      repo.RepoPath,
+		DefaultBranch: repo.DefaultBranch,
+		WebURL:        repo.RepoURL,
+	}
+}
+
+// toRepoPath converts a repository name to a URL-safe repository path
+func toRepoPath(name string) string {
+	path := repoPathPattern.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+	return strings.Trim(path, "-.")
+}
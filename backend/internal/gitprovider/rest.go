@@ -0,0 +1,196 @@
+package gitprovider
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// restClient is a minimal JSON client shared by the GitHub and Gitea providers
+type restClient struct {
+	baseURL    string
+	authHeader string
+	authValue  string
+	accept     string
+	httpClient *http.Client
+}
+
+func newRESTClient(baseURL, authHeader, authValue, accept string) *restClient {
+	return &restClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		authHeader: authHeader,
+		authValue:  authValue,
+		accept:     accept,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// do sends a JSON request and decodes the JSON response into out (if not nil)
+func (c *restClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set(c.authHeader, c.authValue)
+	req.Header.Set("Accept", c.accept)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// contentsResponse is the file contents shape shared by GitHub and Gitea
+type contentsResponse struct {
+	SHA      string `json:"sha"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+// remoteRepository is the repository shape shared by GitHub and Gitea
+type remoteRepository struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+	HTMLURL       string `json:"html_url"`
+}
+
+func (r *remoteRepository) toRepository() *Repository {
+	return &Repository{
+		ID:            r.ID,
+		Name:          r.Name,
+		FullName:      r.FullName,
+		DefaultBranch: r.DefaultBranch,
+		WebURL:        r.HTMLURL,
+	}
+}
+
+// pullRequestPayload is the pull_request webhook shape shared by GitHub and Gitea
+type pullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Merged         bool   `json:"merged"`
+		MergeCommitSHA string `json:"merge_commit_sha"`
+		Base           struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+	} `json:"pull_request"`
+	Repository remoteRepository `json:"repository"`
+}
+
+// pushPayload is the push webhook shape shared by GitHub and Gitea
+type pushPayload struct {
+	Ref        string           `json:"ref"`
+	After      string           `json:"after"`
+	Repository remoteRepository `json:"repository"`
+}
+
+// parsePushOrPullRequest converts a push or pull_request payload into a WebhookEvent
+func parsePushOrPullRequest(eventType string, body []byte) (*WebhookEvent, error) {
+	switch eventType {
+	case "push":
+		var payload pushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("invalid push payload: %w", err)
+		}
+		if !strings.HasPrefix(payload.Ref, "refs/heads/") {
+			return nil, nil
+		}
+		return &WebhookEvent{
+			Type:         EventPush,
+			RepoID:       payload.Repository.ID,
+			RepoFullName: payload.Repository.FullName,
+			Branch:       strings.TrimPrefix(payload.Ref, "refs/heads/"),
+			CommitHash:   payload.After,
+		}, nil
+
+	case "pull_request":
+		var payload pullRequestPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("invalid pull request payload: %w", err)
+		}
+		if payload.Action != "closed" {
+			return nil, nil
+		}
+		eventType := EventClose
+		if payload.PullRequest.Merged {
+			eventType = EventMerge
+		}
+		return &WebhookEvent{
+			Type:         eventType,
+			RepoID:       payload.Repository.ID,
+			RepoFullName: payload.Repository.FullName,
+			Branch:       payload.PullRequest.Base.Ref,
+			SourceBranch: payload.PullRequest.Head.Ref,
+			CommitHash:   payload.PullRequest.MergeCommitSHA,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// validHMAC reports whether signature is the hex HMAC-SHA256 of body keyed with secret
+func validHMAC(secret string, body []byte, signature string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
+
+// readBody reads the webhook body, bounded to protect against oversized payloads
+func readBody(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 10<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook body: %w", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,196 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// Actions of a change
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Resources a change applies to
+const (
+	ResourceProject  = "project"
+	ResourceEntity   = "entity"
+	ResourceEndpoint = "endpoint"
+)
+
+// Change is one create, update or delete of applying a manifest
+type Change struct {
+	Action   string   `json:"action"`
+	Resource string   `json:"resource"`
+	Entity   string   `json:"entity,omitempty"`   // Name of the entity, or of the entity owning the endpoint
+	Endpoint string   `json:"endpoint,omitempty"` // Key of the endpoint, e.g. "GET /users/:id"
+	Fields   []string `json:"fields,omitempty"`   // Fields an update changes
+}
+
+// Plan lists the changes that turn the current state of a project into a manifest
+type Plan struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty reports whether the project already matches the manifest
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Diff returns the plan turning current into desired. Its changes can be applied in order:
+// the project, endpoint deletes, entity deletes, entity creates and updates, then endpoint
+// creates and updates, so a route or table freed by a delete can be reused.
+func Diff(current, desired *Manifest) (*Plan, error) {
+	plan := &Plan{Changes: []Change{}}
+
+	projectFields, err := diffProject(current, desired)
+	if err != nil {
+		return nil, err
+	}
+	if len(projectFields) > 0 {
+		plan.Changes = append(plan.Changes, Change{Action: ActionUpdate, Resource: ResourceProject, Fields: projectFields})
+	}
+
+	// Endpoints are compared within their entity, so moving one to another entity replaces it
+	for _, entity := range current.Spec.Entities {
+		wanted := desired.Entity(entity.Name)
+		for _, endpoint := range entity.Endpoints {
+			if wanted == nil || wanted.Endpoint(endpoint.Key()) == nil {
+				plan.Changes = append(plan.Changes, Change{
+					Action: ActionDelete, Resource: ResourceEndpoint, Entity: entity.Name, Endpoint: endpoint.Key(),
+				})
+			}
+		}
+	}
+	for _, entity := range current.Spec.Entities {
+		if desired.Entity(entity.Name) == nil {
+			plan.Changes = append(plan.Changes, Change{Action: ActionDelete, Resource: ResourceEntity, Entity: entity.Name})
+		}
+	}
+
+	for i := range desired.Spec.Entities {
+		entity := &desired.Spec.Entities[i]
+		existing := current.Entity(entity.Name)
+		if existing == nil {
+			plan.Changes = append(plan.Changes, Change{Action: ActionCreate, Resource: ResourceEntity, Entity: entity.Name})
+			continue
+		}
+		fields, err := diffEntity(existing, entity)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			plan.Changes = append(plan.Changes, Change{Action: ActionUpdate, Resource: ResourceEntity, Entity: entity.Name, Fields: fields})
+		}
+	}
+
+	for i := range desired.Spec.Entities {
+		entity := &desired.Spec.Entities[i]
+		existing := current.Entity(entity.Name)
+		for j := range entity.Endpoints {
+			endpoint := &entity.Endpoints[j]
+			var before *Endpoint
+			if existing != nil {
+				before = existing.Endpoint(endpoint.Key())
+			}
+			if before == nil {
+				plan.Changes = append(plan.Changes, Change{
+					Action: ActionCreate, Resource: ResourceEndpoint, Entity: entity.Name, Endpoint: endpoint.Key(),
+				})
+				continue
+			}
+			fields, err := diffEndpoint(before, endpoint)
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) > 0 {
+				plan.Changes = append(plan.Changes, Change{
+					Action: ActionUpdate, Resource: ResourceEndpoint, Entity: entity.Name, Endpoint: endpoint.Key(), Fields: fields,
+				})
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// fieldDiff collects the names of the fields whose JSON forms differ
+type fieldDiff struct {
+	fields []string
+	err    error
+}
+
+func (d *fieldDiff) compare(name string, before, after interface{}) {
+	if d.err != nil {
+		return
+	}
+	b, err := json.Marshal(before)
+	if err != nil {
+		d.err = fmt.Errorf("failed to compare %s: %w", name, err)
+		return
+	}
+	a, err := json.Marshal(after)
+	if err != nil {
+		d.err = fmt.Errorf("failed to compare %s: %w", name, err)
+		return
+	}
+	if !bytes.Equal(b, a) {
+		d.fields = append(d.fields, name)
+	}
+}
+
+func diffProject(current, desired *Manifest) ([]string, error) {
+	var d fieldDiff
+	d.compare("name", current.Metadata.Name, desired.Metadata.Name)
+	d.compare("description", current.Spec.Description, desired.Spec.Description)
+	d.compare("image", current.Spec.Image, desired.Spec.Image)
+	d.compare("port", Port(&current.Spec), Port(&desired.Spec))
+	d.compare("module_path", current.Spec.ModulePath, desired.Spec.ModulePath)
+	d.compare("dialect", current.Spec.Dialect, desired.Spec.Dialect)
+	d.compare("environments", nonEmpty(current.Spec.Environments), nonEmpty(desired.Spec.Environments))
+	return d.fields, d.err
+}
+
+func diffEntity(current, desired *Entity) ([]string, error) {
+	var d fieldDiff
+	d.compare("table_name", current.TableName, desired.TableName)
+	d.compare("description", current.Description, desired.Description)
+	d.compare("fields", current.Fields, desired.Fields)
+	return d.fields, d.err
+}
+
+func diffEndpoint(current, desired *Endpoint) ([]string, error) {
+	var d fieldDiff
+	d.compare("name", current.Name, desired.Name)
+	d.compare("description", current.Description, desired.Description)
+	d.compare("require_auth", RequireAuth(current), RequireAuth(desired))
+	d.compare("request_schema", current.RequestSchema, desired.RequestSchema)
+	d.compare("response_schema", current.ResponseSchema, desired.ResponseSchema)
+	d.compare("mock", current.Mock, desired.Mock)
+	return d.fields, d.err
+}
+
+// Port returns the container port of a spec, the default port when it is not set
+func Port(spec *Spec) int {
+	if spec.Port == 0 {
+		return models.DefaultProjectPort
+	}
+	return spec.Port
+}
+
+// RequireAuth reports whether an endpoint requires authentication, true when it is not set
+func RequireAuth(endpoint *Endpoint) bool {
+	return endpoint.RequireAuth == nil || *endpoint.RequireAuth
+}
+
+// nonEmpty treats empty environments like absent ones
+func nonEmpty(environments map[string]models.EnvironmentSettings) map[string]models.EnvironmentSettings {
+	if len(environments) == 0 {
+		return nil
+	}
+	return environments
+}
@@ -0,0 +1,466 @@
+// Package manifest converts projects to and from lambra.yaml, a declarative description of a
+// project's settings, entities and endpoints meant to be kept under version control.
+package manifest
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/generator"
+	"github.com/yourusername/lambra/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// APIVersion and Kind identify a project manifest
+const (
+	APIVersion = "lambra/v1"
+	Kind       = "Project"
+)
+
+// Formats a manifest is encoded in
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// FileName is the conventional name of a manifest in a repository
+const FileName = "lambra.yaml"
+
+// Manifest is the desired state of a project. Entities are identified by name and endpoints by
+// their entity, method and path, so renaming either replaces it.
+type Manifest struct {
+	APIVersion string   `json:"apiVersion" binding:"required,eq=lambra/v1"`
+	Kind       string   `json:"kind" binding:"required,eq=Project"`
+	Metadata   Metadata `json:"metadata"`
+	Spec       Spec     `json:"spec"`
+}
+
+// Metadata names the project a manifest describes
+type Metadata struct {
+	Name      string `json:"name" binding:"required,min=3,max=100"`
+	Namespace string `json:"namespace" binding:"required,min=3,max=50"` // Must match the project, it cannot be changed
+}
+
+// Spec holds the settings, entities and endpoints of a project
+type Spec struct {
+	Description  string                                `json:"description,omitempty" binding:"max=500"`
+	Image        string                                `json:"image,omitempty" binding:"max=255"`
+	Port         int                                   `json:"port,omitempty" binding:"omitempty,min=1,max=65535"`
+	ModulePath   string                                `json:"module_path,omitempty" binding:"max=255"`
+	Dialect      string                                `json:"dialect,omitempty" binding:"omitempty,oneof=postgres mysql"`
+	Environments map[string]models.EnvironmentSettings `json:"environments,omitempty" binding:"omitempty,dive,keys,oneof=dev staging production,endkeys"`
+	Entities     []Entity                              `json:"entities" binding:"dive"`
+}
+
+// Entity is an entity of a project with its endpoints
+type Entity struct {
+	Name        string               `json:"name" binding:"required,min=2,max=100"`
+	TableName   string               `json:"table_name" binding:"required,min=2,max=100"`
+	Description string               `json:"description,omitempty" binding:"max=500"`
+	Fields      []models.EntityField `json:"fields" binding:"required,min=1"`
+	Endpoints   []Endpoint           `json:"endpoints,omitempty" binding:"dive"`
+}
+
+// Endpoint is an endpoint of an entity. Schemas and the mock are plain YAML/JSON values.
+type Endpoint struct {
+	Name           string      `json:"name" binding:"required,min=2,max=100"`
+	Method         string      `json:"method" binding:"required,oneof=GET POST PUT DELETE PATCH"`
+	Path           string      `json:"path" binding:"required,min=1,max=255"`
+	Description    string      `json:"description,omitempty" binding:"max=500"`
+	RequireAuth    *bool       `json:"require_auth,omitempty"` // Defaults to true
+	RequestSchema  interface{} `json:"request_schema,omitempty"`
+	ResponseSchema interface{} `json:"response_schema,omitempty"`
+	Mock           interface{} `json:"mock,omitempty"` // models.EndpointMock
+}
+
+// Key identifies an endpoint within its entity
+func (e *Endpoint) Key() string {
+	return e.Method + " " + e.Path
+}
+
+// Entity returns the entity of the manifest with a name, or nil
+func (m *Manifest) Entity(name string) *Entity {
+	for i := range m.Spec.Entities {
+		if m.Spec.Entities[i].Name == name {
+			return &m.Spec.Entities[i]
+		}
+	}
+	return nil
+}
+
+// Endpoint returns the endpoint of the entity with a key, or nil
+func (e *Entity) Endpoint(key string) *Endpoint {
+	for i := range e.Endpoints {
+		if e.Endpoints[i].Key() == key {
+			return &e.Endpoints[i]
+		}
+	}
+	return nil
+}
+
+// Validate reports the entity names and table names a manifest uses more than once, and routes
+// the generated Gin router cannot register together. Table names are compared ignoring case, as
+// MySQL does on case-insensitive file systems.
+func (m *Manifest) Validate() error {
+	var fields []apperror.FieldError
+	names := make(map[string]int)
+	tables := make(map[string]int)
+
+	// declaredRoute is an endpoint declared earlier in the manifest
+	type declaredRoute struct {
+		field    string
+		endpoint *Endpoint
+	}
+	var routes []declaredRoute
+
+	for i, entity := range m.Spec.Entities {
+		if first, ok := names[entity.Name]; ok {
+			fields = append(fields, apperror.FieldError{
+				Field:   fmt.Sprintf("spec.entities[%d].name", i),
+				Message: fmt.Sprintf("duplicates spec.entities[%d].name", first),
+			})
+		} else {
+			names[entity.Name] = i
+		}
+		table := strings.ToLower(entity.TableName)
+		if first, ok := tables[table]; ok {
+			fields = append(fields, apperror.FieldError{
+				Field:   fmt.Sprintf("spec.entities[%d].table_name", i),
+				Message: fmt.Sprintf("duplicates spec.entities[%d].table_name", first),
+			})
+		} else {
+			tables[table] = i
+		}
+
+		for j := range entity.Endpoints {
+			endpoint := &m.Spec.Entities[i].Endpoints[j]
+			for _, earlier := range routes {
+				if reason := generator.RouteConflict(endpoint.Method, endpoint.Path, earlier.endpoint.Method, earlier.endpoint.Path); reason != "" {
+					fields = append(fields, apperror.FieldError{
+						Field:   fmt.Sprintf("spec.entities[%d].endpoints[%d].path", i, j),
+						Message: fmt.Sprintf("conflicts with %s (%s): %s", earlier.field, earlier.endpoint.Key(), reason),
+					})
+					break
+				}
+			}
+			routes = append(routes, declaredRoute{field: fmt.Sprintf("spec.entities[%d].endpoints[%d]", i, j), endpoint: endpoint})
+		}
+	}
+
+	if len(fields) > 0 {
+		return apperror.Validation("invalid manifest", fields...)
+	}
+	return nil
+}
+
+// Parse reads a manifest from YAML or JSON; JSON is read as YAML. Unknown keys are rejected so
+// typos do not silently drop settings.
+func Parse(data []byte) (*Manifest, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, apperror.Validation("invalid manifest: " + err.Error())
+	}
+
+	// Going through JSON applies the JSON names of the manifest types
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, apperror.Validation("invalid manifest: " + err.Error())
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	var m Manifest
+	if err := decoder.Decode(&m); err != nil {
+		return nil, apperror.Validation("invalid manifest: " + err.Error())
+	}
+	return &m, nil
+}
+
+// ErrUnknownFormat is returned when encoding a manifest in a format other than YAML or JSON
+var ErrUnknownFormat = apperror.Validation("unsupported manifest format",
+	apperror.FieldError{Field: "format", Message: "must be one of " + FormatYAML + ", " + FormatJSON})
+
+// Encode writes a manifest as YAML or JSON
+func Encode(m *Manifest, format string) ([]byte, error) {
+	if format != FormatYAML && format != FormatJSON {
+		return nil, ErrUnknownFormat
+	}
+
+	if format == FormatJSON {
+		data, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode manifest: %w", err)
+		}
+		return append(data, '\n'), nil
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	// JSON is YAML in flow style; re-encoding its nodes in block style keeps the key order
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	blockStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// blockStyle clears the flow and quoting styles of a node tree; strings that would read as
+// another type stay quoted
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// FromProject builds the manifest of a project's current state, sorted so exports are stable
+func FromProject(project *models.Project, entities []models.Entity, endpoints []models.Endpoint) (*Manifest, error) {
+	m := &Manifest{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Metadata:   Metadata{Name: project.Name, Namespace: project.Namespace},
+		Spec: Spec{
+			Description:  project.Description.String,
+			Image:        project.Image,
+			Port:         project.Port,
+			ModulePath:   project.ModulePath,
+			Dialect:      project.Dialect,
+			Environments: project.GetEnvironments(),
+			Entities:     make([]Entity, 0, len(entities)),
+		},
+	}
+
+	byID := make(map[int64]int, len(entities))
+	for i, entity := range entities {
+		var fields []models.EntityField
+		if len(entity.Fields) > 0 {
+			if err := json.Unmarshal(entity.Fields, &fields); err != nil {
+				return nil, fmt.Errorf("failed to parse fields of entity %s: %w", entity.Name, err)
+			}
+		}
+		m.Spec.Entities = append(m.Spec.Entities, Entity{
+			Name:        entity.Name,
+			TableName:   entity.TableName,
+			Description: entity.Description.String,
+			Fields:      fields,
+		})
+		byID[entity.ID] = i
+	}
+
+	for _, endpoint := range endpoints {
+		i, ok := byID[endpoint.EntityID]
+		if !ok {
+			continue
+		}
+		requestSchema, err := decodeSchema(endpoint.RequestSchema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse request schema of %s %s: %w", endpoint.Method, endpoint.Path, err)
+		}
+		responseSchema, err := decodeSchema(endpoint.ResponseSchema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse response schema of %s %s: %w", endpoint.Method, endpoint.Path, err)
+		}
+		mock, err := decodeSchema(endpoint.Mock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse mock of %s %s: %w", endpoint.Method, endpoint.Path, err)
+		}
+		requireAuth := endpoint.RequireAuth
+		m.Spec.Entities[i].Endpoints = append(m.Spec.Entities[i].Endpoints, Endpoint{
+			Name:           endpoint.Name,
+			Method:         endpoint.Method,
+			Path:           endpoint.Path,
+			Description:    endpoint.Description.String,
+			RequireAuth:    &requireAuth,
+			RequestSchema:  requestSchema,
+			ResponseSchema: responseSchema,
+			Mock:           mock,
+		})
+	}
+
+	sort.Slice(m.Spec.Entities, func(i, j int) bool {
+		return m.Spec.Entities[i].Name < m.Spec.Entities[j].Name
+	})
+	for _, entity := range m.Spec.Entities {
+		sort.Slice(entity.Endpoints, func(i, j int) bool {
+			a, b := entity.Endpoints[i], entity.Endpoints[j]
+			if a.Path != b.Path {
+				return a.Path < b.Path
+			}
+			return a.Method < b.Method
+		})
+	}
+
+	return m, nil
+}
+
+// FromSnapshot builds the manifest of the state frozen in snapshot metadata. Settings a snapshot
+// did not record stay empty, as do the endpoints of snapshots taken before they recorded the
+// entities of endpoints.
+func FromSnapshot(metadata *models.SnapshotMetadata) (*Manifest, error) {
+	project := &models.Project{}
+	project.Name, _ = metadata.Config["name"].(string)
+	project.Namespace, _ = metadata.Config["namespace"].(string)
+	description, _ := metadata.Config["description"].(string)
+	project.Description = sql.NullString{String: description, Valid: description != ""}
+	project.Image, _ = metadata.Config["image"].(string)
+	if port, ok := metadata.Config["port"].(float64); ok {
+		project.Port = int(port)
+	}
+	project.ModulePath, _ = metadata.Config["module_path"].(string)
+	project.Dialect, _ = metadata.Config["dialect"].(string)
+	if environments, ok := metadata.Config["environments"]; ok && environments != nil {
+		raw, err := json.Marshal(environments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal snapshot environments: %w", err)
+		}
+		project.Environments = raw
+	}
+
+	// Snapshots do not keep internal IDs, so entities are numbered to link their endpoints
+	entities := make([]models.Entity, len(metadata.Entities))
+	ids := make(map[string]int64, len(metadata.Entities))
+	for i, entity := range metadata.Entities {
+		entity.ID = int64(i + 1)
+		entities[i] = entity
+		ids[entity.UUID] = entity.ID
+	}
+	endpoints := make([]models.Endpoint, len(metadata.Endpoints))
+	for i, endpoint := range metadata.Endpoints {
+		endpoint.EntityID = ids[metadata.EndpointEntities[endpoint.UUID]]
+		endpoints[i] = endpoint
+	}
+
+	return FromProject(project, entities, endpoints)
+}
+
+// ToProject builds the project, entities and endpoints a manifest describes without storing
+// them, e.g. to generate code offline. Entities are numbered to link their endpoints.
+func (m *Manifest) ToProject() (*models.Project, []models.Entity, []models.Endpoint, error) {
+	project := &models.Project{}
+	if err := m.ApplyTo(project); err != nil {
+		return nil, nil, nil, err
+	}
+
+	entities := make([]models.Entity, len(m.Spec.Entities))
+	var endpoints []models.Endpoint
+	for i := range m.Spec.Entities {
+		spec := &m.Spec.Entities[i]
+		entity := &entities[i]
+		entity.ID = int64(i + 1)
+		if err := spec.ApplyTo(entity); err != nil {
+			return nil, nil, nil, fmt.Errorf("entity %s: %w", spec.Name, err)
+		}
+
+		for j := range spec.Endpoints {
+			endpoint := models.Endpoint{EntityID: entity.ID}
+			if err := spec.Endpoints[j].ApplyTo(&endpoint); err != nil {
+				return nil, nil, nil, fmt.Errorf("endpoint %s: %w", spec.Endpoints[j].Key(), err)
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	return project, entities, endpoints, nil
+}
+
+// ApplyTo copies the metadata and settings of a manifest onto a project
+func (m *Manifest) ApplyTo(project *models.Project) error {
+	environments := m.Spec.Environments
+	if environments == nil {
+		environments = map[string]models.EnvironmentSettings{}
+	}
+	data, err := json.Marshal(environments)
+	if err != nil {
+		return fmt.Errorf("failed to marshal environments: %w", err)
+	}
+
+	project.Name = m.Metadata.Name
+	project.Namespace = m.Metadata.Namespace
+	project.Description = nullString(m.Spec.Description)
+	project.Image = m.Spec.Image
+	project.Port = Port(&m.Spec)
+	project.ModulePath = m.Spec.ModulePath
+	project.Dialect = m.Spec.Dialect
+	project.Environments = data
+	return nil
+}
+
+// ApplyTo copies the manifest spec of an entity onto it
+func (e *Entity) ApplyTo(entity *models.Entity) error {
+	fields, err := json.Marshal(e.Fields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fields: %w", err)
+	}
+	entity.Name = e.Name
+	entity.TableName = e.TableName
+	entity.Description = nullString(e.Description)
+	entity.Fields = fields
+	return nil
+}
+
+// ApplyTo copies the manifest spec of an endpoint onto it
+func (e *Endpoint) ApplyTo(endpoint *models.Endpoint) error {
+	requestSchema, err := EncodeSchema(e.RequestSchema)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request schema: %w", err)
+	}
+	responseSchema, err := EncodeSchema(e.ResponseSchema)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response schema: %w", err)
+	}
+	mock, err := EncodeSchema(e.Mock)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mock: %w", err)
+	}
+	endpoint.Name = e.Name
+	endpoint.Method = e.Method
+	endpoint.Path = e.Path
+	endpoint.Description = nullString(e.Description)
+	endpoint.RequireAuth = RequireAuth(e)
+	endpoint.RequestSchema = requestSchema
+	endpoint.ResponseSchema = responseSchema
+	endpoint.Mock = mock
+	return nil
+}
+
+// EncodeSchema returns the stored JSON of a manifest schema, nil when it is absent
+func EncodeSchema(schema interface{}) (json.RawMessage, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	return json.Marshal(schema)
+}
+
+func decodeSchema(raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var schema interface{}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
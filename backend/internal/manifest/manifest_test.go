@@ -0,0 +1,275 @@
+package manifest
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+func projectFixture() (*models.Project, []models.Entity, []models.Endpoint) {
+	project := &models.Project{
+		Name:        "shop",
+		Namespace:   "shop",
+		Description: sql.NullString{String: "Online shop", Valid: true},
+		Image:       "registry.example.com/shop",
+		Port:        8080,
+		Dialect:     models.DialectPostgres,
+	}
+	entities := []models.Entity{
+		{BaseEntity: models.BaseEntity{ID: 2}, Name: "User", TableName: "users",
+			Fields: json.RawMessage(`[{"name":"email","type":"string","required":true,"unique":true,"default_value":"true"}]`)},
+		{BaseEntity: models.BaseEntity{ID: 1}, Name: "Order", TableName: "orders",
+			Fields: json.RawMessage(`[{"name":"total","type":"float","required":true,"unique":false}]`)},
+	}
+	endpoints := []models.Endpoint{
+		{EntityID: 2, Name: "Get user", Method: "GET", Path: "/users/:id", RequireAuth: true,
+			ResponseSchema: json.RawMessage(`{"type":"object","properties":{"email":{"type":"string"}}}`),
+			Mock:           json.RawMessage(`{"example":{"email":"jane@example.com"},"scenarios":[{"name":"missing","status":404}]}`)},
+		{EntityID: 2, Name: "List users", Method: "GET", Path: "/users", RequireAuth: false},
+	}
+	return project, entities, endpoints
+}
+
+func TestFromProject(t *testing.T) {
+	m, err := FromProject(projectFixture())
+	if err != nil {
+		t.Fatalf("FromProject() error = %v", err)
+	}
+
+	if len(m.Spec.Entities) != 2 || m.Spec.Entities[0].Name != "Order" || m.Spec.Entities[1].Name != "User" {
+		t.Fatalf("Entities = %+v, want Order then User", m.Spec.Entities)
+	}
+	user := m.Spec.Entities[1]
+	if len(user.Endpoints) != 2 || user.Endpoints[0].Path != "/users" || user.Endpoints[1].Path != "/users/:id" {
+		t.Errorf("Endpoints = %+v, want sorted by path", user.Endpoints)
+	}
+	if RequireAuth(&user.Endpoints[0]) {
+		t.Error("List users requires auth, want public")
+	}
+}
+
+func TestEncodeUnknownFormat(t *testing.T) {
+	m, err := FromProject(projectFixture())
+	if err != nil {
+		t.Fatalf("FromProject() error = %v", err)
+	}
+
+	_, err = Encode(m, "xml")
+	if !errors.Is(err, apperror.ErrValidation) {
+		t.Fatalf("Encode(xml) error = %v, want a validation error", err)
+	}
+	if fields := apperror.Fields(err); len(fields) != 1 || fields[0].Field != "format" {
+		t.Errorf("Encode(xml) fields = %v, want format", fields)
+	}
+}
+
+func TestEncodeParseRoundTrip(t *testing.T) {
+	m, err := FromProject(projectFixture())
+	if err != nil {
+		t.Fatalf("FromProject() error = %v", err)
+	}
+
+	for _, format := range []string{FormatYAML, FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			data, err := Encode(m, format)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if format == FormatYAML && (strings.Contains(string(data), "{") || !strings.HasPrefix(string(data), "apiVersion: lambra/v1\n")) {
+				t.Errorf("YAML is not in block style with stable key order:\n%s", data)
+			}
+
+			parsed, err := Parse(data)
+			if err != nil {
+				t.Fatalf("Parse() error = %v\n%s", err, data)
+			}
+			plan, err := Diff(m, parsed)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if !plan.Empty() {
+				t.Errorf("round trip changed the manifest: %+v\n%s", plan.Changes, data)
+			}
+			if got := parsed.Entity("User").Fields[0].DefaultValue; got != "true" {
+				t.Errorf("DefaultValue = %q, want the string \"true\"", got)
+			}
+		})
+	}
+}
+
+func TestParseRejectsUnknownKeys(t *testing.T) {
+	_, err := Parse([]byte("apiVersion: lambra/v1\nkind: Project\nspec:\n  imgae: shop\n"))
+	if err == nil {
+		t.Error("Parse() error = nil, want error for unknown key")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	current, err := FromProject(projectFixture())
+	if err != nil {
+		t.Fatalf("FromProject() error = %v", err)
+	}
+
+	desired, err := Parse([]byte(`
+apiVersion: lambra/v1
+kind: Project
+metadata:
+  name: shop
+  namespace: shop
+spec:
+  description: Online shop
+  image: registry.example.com/shop
+  port: 9090
+  dialect: postgres
+  entities:
+    - name: User
+      table_name: users
+      fields:
+        - {name: email, type: string, required: true, unique: true, default_value: "true"}
+      endpoints:
+        - name: Get user
+          method: GET
+          path: /users/:id
+          response_schema:
+            type: object
+            properties:
+              email: {type: string}
+          mock:
+            example: {email: jane@example.com}
+            scenarios:
+              - {name: missing, status: 404}
+        - name: Create user
+          method: POST
+          path: /users
+    - name: Product
+      table_name: products
+      fields:
+        - {name: title, type: string}
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	plan, err := Diff(current, desired)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	want := []Change{
+		{Action: ActionUpdate, Resource: ResourceProject, Fields: []string{"port"}},
+		{Action: ActionDelete, Resource: ResourceEndpoint, Entity: "User", Endpoint: "GET /users"},
+		{Action: ActionDelete, Resource: ResourceEntity, Entity: "Order"},
+		{Action: ActionCreate, Resource: ResourceEntity, Entity: "Product"},
+		{Action: ActionCreate, Resource: ResourceEndpoint, Entity: "User", Endpoint: "POST /users"},
+	}
+	if !reflect.DeepEqual(plan.Changes, want) {
+		t.Errorf("Diff() = %+v\nwant %+v", plan.Changes, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	m := &Manifest{Spec: Spec{Entities: []Entity{
+		{Name: "User", TableName: "users", Endpoints: []Endpoint{{Method: "GET", Path: "/users"}}},
+		{Name: "User", TableName: "people", Endpoints: []Endpoint{{Method: "GET", Path: "/users"}}},
+	}}}
+
+	err := m.Validate()
+	if err == nil {
+		t.Fatal("Validate() error = nil, want duplicates")
+	}
+	if !strings.Contains(err.Error(), "invalid manifest") {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestValidateConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		entities []Entity
+		fields   []string
+	}{
+		{
+			name: "no conflicts",
+			entities: []Entity{
+				{Name: "User", TableName: "users", Endpoints: []Endpoint{{Method: "GET", Path: "/users/:id"}, {Method: "PUT", Path: "/users/:id"}}},
+				{Name: "Order", TableName: "orders", Endpoints: []Endpoint{{Method: "GET", Path: "/users/:id/orders"}}},
+			},
+		},
+		{
+			name: "table names differing in case",
+			entities: []Entity{
+				{Name: "User", TableName: "users"},
+				{Name: "Member", TableName: "Users"},
+			},
+			fields: []string{"spec.entities[1].table_name"},
+		},
+		{
+			name: "static segment next to a parameter",
+			entities: []Entity{
+				{Name: "User", TableName: "users", Endpoints: []Endpoint{{Method: "GET", Path: "/users/:id"}, {Method: "GET", Path: "/users/me"}}},
+			},
+			fields: []string{"spec.entities[0].endpoints[1].path"},
+		},
+		{
+			name: "parameters with different names",
+			entities: []Entity{
+				{Name: "User", TableName: "users", Endpoints: []Endpoint{{Method: "GET", Path: "/users/:id"}}},
+				{Name: "Profile", TableName: "profiles", Endpoints: []Endpoint{{Method: "GET", Path: "/users/:userId/profile"}}},
+			},
+			fields: []string{"spec.entities[1].endpoints[0].path"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Manifest{Spec: Spec{Entities: tt.entities}}).Validate()
+			var fields []string
+			for _, field := range apperror.Fields(err) {
+				fields = append(fields, field.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("Validate() error = %v, fields %v, want %v", err, fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestToProjectRoundTrip(t *testing.T) {
+	m, err := FromProject(projectFixture())
+	if err != nil {
+		t.Fatalf("FromProject() error = %v", err)
+	}
+
+	project, entities, endpoints, err := m.ToProject()
+	if err != nil {
+		t.Fatalf("ToProject() error = %v", err)
+	}
+	if project.Namespace != "shop" || project.Description.String != "Online shop" || project.Port != 8080 {
+		t.Errorf("project = %+v", project)
+	}
+	if len(entities) != 2 || len(endpoints) != 2 {
+		t.Fatalf("got %d entities and %d endpoints, want 2 and 2", len(entities), len(endpoints))
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.EntityID != entities[1].ID {
+			t.Errorf("%s %s belongs to entity %d, want User (%d)", endpoint.Method, endpoint.Path, endpoint.EntityID, entities[1].ID)
+		}
+	}
+
+	back, err := FromProject(project, entities, endpoints)
+	if err != nil {
+		t.Fatalf("FromProject() error = %v", err)
+	}
+	plan, err := Diff(m, back)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("Diff() after round trip = %+v, want no changes", plan.Changes)
+	}
+}
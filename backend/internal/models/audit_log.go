@@ -0,0 +1,74 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// AuditLog records one create, update or delete. Entries are never updated or deleted.
+type AuditLog struct {
+	ID           int64           `db:"id" json:"-"`
+	UUID         string          `db:"uuid" json:"id"`
+	ProjectUUID  sql.NullString  `db:"project_uuid" json:"-"` // Empty for resources outside projects (templates)
+	ResourceType string          `db:"resource_type" json:"resource_type"`
+	ResourceID   string          `db:"resource_id" json:"resource_id"` // UUID of the resource
+	Action       string          `db:"action" json:"action"`
+	Actor        string          `db:"actor" json:"actor"`
+	RequestID    sql.NullString  `db:"request_id" json:"-"`
+	Changes      json.RawMessage `db:"changes" json:"changes"` // Changed fields by path: {"path": {"before", "after"}}
+	CreatedAt    time.Time       `db:"created_at" json:"created_at"`
+}
+
+// MarshalJSON custom JSON marshaling for AuditLog
+func (l AuditLog) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		UUID         string          `json:"id"`
+		ProjectID    string          `json:"project_id,omitempty"`
+		ResourceType string          `json:"resource_type"`
+		ResourceID   string          `json:"resource_id"`
+		Action       string          `json:"action"`
+		Actor        string          `json:"actor"`
+		RequestID    string          `json:"request_id,omitempty"`
+		Changes      json.RawMessage `json:"changes"`
+		CreatedAt    time.Time       `json:"created_at"`
+	}{
+		UUID:         l.UUID,
+		ProjectID:    l.ProjectUUID.String,
+		ResourceType: l.ResourceType,
+		ResourceID:   l.ResourceID,
+		Action:       l.Action,
+		Actor:        l.Actor,
+		RequestID:    l.RequestID.String,
+		Changes:      l.Changes,
+		CreatedAt:    l.CreatedAt,
+	})
+}
+
+// AuditLogFilter for querying audit logs
+type AuditLogFilter struct {
+	ProjectUUID  string
+	ResourceType string
+	ResourceID   string
+	Action       string
+	Actor        string
+	Since        *time.Time
+	Until        *time.Time
+}
+
+// Audit action constants
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore" // Brought back from the trash
+	AuditActionPurge   = "purge"   // Permanently removed from the trash
+)
+
+// Audited resource type constants
+const (
+	AuditResourceProject  = "project"
+	AuditResourceEntity   = "entity"
+	AuditResourceEndpoint = "endpoint"
+	AuditResourceTemplate = "template"
+)
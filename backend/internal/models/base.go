@@ -53,6 +53,24 @@ func (b *BaseEntity) ToJSON() BaseEntityJSON {
 	return result
 }
 
+// ToBaseEntity converts BaseEntityJSON back to BaseEntity; the internal ID is not part of the JSON
+func (j *BaseEntityJSON) ToBaseEntity() BaseEntity {
+	result := BaseEntity{
+		UUID:      j.UUID,
+		CreatedBy: sql.NullString{String: j.CreatedBy, Valid: j.CreatedBy != ""},
+		UpdatedBy: sql.NullString{String: j.UpdatedBy, Valid: j.UpdatedBy != ""},
+		DeletedBy: sql.NullString{String: j.DeletedBy, Valid: j.DeletedBy != ""},
+		CreatedAt: j.CreatedAt,
+		UpdatedAt: j.UpdatedAt,
+	}
+
+	if j.DeletedAt != nil {
+		result.DeletedAt = sql.NullTime{Time: *j.DeletedAt, Valid: true}
+	}
+
+	return result
+}
+
 // SetCreatedBy sets the created_by field
 func (b *BaseEntity) SetCreatedBy(user string) {
 	b.CreatedBy = sql.NullString{String: user, Valid: true}
@@ -2,23 +2,64 @@ package models
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
 // Deployment represents a deployment instance
 type Deployment struct {
-	ID            int64          `db:"id" json:"id"`
-	ProjectID     int64          `db:"project_id" json:"project_id"`
-	SnapshotID    sql.NullInt64  `db:"snapshot_id" json:"snapshot_id,omitempty"`
+	BaseEntity
+	ProjectID     int64          `db:"project_id" json:"-"`            // FK to projects.id (internal)
+	ProjectUUID   string         `db:"project_uuid" json:"-"`          // Joined from projects.uuid
+	SnapshotID    sql.NullInt64  `db:"snapshot_id" json:"-"`           // FK to generation_snapshots.id (internal)
+	SnapshotUUID  sql.NullString `db:"snapshot_uuid" json:"-"`         // Joined from generation_snapshots.uuid
 	Environment   string         `db:"environment" json:"environment"` // dev, staging, production
 	Status        string         `db:"status" json:"status"`           // pending, deploying, success, failed
 	Version       string         `db:"version" json:"version"`
-	DeployedBy    sql.NullString `db:"deployed_by" json:"deployed_by,omitempty"`
-	DeploymentURL sql.NullString `db:"deployment_url" json:"deployment_url,omitempty"`
-	ErrorMessage  sql.NullString `db:"error_message" json:"error_message,omitempty"`
+	DeployedBy    sql.NullString `db:"deployed_by" json:"-"`
+	DeploymentURL sql.NullString `db:"deployment_url" json:"-"`
+	ErrorMessage  sql.NullString `db:"error_message" json:"-"`
 	StartedAt     time.Time      `db:"started_at" json:"started_at"`
-	CompletedAt   sql.NullTime   `db:"completed_at" json:"completed_at,omitempty"`
-	CreatedAt     time.Time      `db:"created_at" json:"created_at"`
+	CompletedAt   sql.NullTime   `db:"completed_at" json:"-"`
+}
+
+// MarshalJSON custom JSON marshaling for Deployment
+func (d Deployment) MarshalJSON() ([]byte, error) {
+	var completedAt *time.Time
+	if d.CompletedAt.Valid {
+		completedAt = &d.CompletedAt.Time
+	}
+
+	return json.Marshal(&struct {
+		BaseEntityJSON
+		ProjectID     string     `json:"project_id"`
+		SnapshotID    string     `json:"snapshot_id,omitempty"`
+		Environment   string     `json:"environment"`
+		Status        string     `json:"status"`
+		Version       string     `json:"version"`
+		DeployedBy    string     `json:"deployed_by,omitempty"`
+		DeploymentURL string     `json:"deployment_url,omitempty"`
+		ErrorMessage  string     `json:"error_message,omitempty"`
+		StartedAt     time.Time  `json:"started_at"`
+		CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	}{
+		BaseEntityJSON: d.BaseEntity.ToJSON(),
+		ProjectID:      d.ProjectUUID,
+		SnapshotID:     d.SnapshotUUID.String,
+		Environment:    d.Environment,
+		Status:         d.Status,
+		Version:        d.Version,
+		DeployedBy:     d.DeployedBy.String,
+		DeploymentURL:  d.DeploymentURL.String,
+		ErrorMessage:   d.ErrorMessage.String,
+		StartedAt:      d.StartedAt,
+		CompletedAt:    completedAt,
+	})
+}
+
+// IsFinished checks if the deployment reached a terminal status
+func (d *Deployment) IsFinished() bool {
+	return d.Status == DeploymentStatusSuccess || d.Status == DeploymentStatusFailed
 }
 
 // DeploymentWithLogs includes deployment logs
@@ -30,7 +71,7 @@ type DeploymentWithLogs struct {
 // DeploymentLog represents deployment log entries
 type DeploymentLog struct {
 	ID           int64     `db:"id" json:"id"`
-	DeploymentID int64     `db:"deployment_id" json:"deployment_id"`
+	DeploymentID int64     `db:"deployment_id" json:"-"`
 	Level        string    `db:"level" json:"level"` // info, warning, error
 	Message      string    `db:"message" json:"message"`
 	CreatedAt    time.Time `db:"created_at" json:"created_at"`
@@ -38,11 +79,25 @@ type DeploymentLog struct {
 
 // CreateDeploymentRequest for creating deployment
 type CreateDeploymentRequest struct {
-	ProjectID   int64  `json:"project_id" binding:"required"`
-	SnapshotID  int64  `json:"snapshot_id"`
-	Environment string `json:"environment" binding:"required,oneof=dev staging production"`
-	Version     string `json:"version" binding:"required"`
-	DeployedBy  string `json:"deployed_by"`
+	ProjectUUID  string `json:"project_id"` // Will be set from URL param, not from request body
+	SnapshotUUID string `json:"snapshot_id" binding:"required"`
+	Environment  string `json:"environment" binding:"required,oneof=dev staging production"`
+	Version      string `json:"version" binding:"max=50"` // Defaults to the snapshot version
+}
+
+// UpdateDeploymentStatusRequest for reporting deployment progress
+type UpdateDeploymentStatusRequest struct {
+	Status        string `json:"status" binding:"required,oneof=deploying success failed"`
+	DeploymentURL string `json:"deployment_url" binding:"max=500"`
+	ErrorMessage  string `json:"error_message"`
+}
+
+// DeploymentFilter for listing deployments
+type DeploymentFilter struct {
+	ProjectID    int64
+	ProjectUUIDs []string // Restricts the listing to these projects when not nil
+	Environment  string
+	Status       string
 }
 
 // Deployment status constants
@@ -59,3 +114,13 @@ const (
 	DeploymentEnvStaging    = "staging"
 	DeploymentEnvProduction = "production"
 )
+
+// DeploymentEnvironments lists every environment a project can be deployed to
+var DeploymentEnvironments = []string{DeploymentEnvDev, DeploymentEnvStaging, DeploymentEnvProduction}
+
+// Deployment log level constants
+const (
+	DeploymentLogInfo    = "info"
+	DeploymentLogWarning = "warning"
+	DeploymentLogError   = "error"
+)
@@ -16,7 +16,9 @@ type Endpoint struct {
 	Description    sql.NullString  `db:"description" json:"-"`
 	RequestSchema  json.RawMessage `db:"request_schema" json:"request_schema,omitempty"`
 	ResponseSchema json.RawMessage `db:"response_schema" json:"response_schema,omitempty"`
+	Mock           json.RawMessage `db:"mock" json:"mock,omitempty"` // EndpointMock, null when the mock only synthesizes responses
 	RequireAuth    bool            `db:"require_auth" json:"require_auth"`
+	Version        int64           `db:"version" json:"version"` // Incremented by every update, the ETag of the resource
 }
 
 // MarshalJSON custom JSON marshaling for Endpoint
@@ -29,7 +31,9 @@ func (e Endpoint) MarshalJSON() ([]byte, error) {
 		Description    string          `json:"description,omitempty"`
 		RequestSchema  json.RawMessage `json:"request_schema,omitempty"`
 		ResponseSchema json.RawMessage `json:"response_schema,omitempty"`
+		Mock           json.RawMessage `json:"mock,omitempty"`
 		RequireAuth    bool            `json:"require_auth"`
+		Version        int64           `json:"version"`
 	}{
 		BaseEntityJSON: e.BaseEntity.ToJSON(),
 		Name:           e.Name,
@@ -38,10 +42,45 @@ func (e Endpoint) MarshalJSON() ([]byte, error) {
 		Description:    e.Description.String,
 		RequestSchema:  e.RequestSchema,
 		ResponseSchema: e.ResponseSchema,
+		Mock:           e.Mock,
 		RequireAuth:    e.RequireAuth,
+		Version:        e.Version,
 	})
 }
 
+// UnmarshalJSON restores an endpoint from its JSON form, e.g. from snapshot metadata
+func (e *Endpoint) UnmarshalJSON(data []byte) error {
+	var v struct {
+		BaseEntityJSON
+		Name           string          `json:"name"`
+		Path           string          `json:"path"`
+		Method         string          `json:"method"`
+		Description    string          `json:"description"`
+		RequestSchema  json.RawMessage `json:"request_schema"`
+		ResponseSchema json.RawMessage `json:"response_schema"`
+		Mock           json.RawMessage `json:"mock"`
+		RequireAuth    bool            `json:"require_auth"`
+		Version        int64           `json:"version"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*e = Endpoint{
+		BaseEntity:     v.BaseEntityJSON.ToBaseEntity(),
+		Name:           v.Name,
+		Path:           v.Path,
+		Method:         v.Method,
+		Description:    sql.NullString{String: v.Description, Valid: v.Description != ""},
+		RequestSchema:  v.RequestSchema,
+		ResponseSchema: v.ResponseSchema,
+		Mock:           v.Mock,
+		RequireAuth:    v.RequireAuth,
+		Version:        v.Version,
+	}
+	return nil
+}
+
 // EndpointWithMetrics includes metrics data
 type EndpointWithMetrics struct {
 	Endpoint
@@ -59,7 +98,13 @@ type CreateEndpointRequest struct {
 	Description    string          `json:"description" binding:"max=500"`
 	RequestSchema  json.RawMessage `json:"request_schema"`
 	ResponseSchema json.RawMessage `json:"response_schema"`
-	RequireAuth    bool            `json:"require_auth"`
+	Mock           json.RawMessage `json:"mock"`
+	RequireAuth    *bool           `json:"require_auth"` // Defaults to true
+}
+
+// BulkCreateEndpointsRequest for creating several endpoints of a project's entities at once, all or none
+type BulkCreateEndpointsRequest struct {
+	Endpoints []CreateEndpointRequest `json:"endpoints" binding:"required,min=1,max=100,dive"`
 }
 
 // UpdateEndpointRequest for updating endpoint
@@ -70,21 +115,73 @@ type UpdateEndpointRequest struct {
 	Description    string          `json:"description" binding:"max=500"`
 	RequestSchema  json.RawMessage `json:"request_schema"`
 	ResponseSchema json.RawMessage `json:"response_schema"`
+	Mock           json.RawMessage `json:"mock"`
 	RequireAuth    *bool           `json:"require_auth"`
 }
 
-// TestEndpointRequest for testing an endpoint
+// EndpointMock configures how the mock server answers an endpoint. Without an example the response
+// is synthesized from the response schema.
+type EndpointMock struct {
+	Status    int             `json:"status,omitempty"`  // Status of the example response, by default 201 for POST and 200 otherwise
+	Example   json.RawMessage `json:"example,omitempty"` // Fixed response body
+	Scenarios []MockScenario  `json:"scenarios,omitempty"`
+}
+
+// MockScenario is a response the mock server returns instead when a request selects it by name,
+// typically an error
+type MockScenario struct {
+	Name   string          `json:"name"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// Scenario returns the scenario with a name, nil when there is none
+func (m *EndpointMock) Scenario(name string) *MockScenario {
+	for i := range m.Scenarios {
+		if m.Scenarios[i].Name == name {
+			return &m.Scenarios[i]
+		}
+	}
+	return nil
+}
+
+// MockRequest is a request to the mock of a project, with the path below the project
+type MockRequest struct {
+	Method        string
+	Path          string
+	Scenario      string // Name of the scenario of the endpoint to answer with, empty for the regular response
+	Authorization string
+	Body          []byte
+}
+
+// MockResponse is the answer of the mock server
+type MockResponse struct {
+	Status       int
+	Headers      map[string]string
+	Body         json.RawMessage // nil for no body
+	EndpointUUID string          // Endpoint answering the request, empty when none matched
+}
+
+// TestEndpointRequest for testing an endpoint against a running service, reached at BaseURL or at
+// the service of an environment
 type TestEndpointRequest struct {
-	Headers map[string]string `json:"headers"`
-	Body    json.RawMessage   `json:"body"`
-	Params  map[string]string `json:"params"`
+	BaseURL     string            `json:"base_url" binding:"omitempty,url,max=500"`
+	Environment string            `json:"environment" binding:"omitempty,oneof=dev staging production"`
+	Headers     map[string]string `json:"headers"`
+	Body        json.RawMessage   `json:"body"`
+	Params      map[string]string `json:"params"` // Values of the path parameters by name
+	Query       map[string]string `json:"query"`
 }
 
 // TestEndpointResponse contains test result
 type TestEndpointResponse struct {
+	RunID        string            `json:"run_id,omitempty"`
+	URL          string            `json:"url"`
 	StatusCode   int               `json:"status_code"`
 	ResponseTime int64             `json:"response_time"` // in milliseconds
 	Headers      map[string]string `json:"headers"`
 	Body         json.RawMessage   `json:"body"`
 	Error        string            `json:"error,omitempty"`
+	SchemaValid  *bool             `json:"schema_valid,omitempty"` // Unset when the response was not checked
+	SchemaErrors []string          `json:"schema_errors,omitempty"`
 }
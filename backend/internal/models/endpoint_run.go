@@ -0,0 +1,50 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// EndpointRun records one test of an endpoint against a running service. Runs are never updated.
+type EndpointRun struct {
+	ID           int64           `db:"id" json:"-"`
+	UUID         string          `db:"uuid" json:"id"`
+	EndpointID   int64           `db:"endpoint_id" json:"-"`   // FK to endpoints.id (internal)
+	EndpointUUID string          `db:"endpoint_uuid" json:"-"` // Joined from endpoints.uuid
+	Environment  sql.NullString  `db:"environment" json:"-"`   // Empty when run against a base URL
+	Method       string          `db:"method" json:"method"`
+	URL          string          `db:"url" json:"url"`
+	StatusCode   int             `db:"status_code" json:"status_code"` // 0 when no response was received
+	Request      json.RawMessage `db:"request" json:"request"`         // TestEndpointRequest with credentials redacted
+	Response     json.RawMessage `db:"response" json:"response"`       // TestEndpointResponse
+	CreatedBy    sql.NullString  `db:"created_by" json:"-"`
+	CreatedAt    time.Time       `db:"created_at" json:"created_at"`
+}
+
+// MarshalJSON custom JSON marshaling for EndpointRun
+func (r EndpointRun) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		UUID        string          `json:"id"`
+		EndpointID  string          `json:"endpoint_id"`
+		Environment string          `json:"environment,omitempty"`
+		Method      string          `json:"method"`
+		URL         string          `json:"url"`
+		StatusCode  int             `json:"status_code"`
+		Request     json.RawMessage `json:"request"`
+		Response    json.RawMessage `json:"response"`
+		CreatedBy   string          `json:"created_by,omitempty"`
+		CreatedAt   time.Time       `json:"created_at"`
+	}{
+		UUID:        r.UUID,
+		EndpointID:  r.EndpointUUID,
+		Environment: r.Environment.String,
+		Method:      r.Method,
+		URL:         r.URL,
+		StatusCode:  r.StatusCode,
+		Request:     r.Request,
+		Response:    r.Response,
+		CreatedBy:   r.CreatedBy.String,
+		CreatedAt:   r.CreatedAt,
+	})
+}
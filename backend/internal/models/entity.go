@@ -12,7 +12,8 @@ type Entity struct {
 	Name        string          `db:"name" json:"name"`
 	TableName   string          `db:"table_name" json:"table_name"`
 	Description sql.NullString  `db:"description" json:"-"`
-	Fields      json.RawMessage `db:"fields" json:"fields"` // JSON array of fields
+	Fields      json.RawMessage `db:"fields" json:"fields"`   // JSON array of fields
+	Version     int64           `db:"version" json:"version"` // Incremented by every update, the ETag of the resource
 }
 
 // MarshalJSON custom JSON marshaling for Entity
@@ -23,15 +24,42 @@ func (e Entity) MarshalJSON() ([]byte, error) {
 		TableName   string          `json:"table_name"`
 		Description string          `json:"description,omitempty"`
 		Fields      json.RawMessage `json:"fields"`
+		Version     int64           `json:"version"`
 	}{
 		BaseEntityJSON: e.BaseEntity.ToJSON(),
 		Name:           e.Name,
 		TableName:      e.TableName,
 		Description:    e.Description.String,
 		Fields:         e.Fields,
+		Version:        e.Version,
 	})
 }
 
+// UnmarshalJSON restores an entity from its JSON form, e.g. from snapshot metadata
+func (e *Entity) UnmarshalJSON(data []byte) error {
+	var v struct {
+		BaseEntityJSON
+		Name        string          `json:"name"`
+		TableName   string          `json:"table_name"`
+		Description string          `json:"description"`
+		Fields      json.RawMessage `json:"fields"`
+		Version     int64           `json:"version"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*e = Entity{
+		BaseEntity:  v.BaseEntityJSON.ToBaseEntity(),
+		Name:        v.Name,
+		TableName:   v.TableName,
+		Description: sql.NullString{String: v.Description, Valid: v.Description != ""},
+		Fields:      v.Fields,
+		Version:     v.Version,
+	}
+	return nil
+}
+
 // EntityField represents a field in an entity
 type EntityField struct {
 	Name         string `json:"name"`
@@ -58,6 +86,11 @@ type CreateEntityRequest struct {
 	Fields      []EntityField `json:"fields" binding:"required,min=1"`
 }
 
+// BulkCreateEntitiesRequest for creating several entities of a project at once, all or none
+type BulkCreateEntitiesRequest struct {
+	Entities []CreateEntityRequest `json:"entities" binding:"required,min=1,max=100,dive"`
+}
+
 // UpdateEntityRequest for updating entity
 type UpdateEntityRequest struct {
 	Name        string        `json:"name" binding:"omitempty,min=2,max=100"`
@@ -3,28 +3,57 @@ package models
 import (
 	"database/sql"
 	"encoding/json"
-	"time"
 )
 
 // GenerationSnapshot represents a snapshot of generated code
 type GenerationSnapshot struct {
-	ID               int64           `db:"id" json:"id"`
-	ProjectID        int64           `db:"project_id" json:"project_id"`
+	BaseEntity
+	ProjectID        int64           `db:"project_id" json:"-"` // FK to projects.id (internal)
 	Version          string          `db:"version" json:"version"`
 	GitCommitHash    string          `db:"git_commit_hash" json:"git_commit_hash"`
-	GitTag           sql.NullString  `db:"git_tag" json:"git_tag,omitempty"`
+	GitTag           sql.NullString  `db:"git_tag" json:"-"`
 	Metadata         json.RawMessage `db:"metadata" json:"metadata"`                   // Stores entities, endpoints config
 	DatabaseSnapshot json.RawMessage `db:"database_snapshot" json:"database_snapshot"` // Migration info
 	Status           string          `db:"status" json:"status"`                       // created, active, rolled_back
-	CreatedBy        sql.NullString  `db:"created_by" json:"created_by,omitempty"`
-	CreatedAt        time.Time       `db:"created_at" json:"created_at"`
+}
+
+// MarshalJSON custom JSON marshaling for GenerationSnapshot
+func (s GenerationSnapshot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		BaseEntityJSON
+		Version          string          `json:"version"`
+		GitCommitHash    string          `json:"git_commit_hash"`
+		GitTag           string          `json:"git_tag,omitempty"`
+		Metadata         json.RawMessage `json:"metadata"`
+		DatabaseSnapshot json.RawMessage `json:"database_snapshot"`
+		Status           string          `json:"status"`
+	}{
+		BaseEntityJSON:   s.BaseEntity.ToJSON(),
+		Version:          s.Version,
+		GitCommitHash:    s.GitCommitHash,
+		GitTag:           s.GitTag.String,
+		Metadata:         s.Metadata,
+		DatabaseSnapshot: s.DatabaseSnapshot,
+		Status:           s.Status,
+	})
 }
 
 // SnapshotMetadata contains snapshot metadata
 type SnapshotMetadata struct {
-	Entities  []Entity               `json:"entities"`
-	Endpoints []Endpoint             `json:"endpoints"`
-	Config    map[string]interface{} `json:"config"`
+	Entities         []Entity               `json:"entities"`
+	Endpoints        []Endpoint             `json:"endpoints"`
+	EndpointEntities map[string]string      `json:"endpoint_entities,omitempty"` // Entity UUID of every endpoint keyed by endpoint UUID
+	Config           map[string]interface{} `json:"config"`
+	Manifests        map[string]string      `json:"manifests,omitempty"` // Kubernetes manifests and Helm chart keyed by path
+}
+
+// GetMetadata parses the snapshot metadata
+func (s *GenerationSnapshot) GetMetadata() (*SnapshotMetadata, error) {
+	var metadata SnapshotMetadata
+	if err := json.Unmarshal(s.Metadata, &metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
 }
 
 // DatabaseSnapshotInfo contains database migration info
@@ -35,13 +64,9 @@ type DatabaseSnapshotInfo struct {
 
 // CreateSnapshotRequest for creating snapshot
 type CreateSnapshotRequest struct {
-	ProjectID        int64                `json:"project_id" binding:"required"`
-	Version          string               `json:"version" binding:"required"`
-	GitCommitHash    string               `json:"git_commit_hash" binding:"required"`
-	GitTag           string               `json:"git_tag"`
-	Metadata         SnapshotMetadata     `json:"metadata" binding:"required"`
-	DatabaseSnapshot DatabaseSnapshotInfo `json:"database_snapshot"`
-	CreatedBy        string               `json:"created_by"`
+	ProjectUUID string `json:"project_id"` // Will be set from URL param, not from request body
+	Version     string `json:"version" binding:"required,min=1,max=50"`
+	GitTag      string `json:"git_tag" binding:"max=100"`
 }
 
 // Snapshot status constants
@@ -8,10 +8,12 @@ import (
 // GitRepository represents a Git repository
 type GitRepository struct {
 	BaseEntity
-	ProjectID        int64          `db:"project_id" json:"-"` // FK to projects.id (internal)
+	ProjectID        int64          `db:"project_id" json:"-"`      // FK to projects.id (internal)
+	Provider         string         `db:"provider" json:"provider"` // gitlab, github, gitea
 	RepoURL          string         `db:"repo_url" json:"repo_url"`
 	RepoName         string         `db:"repo_name" json:"repo_name"`
-	GitLabRepoID     int64          `db:"gitlab_repo_id" json:"gitlab_repo_id"`
+	RepoPath         string         `db:"repo_path" json:"repo_path"`           // owner/name on the provider
+	GitLabRepoID     int64          `db:"gitlab_repo_id" json:"gitlab_repo_id"` // Remote repository ID on the provider
 	DefaultBranch    string         `db:"default_branch" json:"default_branch"`
 	DevelopBranch    string         `db:"develop_branch" json:"develop_branch"`
 	StagingBranch    string         `db:"staging_branch" json:"staging_branch"`
@@ -23,8 +25,10 @@ type GitRepository struct {
 func (g GitRepository) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
 		BaseEntityJSON
+		Provider         string `json:"provider"`
 		RepoURL          string `json:"repo_url"`
 		RepoName         string `json:"repo_name"`
+		RepoPath         string `json:"repo_path"`
 		GitLabRepoID     int64  `json:"gitlab_repo_id"`
 		DefaultBranch    string `json:"default_branch"`
 		DevelopBranch    string `json:"develop_branch"`
@@ -33,8 +37,10 @@ func (g GitRepository) MarshalJSON() ([]byte, error) {
 		LastCommitHash   string `json:"last_commit_hash,omitempty"`
 	}{
 		BaseEntityJSON:   g.BaseEntity.ToJSON(),
+		Provider:         g.Provider,
 		RepoURL:          g.RepoURL,
 		RepoName:         g.RepoName,
+		RepoPath:         g.RepoPath,
 		GitLabRepoID:     g.GitLabRepoID,
 		DefaultBranch:    g.DefaultBranch,
 		DevelopBranch:    g.DevelopBranch,
@@ -47,6 +53,7 @@ func (g GitRepository) MarshalJSON() ([]byte, error) {
 // CreateGitRepositoryRequest for creating Git repo
 type CreateGitRepositoryRequest struct {
 	ProjectUUID string `json:"project_id" binding:"required"` // Accepts project UUID from frontend
+	Provider    string `json:"provider" binding:"omitempty,oneof=gitlab github gitea"`
 	RepoName    string `json:"repo_name" binding:"required,min=3,max=100"`
 	Description string `json:"description"`
 }
@@ -57,3 +64,10 @@ type GitBranches struct {
 	Staging    string
 	Production string
 }
+
+// Git provider constants
+const (
+	GitProviderGitLab = "gitlab"
+	GitProviderGitHub = "github"
+	GitProviderGitea  = "gitea"
+)
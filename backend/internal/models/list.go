@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// ListOptions holds the search, creator, date range and sort options shared by listings
+type ListOptions struct {
+	Search        string     // Matches name or description when not empty
+	CreatedBy     string     // Username of the creator when not empty
+	CreatedAfter  *time.Time // Inclusive lower bound of created_at when set
+	CreatedBefore *time.Time // Exclusive upper bound of created_at when set
+	Sort          string     // Sort field of the listing, its default order when empty
+	Descending    bool
+}
+
+// EntityFilter for listing the entities of a project
+type EntityFilter struct {
+	ListOptions
+	ProjectID int64
+}
+
+// EndpointFilter for listing the endpoints of a project or entity
+type EndpointFilter struct {
+	ListOptions
+	ProjectID   int64 // Restricts the listing to one project when not zero
+	EntityID    int64 // Restricts the listing to one entity when not zero
+	Method      string
+	RequireAuth *bool
+}
@@ -0,0 +1,20 @@
+package models
+
+// Precondition holds the resource versions an If-Match header accepts. A nil precondition
+// accepts any version.
+type Precondition struct {
+	Versions []int64
+}
+
+// Matches reports whether a resource at version satisfies the precondition
+func (p *Precondition) Matches(version int64) bool {
+	if p == nil {
+		return true
+	}
+	for _, v := range p.Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
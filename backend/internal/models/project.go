@@ -8,30 +8,84 @@ import (
 // Project represents a microservice project
 type Project struct {
 	BaseEntity
-	Name        string         `db:"name" json:"name"`
-	Description sql.NullString `db:"description" json:"-"`
-	Status      string         `db:"status" json:"status"`       // active, generating, failed, archived
-	GitRepoID   sql.NullInt64  `db:"git_repo_id" json:"-"`       // Foreign key to git_repositories.id
-	Namespace   string         `db:"namespace" json:"namespace"` // k8s namespace
+	Name         string          `db:"name" json:"name"`
+	Description  sql.NullString  `db:"description" json:"-"`
+	Status       string          `db:"status" json:"status"`           // active, generating, failed, archived
+	GitRepoID    sql.NullInt64   `db:"git_repo_id" json:"-"`           // Foreign key to git_repositories.id
+	TeamID       sql.NullInt64   `db:"team_id" json:"-"`               // FK to teams.id (internal), null for projects without a team
+	TeamUUID     sql.NullString  `db:"team_uuid" json:"-"`             // Joined from teams.uuid
+	Namespace    string          `db:"namespace" json:"namespace"`     // k8s namespace, unique within the team
+	Image        string          `db:"image" json:"image"`             // Container image without tag
+	Port         int             `db:"port" json:"port"`               // Container port
+	ModulePath   string          `db:"module_path" json:"module_path"` // Go module path of the generated service
+	Dialect      string          `db:"dialect" json:"dialect"`         // SQL dialect of generated migrations and queries
+	Environments json.RawMessage `db:"environments" json:"-"`          // Per-environment settings
+	Version      int64           `db:"version" json:"version"`         // Incremented by every update, the ETag of the resource
 }
 
 // MarshalJSON custom JSON marshaling for Project
 func (p Project) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
 		BaseEntityJSON
-		Name        string `json:"name"`
-		Description string `json:"description,omitempty"`
-		Status      string `json:"status"`
-		Namespace   string `json:"namespace"`
+		Name         string                         `json:"name"`
+		Description  string                         `json:"description,omitempty"`
+		Status       string                         `json:"status"`
+		TeamID       string                         `json:"team_id,omitempty"`
+		Namespace    string                         `json:"namespace"`
+		Image        string                         `json:"image,omitempty"`
+		Port         int                            `json:"port"`
+		ModulePath   string                         `json:"module_path,omitempty"`
+		Dialect      string                         `json:"dialect,omitempty"`
+		Environments map[string]EnvironmentSettings `json:"environments,omitempty"`
+		Version      int64                          `json:"version"`
 	}{
 		BaseEntityJSON: p.BaseEntity.ToJSON(),
 		Name:           p.Name,
 		Description:    p.Description.String,
 		Status:         p.Status,
+		TeamID:         p.TeamUUID.String,
 		Namespace:      p.Namespace,
+		Image:          p.Image,
+		Port:           p.Port,
+		ModulePath:     p.ModulePath,
+		Dialect:        p.Dialect,
+		Environments:   p.GetEnvironments(),
+		Version:        p.Version,
 	})
 }
 
+// GetEnvironments parses per-environment settings, ignoring malformed data
+func (p *Project) GetEnvironments() map[string]EnvironmentSettings {
+	var environments map[string]EnvironmentSettings
+	if len(p.Environments) > 0 {
+		json.Unmarshal(p.Environments, &environments)
+	}
+	return environments
+}
+
+// EnvironmentNamespace returns the Kubernetes namespace an environment deploys to:
+// <namespace>-<environment>, unless the environment settings name a namespace
+func (p *Project) EnvironmentNamespace(environment string) string {
+	if namespace := p.GetEnvironments()[environment].Namespace; namespace != "" {
+		return namespace
+	}
+	return p.Namespace + "-" + environment
+}
+
+// EnvironmentSettings holds deployment settings of a project for one environment
+type EnvironmentSettings struct {
+	Namespace     string            `json:"namespace,omitempty"` // Overrides the project namespace
+	Replicas      int               `json:"replicas,omitempty" binding:"omitempty,min=0,max=100"`
+	MaxReplicas   int               `json:"max_replicas,omitempty" binding:"omitempty,min=0,max=100"` // Enables autoscaling when above Replicas
+	TargetCPU     int               `json:"target_cpu,omitempty" binding:"omitempty,min=1,max=100"`   // Autoscaling CPU utilization percentage
+	Host          string            `json:"host,omitempty"`                                           // Ingress host, ingress is disabled when empty
+	CPURequest    string            `json:"cpu_request,omitempty"`
+	MemoryRequest string            `json:"memory_request,omitempty"`
+	CPULimit      string            `json:"cpu_limit,omitempty"`
+	MemoryLimit   string            `json:"memory_limit,omitempty"`
+	Config        map[string]string `json:"config,omitempty"` // Non-secret environment variables
+}
+
 // ProjectWithRelations includes related data
 type ProjectWithRelations struct {
 	Project
@@ -42,16 +96,46 @@ type ProjectWithRelations struct {
 
 // CreateProjectRequest for creating a new project
 type CreateProjectRequest struct {
-	Name        string `json:"name" binding:"required,min=3,max=100"`
-	Description string `json:"description" binding:"max=500"`
-	Namespace   string `json:"namespace" binding:"required,min=3,max=50"`
+	TeamUUID     string                         `json:"team_id"` // Optional owning team; its defaults fill module_path and dialect
+	Name         string                         `json:"name" binding:"required,min=3,max=100"`
+	Description  string                         `json:"description" binding:"max=500"`
+	Namespace    string                         `json:"namespace" binding:"required,min=3,max=50"`
+	Image        string                         `json:"image" binding:"max=255"`
+	Port         int                            `json:"port" binding:"omitempty,min=1,max=65535"`
+	ModulePath   string                         `json:"module_path" binding:"max=255"`
+	Dialect      string                         `json:"dialect" binding:"omitempty,oneof=postgres mysql"`
+	Environments map[string]EnvironmentSettings `json:"environments" binding:"omitempty,dive,keys,oneof=dev staging production,endkeys"`
 }
 
 // UpdateProjectRequest for updating project
 type UpdateProjectRequest struct {
-	Name        string `json:"name" binding:"omitempty,min=3,max=100"`
-	Description string `json:"description" binding:"max=500"`
-	Status      string `json:"status" binding:"omitempty,oneof=active generating failed archived"`
+	Name         string                         `json:"name" binding:"omitempty,min=3,max=100"`
+	Description  string                         `json:"description" binding:"max=500"`
+	Status       string                         `json:"status" binding:"omitempty,oneof=active generating failed archived"`
+	Image        string                         `json:"image" binding:"max=255"`
+	Port         int                            `json:"port" binding:"omitempty,min=1,max=65535"`
+	ModulePath   string                         `json:"module_path" binding:"max=255"`
+	Dialect      string                         `json:"dialect" binding:"omitempty,oneof=postgres mysql"`
+	Environments map[string]EnvironmentSettings `json:"environments" binding:"omitempty,dive,keys,oneof=dev staging production,endkeys"`
+}
+
+// CloneProjectRequest for copying a project with its entities and endpoints into a new project
+type CloneProjectRequest struct {
+	TeamUUID     string `json:"team_id"` // Defaults to the team of the source project
+	Name         string `json:"name" binding:"required,min=3,max=100"`
+	Description  string `json:"description" binding:"max=500"`
+	Namespace    string `json:"namespace" binding:"required,min=3,max=50"`
+	ModulePath   string `json:"module_path" binding:"max=255"` // Defaults to the source module path ending in the new name
+	SnapshotUUID string `json:"snapshot_id"`                   // Clones the state of a snapshot instead of the current one
+}
+
+// ProjectFilter for listing projects
+type ProjectFilter struct {
+	ListOptions
+	UUIDs     []string // Restricts the listing to these projects when not nil
+	TeamID    int64    // Restricts the listing to one team when not zero
+	Status    string
+	Namespace string
 }
 
 // ProjectStatus constants
@@ -61,3 +145,12 @@ const (
 	ProjectStatusFailed     = "failed"
 	ProjectStatusArchived   = "archived"
 )
+
+// SQL dialects of generated services
+const (
+	DialectPostgres = "postgres"
+	DialectMySQL    = "mysql"
+)
+
+// DefaultProjectPort is the container port used when a project does not set one
+const DefaultProjectPort = 8080
@@ -0,0 +1,48 @@
+package models
+
+import "encoding/json"
+
+// ProjectConfigVar represents a configuration variable or secret of a project environment
+type ProjectConfigVar struct {
+	BaseEntity
+	ProjectID   int64  `db:"project_id" json:"-"` // FK to projects.id (internal)
+	Environment string `db:"environment" json:"environment"`
+	Name        string `db:"name" json:"name"`
+	Value       string `db:"value" json:"-"` // Ciphertext when IsSecret is set
+	IsSecret    bool   `db:"is_secret" json:"secret"`
+}
+
+// MarshalJSON custom JSON marshaling for ProjectConfigVar, secret values are never returned
+func (v ProjectConfigVar) MarshalJSON() ([]byte, error) {
+	value := v.Value
+	if v.IsSecret {
+		value = ""
+	}
+
+	return json.Marshal(&struct {
+		BaseEntityJSON
+		Environment string `json:"environment"`
+		Name        string `json:"name"`
+		Value       string `json:"value,omitempty"`
+		IsSecret    bool   `json:"secret"`
+	}{
+		BaseEntityJSON: v.BaseEntity.ToJSON(),
+		Environment:    v.Environment,
+		Name:           v.Name,
+		Value:          value,
+		IsSecret:       v.IsSecret,
+	})
+}
+
+// SetConfigVarRequest for creating or replacing a configuration variable
+type SetConfigVarRequest struct {
+	Value  string `json:"value" binding:"max=65535"`
+	Secret bool   `json:"secret"`
+}
+
+// EnvironmentConfig is the configuration of an environment as seen by generated output:
+// plain variables with their values and secrets by name only
+type EnvironmentConfig struct {
+	Variables  map[string]string
+	SecretKeys []string
+}
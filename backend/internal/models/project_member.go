@@ -0,0 +1,41 @@
+package models
+
+import "encoding/json"
+
+// ProjectMember grants a user a role on a project
+type ProjectMember struct {
+	BaseEntity
+	ProjectID   int64  `db:"project_id" json:"-"`   // FK to projects.id (internal)
+	ProjectUUID string `db:"project_uuid" json:"-"` // Joined from projects.uuid
+	UserID      int64  `db:"user_id" json:"-"`      // FK to users.id (internal)
+	Username    string `db:"username" json:"username"`
+	Role        string `db:"role" json:"role"` // owner, maintainer, developer, viewer
+}
+
+// MarshalJSON custom JSON marshaling for ProjectMember
+func (m ProjectMember) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		BaseEntityJSON
+		ProjectID string `json:"project_id"`
+		Username  string `json:"username"`
+		Role      string `json:"role"`
+	}{
+		BaseEntityJSON: m.BaseEntity.ToJSON(),
+		ProjectID:      m.ProjectUUID,
+		Username:       m.Username,
+		Role:           m.Role,
+	})
+}
+
+// SetProjectMemberRequest for adding a member or changing its role
+type SetProjectMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner maintainer developer viewer"`
+}
+
+// Project role constants, from most to least privileged
+const (
+	ProjectRoleOwner      = "owner"
+	ProjectRoleMaintainer = "maintainer"
+	ProjectRoleDeveloper  = "developer"
+	ProjectRoleViewer     = "viewer"
+)
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+// Team is an organization unit that owns projects
+type Team struct {
+	BaseEntity
+	Name        string          `db:"name" json:"name"`
+	Slug        string          `db:"slug" json:"slug"` // Unique URL-friendly name
+	Description sql.NullString  `db:"description" json:"-"`
+	Settings    json.RawMessage `db:"settings" json:"-"` // Defaults for new projects
+}
+
+// MarshalJSON custom JSON marshaling for Team
+func (t Team) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		BaseEntityJSON
+		Name        string       `json:"name"`
+		Slug        string       `json:"slug"`
+		Description string       `json:"description,omitempty"`
+		Settings    TeamSettings `json:"settings"`
+	}{
+		BaseEntityJSON: t.BaseEntity.ToJSON(),
+		Name:           t.Name,
+		Slug:           t.Slug,
+		Description:    t.Description.String,
+		Settings:       t.GetSettings(),
+	})
+}
+
+// GetSettings parses the team defaults, ignoring malformed data
+func (t *Team) GetSettings() TeamSettings {
+	var settings TeamSettings
+	if len(t.Settings) > 0 {
+		json.Unmarshal(t.Settings, &settings)
+	}
+	return settings
+}
+
+// TeamSettings holds the defaults applied to projects of a team
+type TeamSettings struct {
+	ModulePathPrefix string   `json:"module_path_prefix,omitempty" binding:"max=200"` // New projects get <prefix>/<project-name>
+	Dialect          string   `json:"dialect,omitempty" binding:"omitempty,oneof=postgres mysql"`
+	TemplateSet      []string `json:"template_set,omitempty"` // Template IDs overriding the built-in templates of the same type
+}
+
+// TeamMember grants a user a role in a team
+type TeamMember struct {
+	BaseEntity
+	TeamID   int64  `db:"team_id" json:"-"`   // FK to teams.id (internal)
+	TeamUUID string `db:"team_uuid" json:"-"` // Joined from teams.uuid
+	UserID   int64  `db:"user_id" json:"-"`   // FK to users.id (internal)
+	Username string `db:"username" json:"username"`
+	Role     string `db:"role" json:"role"` // owner, member
+}
+
+// MarshalJSON custom JSON marshaling for TeamMember
+func (m TeamMember) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		BaseEntityJSON
+		TeamID   string `json:"team_id"`
+		Username string `json:"username"`
+		Role     string `json:"role"`
+	}{
+		BaseEntityJSON: m.BaseEntity.ToJSON(),
+		TeamID:         m.TeamUUID,
+		Username:       m.Username,
+		Role:           m.Role,
+	})
+}
+
+// TeamStats summarizes the projects of a team
+type TeamStats struct {
+	Projects         int64            `json:"projects"`
+	ProjectsByStatus map[string]int64 `json:"projects_by_status"`
+	Entities         int64            `json:"entities"`
+	Endpoints        int64            `json:"endpoints"`
+	Deployments      int64            `json:"deployments"`
+	Members          int64            `json:"members"`
+}
+
+// CreateTeamRequest for creating a new team
+type CreateTeamRequest struct {
+	Name        string       `json:"name" binding:"required,min=2,max=100"`
+	Slug        string       `json:"slug" binding:"required,min=2,max=50,hostname_rfc1123"`
+	Description string       `json:"description" binding:"max=500"`
+	Settings    TeamSettings `json:"settings"`
+}
+
+// UpdateTeamRequest for updating a team
+type UpdateTeamRequest struct {
+	Name        string        `json:"name" binding:"omitempty,min=2,max=100"`
+	Description string        `json:"description" binding:"max=500"`
+	Settings    *TeamSettings `json:"settings"` // Replaces all defaults when set
+}
+
+// SetTeamMemberRequest for adding a member or changing its role
+type SetTeamMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner member"`
+}
+
+// Team role constants
+const (
+	TeamRoleOwner  = "owner"
+	TeamRoleMember = "member"
+)
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+// Template is a reusable code generation template, managed by the owners of its team
+type Template struct {
+	BaseEntity
+	Name        string         `db:"name" json:"name"`
+	Type        string         `db:"type" json:"type"` // model, repository, service, handler, ...
+	Content     string         `db:"content" json:"content"`
+	Description sql.NullString `db:"description" json:"-"`
+	IsDefault   bool           `db:"is_default" json:"is_default"`
+	TeamID      sql.NullInt64  `db:"team_id" json:"-"`       // FK to teams.id (internal), null for read-only templates created before teams
+	TeamUUID    sql.NullString `db:"team_uuid" json:"-"`     // Joined from teams.uuid
+	Version     int64          `db:"version" json:"version"` // Incremented by every update, the ETag of the resource
+}
+
+// MarshalJSON custom JSON marshaling for Template
+func (t Template) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		BaseEntityJSON
+		Name        string `json:"name"`
+		Type        string `json:"type"`
+		Content     string `json:"content"`
+		Description string `json:"description,omitempty"`
+		IsDefault   bool   `json:"is_default"`
+		TeamID      string `json:"team_id,omitempty"`
+		Version     int64  `json:"version"`
+	}{
+		BaseEntityJSON: t.BaseEntity.ToJSON(),
+		Name:           t.Name,
+		Type:           t.Type,
+		Content:        t.Content,
+		Description:    t.Description.String,
+		IsDefault:      t.IsDefault,
+		TeamID:         t.TeamUUID.String,
+		Version:        t.Version,
+	})
+}
+
+// CreateTemplateRequest for creating a new template
+type CreateTemplateRequest struct {
+	Name        string `json:"name" binding:"required,min=2,max=100"`
+	Type        string `json:"type" binding:"required,max=50"`
+	Content     string `json:"content" binding:"required"`
+	Description string `json:"description" binding:"max=500"`
+	IsDefault   bool   `json:"is_default"`
+	TeamUUID    string `json:"team_id" binding:"required"` // Team whose owners manage the template
+}
+
+// UpdateTemplateRequest for updating a template
+type UpdateTemplateRequest struct {
+	Name        string `json:"name" binding:"omitempty,min=2,max=100"`
+	Type        string `json:"type" binding:"max=50"`
+	Content     string `json:"content"`
+	Description string `json:"description" binding:"max=500"`
+	IsDefault   *bool  `json:"is_default"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// ProjectTrash holds the soft deleted entities and endpoints of a project
+type ProjectTrash struct {
+	Entities  []Entity   `json:"entities"`
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// PurgeResult counts the resources a purge removed permanently
+type PurgeResult struct {
+	Projects  int `json:"projects"`
+	Entities  int `json:"entities"`
+	Endpoints int `json:"endpoints"`
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// User represents a Lambra user account
+type User struct {
+	BaseEntity
+	Username     string         `db:"username" json:"username"`
+	Email        string         `db:"email" json:"email"`
+	PasswordHash string         `db:"password_hash" json:"-"`
+	FullName     sql.NullString `db:"full_name" json:"-"`
+	IsActive     bool           `db:"is_active" json:"is_active"`
+	LastLoginAt  sql.NullTime   `db:"last_login_at" json:"-"`
+}
+
+// MarshalJSON custom JSON marshaling for User, the password hash is never returned
+func (u User) MarshalJSON() ([]byte, error) {
+	var lastLoginAt *time.Time
+	if u.LastLoginAt.Valid {
+		lastLoginAt = &u.LastLoginAt.Time
+	}
+
+	return json.Marshal(&struct {
+		BaseEntityJSON
+		Username    string     `json:"username"`
+		Email       string     `json:"email"`
+		FullName    string     `json:"full_name,omitempty"`
+		IsActive    bool       `json:"is_active"`
+		LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	}{
+		BaseEntityJSON: u.BaseEntity.ToJSON(),
+		Username:       u.Username,
+		Email:          u.Email,
+		FullName:       u.FullName.String,
+		IsActive:       u.IsActive,
+		LastLoginAt:    lastLoginAt,
+	})
+}
+
+// APIToken represents a long-lived API token of a user, only its hash is stored
+type APIToken struct {
+	BaseEntity
+	UserID      int64        `db:"user_id" json:"-"` // FK to users.id (internal)
+	Name        string       `db:"name" json:"name"`
+	TokenPrefix string       `db:"token_prefix" json:"token_prefix"` // Leading characters to recognise the token
+	TokenHash   string       `db:"token_hash" json:"-"`
+	LastUsedAt  sql.NullTime `db:"last_used_at" json:"-"`
+	ExpiresAt   sql.NullTime `db:"expires_at" json:"-"`
+}
+
+// MarshalJSON custom JSON marshaling for APIToken
+func (t APIToken) MarshalJSON() ([]byte, error) {
+	var lastUsedAt, expiresAt *time.Time
+	if t.LastUsedAt.Valid {
+		lastUsedAt = &t.LastUsedAt.Time
+	}
+	if t.ExpiresAt.Valid {
+		expiresAt = &t.ExpiresAt.Time
+	}
+
+	return json.Marshal(&struct {
+		BaseEntityJSON
+		Name        string     `json:"name"`
+		TokenPrefix string     `json:"token_prefix"`
+		LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+		ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	}{
+		BaseEntityJSON: t.BaseEntity.ToJSON(),
+		Name:           t.Name,
+		TokenPrefix:    t.TokenPrefix,
+		LastUsedAt:     lastUsedAt,
+		ExpiresAt:      expiresAt,
+	})
+}
+
+// IsExpired checks if the token is past its expiry
+func (t *APIToken) IsExpired() bool {
+	return t.ExpiresAt.Valid && time.Now().After(t.ExpiresAt.Time)
+}
+
+// RegisterRequest for creating a user account
+type RegisterRequest struct {
+	Username string `json:"username" binding:"required,min=3,max=50,alphanum"`
+	Email    string `json:"email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"required,min=8,max=72"` // bcrypt ignores bytes past 72
+	FullName string `json:"full_name" binding:"max=255"`
+}
+
+// LoginRequest for password login, Username also accepts the email address
+type LoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// LoginResponse contains the issued JWT
+type LoginResponse struct {
+	Token     string    `json:"token"`
+	TokenType string    `json:"token_type"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      *User     `json:"user"`
+}
+
+// CreateAPITokenRequest for creating an API token
+type CreateAPITokenRequest struct {
+	Name          string `json:"name" binding:"required,min=1,max=100"`
+	ExpiresInDays int    `json:"expires_in_days" binding:"min=0,max=3650"` // 0 never expires
+}
+
+// CreateAPITokenResponse contains the plain token, which is only returned once
+type CreateAPITokenResponse struct {
+	Token    string    `json:"token"`
+	APIToken *APIToken `json:"api_token"`
+}
@@ -0,0 +1,46 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// ErrLastOwner is returned when the only owner of a project would be removed or demoted
+var ErrLastOwner = apperror.Conflict("a project must keep at least one owner")
+
+// Authorizer decides which projects a user can access and with which role.
+// Users are identified by username so a remote service does not need Lambra's internal IDs.
+type Authorizer interface {
+	// Role returns the role of a user on a project, or an empty string when the user has no access
+	Role(ctx context.Context, projectUUID, username string) (string, error)
+	// ProjectUUIDs lists the projects a user can see
+	ProjectUUIDs(ctx context.Context, username string) ([]string, error)
+	// Members lists the members of a project
+	Members(ctx context.Context, projectUUID string) ([]models.ProjectMember, error)
+	// Grant gives a user a role on a project, replacing any previous role
+	Grant(ctx context.Context, projectUUID, username, role string) error
+	// Revoke removes a user from a project
+	Revoke(ctx context.Context, projectUUID, username string) error
+}
+
+// roleRanks orders roles; a higher rank includes every permission of the lower ones
+var roleRanks = map[string]int{
+	models.ProjectRoleViewer:     1,
+	models.ProjectRoleDeveloper:  2,
+	models.ProjectRoleMaintainer: 3,
+	models.ProjectRoleOwner:      4,
+}
+
+// Allows reports whether a role includes the permissions of the required role
+func Allows(role, required string) bool {
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[required]
+}
+
+// IsRole reports whether a role name is known
+func IsRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
@@ -0,0 +1,124 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+// LocalAuthorizer keeps project membership in Lambra's own project_members table
+type LocalAuthorizer struct {
+	members  *repository.ProjectMemberRepository
+	projects *repository.ProjectRepository
+	users    *repository.UserRepository
+	admins   map[string]bool // Usernames that own every project, e.g. to manage projects created before roles existed
+}
+
+func NewLocalAuthorizer(
+	members *repository.ProjectMemberRepository,
+	projects *repository.ProjectRepository,
+	users *repository.UserRepository,
+	admins []string,
+) *LocalAuthorizer {
+	a := &LocalAuthorizer{
+		members:  members,
+		projects: projects,
+		users:    users,
+		admins:   make(map[string]bool),
+	}
+	for _, username := range admins {
+		a.admins[username] = true
+	}
+	return a
+}
+
+func (a *LocalAuthorizer) Role(ctx context.Context, projectUUID, username string) (string, error) {
+	if a.admins[username] {
+		return models.ProjectRoleOwner, nil
+	}
+	return a.members.GetRole(projectUUID, username)
+}
+
+func (a *LocalAuthorizer) ProjectUUIDs(ctx context.Context, username string) ([]string, error) {
+	if a.admins[username] {
+		return a.projects.GetUUIDs()
+	}
+	return a.members.GetProjectUUIDsByUsername(username)
+}
+
+func (a *LocalAuthorizer) Members(ctx context.Context, projectUUID string) ([]models.ProjectMember, error) {
+	return a.members.GetByProjectUUID(projectUUID)
+}
+
+func (a *LocalAuthorizer) Grant(ctx context.Context, projectUUID, username, role string) error {
+	project, user, err := a.resolve(projectUUID, username)
+	if err != nil {
+		return err
+	}
+
+	if role != models.ProjectRoleOwner {
+		if err := a.checkNotLastOwner(projectUUID, username); err != nil {
+			return err
+		}
+	}
+
+	member := &models.ProjectMember{
+		ProjectID: project.ID,
+		UserID:    user.ID,
+		Role:      role,
+	}
+	member.SetCreatedBy(auth.Actor(ctx))
+
+	return a.members.Upsert(member)
+}
+
+func (a *LocalAuthorizer) Revoke(ctx context.Context, projectUUID, username string) error {
+	project, user, err := a.resolve(projectUUID, username)
+	if err != nil {
+		return err
+	}
+
+	if err := a.checkNotLastOwner(projectUUID, username); err != nil {
+		return err
+	}
+
+	return a.members.Delete(project.ID, user.ID, auth.Actor(ctx))
+}
+
+func (a *LocalAuthorizer) resolve(projectUUID, username string) (*models.Project, *models.User, error) {
+	project, err := a.projects.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := a.users.GetByUsername(username)
+	if err != nil {
+		return nil, nil, fmt.Errorf("user %s: %w", username, err)
+	}
+
+	return project, user, nil
+}
+
+// checkNotLastOwner rejects removing the owner role from the only owner of a project
+func (a *LocalAuthorizer) checkNotLastOwner(projectUUID, username string) error {
+	members, err := a.members.GetByProjectUUID(projectUUID)
+	if err != nil {
+		return err
+	}
+
+	owners, isOwner := 0, false
+	for _, member := range members {
+		if member.Role == models.ProjectRoleOwner {
+			owners++
+			isOwner = isOwner || member.Username == username
+		}
+	}
+
+	if isOwner && owners == 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
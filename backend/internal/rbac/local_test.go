@@ -0,0 +1,81 @@
+package rbac
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+func newTestLocalAuthorizer(t *testing.T, admins []string) (*LocalAuthorizer, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	sqlxDB := sqlx.NewDb(db, "mysql")
+	authorizer := NewLocalAuthorizer(
+		repository.NewProjectMemberRepository(sqlxDB),
+		repository.NewProjectRepository(sqlxDB),
+		repository.NewUserRepository(sqlxDB),
+		admins,
+	)
+	return authorizer, mock
+}
+
+// Projects created before project roles existed have no members after the upgrade
+func TestLocalAuthorizer_ProjectWithoutMembers(t *testing.T) {
+	ctx := context.Background()
+	authz, mock := newTestLocalAuthorizer(t, []string{"admin"})
+
+	mock.ExpectQuery(`SELECT m.role\s+FROM project_members`).
+		WithArgs("p1", "alice").
+		WillReturnRows(sqlmock.NewRows([]string{"role"}))
+	role, err := authz.Role(ctx, "p1", "alice")
+	if err != nil {
+		t.Fatalf("Role(alice) error = %v", err)
+	}
+	if role != "" {
+		t.Errorf("Role(alice) = %q, want no role", role)
+	}
+
+	mock.ExpectQuery(`SELECT p.uuid\s+FROM project_members`).
+		WithArgs("alice").
+		WillReturnRows(sqlmock.NewRows([]string{"uuid"}))
+	uuids, err := authz.ProjectUUIDs(ctx, "alice")
+	if err != nil {
+		t.Fatalf("ProjectUUIDs(alice) error = %v", err)
+	}
+	if len(uuids) != 0 {
+		t.Errorf("ProjectUUIDs(alice) = %v, want none", uuids)
+	}
+
+	// An admin owns every project without being a member, so it can grant the first owner
+	role, err = authz.Role(ctx, "p1", "admin")
+	if err != nil {
+		t.Fatalf("Role(admin) error = %v", err)
+	}
+	if !Allows(role, models.ProjectRoleOwner) {
+		t.Errorf("Role(admin) = %q, want owner", role)
+	}
+
+	mock.ExpectQuery(`SELECT uuid FROM projects WHERE deleted_at IS NULL`).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid"}).AddRow("p1").AddRow("p2"))
+	uuids, err = authz.ProjectUUIDs(ctx, "admin")
+	if err != nil {
+		t.Fatalf("ProjectUUIDs(admin) error = %v", err)
+	}
+	if want := []string{"p1", "p2"}; !reflect.DeepEqual(uuids, want) {
+		t.Errorf("ProjectUUIDs(admin) = %v, want %v", uuids, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,177 @@
+package rbac
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/yourusername/lambra/internal/config"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+var errRemoteNotFound = errors.New("not found")
+
+// RemoteAuthorizer delegates project membership to the external RBAC service:
+//
+//	GET    /api/v1/projects/:project/members            -> {"members": [{"username", "role"}]}
+//	GET    /api/v1/projects/:project/members/:username  -> {"role"}, 404 without access
+//	PUT    /api/v1/projects/:project/members/:username  <- {"role"}
+//	DELETE /api/v1/projects/:project/members/:username
+//	GET    /api/v1/users/:username/projects             -> {"projects": [{"project_id", "role"}]}
+//
+// Projects are identified by their UUID. A 409 on PUT or DELETE means the last owner would be lost.
+type RemoteAuthorizer struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func NewRemoteAuthorizer(cfg *config.RBACConfig) *RemoteAuthorizer {
+	return &RemoteAuthorizer{
+		baseURL:    strings.TrimRight(cfg.ServiceURL, "/"),
+		token:      cfg.Token,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type remoteMember struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+func (a *RemoteAuthorizer) Role(ctx context.Context, projectUUID, username string) (string, error) {
+	var member remoteMember
+	err := a.do(ctx, http.MethodGet, memberPath(projectUUID, username), nil, &member)
+	if errors.Is(err, errRemoteNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if !IsRole(member.Role) {
+		return "", nil
+	}
+	return member.Role, nil
+}
+
+func (a *RemoteAuthorizer) ProjectUUIDs(ctx context.Context, username string) ([]string, error) {
+	var body struct {
+		Projects []struct {
+			ProjectID string `json:"project_id"`
+			Role      string `json:"role"`
+		} `json:"projects"`
+	}
+	err := a.do(ctx, http.MethodGet, "/api/v1/users/"+url.PathEscape(username)+"/projects", nil, &body)
+	if errors.Is(err, errRemoteNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var uuids []string
+	for _, project := range body.Projects {
+		if IsRole(project.Role) {
+			uuids = append(uuids, project.ProjectID)
+		}
+	}
+	return uuids, nil
+}
+
+func (a *RemoteAuthorizer) Members(ctx context.Context, projectUUID string) ([]models.ProjectMember, error) {
+	var body struct {
+		Members []remoteMember `json:"members"`
+	}
+	err := a.do(ctx, http.MethodGet, "/api/v1/projects/"+url.PathEscape(projectUUID)+"/members", nil, &body)
+	if errors.Is(err, errRemoteNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]models.ProjectMember, 0, len(body.Members))
+	for _, member := range body.Members {
+		members = append(members, models.ProjectMember{
+			ProjectUUID: projectUUID,
+			Username:    member.Username,
+			Role:        member.Role,
+		})
+	}
+	return members, nil
+}
+
+func (a *RemoteAuthorizer) Grant(ctx context.Context, projectUUID, username, role string) error {
+	return a.do(ctx, http.MethodPut, memberPath(projectUUID, username), &remoteMember{Username: username, Role: role}, nil)
+}
+
+func (a *RemoteAuthorizer) Revoke(ctx context.Context, projectUUID, username string) error {
+	err := a.do(ctx, http.MethodDelete, memberPath(projectUUID, username), nil, nil)
+	if errors.Is(err, errRemoteNotFound) {
+		return nil
+	}
+	return err
+}
+
+func memberPath(projectUUID, username string) string {
+	return "/api/v1/projects/" + url.PathEscape(projectUUID) + "/members/" + url.PathEscape(username)
+}
+
+// do sends a JSON request and decodes the JSON response into out (if not nil)
+func (a *RemoteAuthorizer) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, a.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if a.token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("rbac service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errRemoteNotFound
+	case resp.StatusCode == http.StatusConflict:
+		return ErrLastOwner
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("rbac service: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("rbac service: failed to decode response: %w", err)
+	}
+
+	return nil
+}
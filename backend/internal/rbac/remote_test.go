@@ -0,0 +1,135 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/config"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// fakeRBACService is an in-memory stand-in for the external RBAC service
+type fakeRBACService struct {
+	roles map[string]map[string]string // project -> username -> role
+}
+
+func (f *fakeRBACService) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/projects/p1/members", func(w http.ResponseWriter, r *http.Request) {
+		var members []remoteMember
+		for username, role := range f.roles["p1"] {
+			members = append(members, remoteMember{Username: username, Role: role})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"members": members})
+	})
+	mux.HandleFunc("/api/v1/projects/p1/members/", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer rbac-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+
+		username := r.URL.Path[len("/api/v1/projects/p1/members/"):]
+		switch r.Method {
+		case http.MethodGet:
+			role, ok := f.roles["p1"][username]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(remoteMember{Username: username, Role: role})
+		case http.MethodPut:
+			var member remoteMember
+			json.NewDecoder(r.Body).Decode(&member)
+			f.roles["p1"][username] = member.Role
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			if f.roles["p1"][username] == models.ProjectRoleOwner {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			delete(f.roles["p1"], username)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc("/api/v1/users/alice/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"projects":[{"project_id":"p1","role":"owner"},{"project_id":"p2","role":"viewer"},{"project_id":"p3","role":"guest"}]}`))
+	})
+	return mux
+}
+
+func TestRemoteAuthorizer(t *testing.T) {
+	fake := &fakeRBACService{roles: map[string]map[string]string{"p1": {"alice": models.ProjectRoleOwner}}}
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	authorizer := NewRemoteAuthorizer(&config.RBACConfig{ServiceURL: server.URL + "/", Token: "rbac-token"})
+	ctx := context.Background()
+
+	if role, err := authorizer.Role(ctx, "p1", "alice"); err != nil || role != models.ProjectRoleOwner {
+		t.Errorf("Role(alice) = %q, %v", role, err)
+	}
+	if role, err := authorizer.Role(ctx, "p1", "bob"); err != nil || role != "" {
+		t.Errorf("Role(bob) = %q, %v, want no access", role, err)
+	}
+
+	if err := authorizer.Grant(ctx, "p1", "bob", models.ProjectRoleDeveloper); err != nil {
+		t.Fatalf("Grant() error = %v", err)
+	}
+	if role, _ := authorizer.Role(ctx, "p1", "bob"); role != models.ProjectRoleDeveloper {
+		t.Errorf("Role(bob) after grant = %q", role)
+	}
+
+	members, err := authorizer.Members(ctx, "p1")
+	if err != nil || len(members) != 2 {
+		t.Errorf("Members() = %+v, %v", members, err)
+	}
+
+	if err := authorizer.Revoke(ctx, "p1", "bob"); err != nil {
+		t.Errorf("Revoke(bob) error = %v", err)
+	}
+	if err := authorizer.Revoke(ctx, "p1", "alice"); !errors.Is(err, ErrLastOwner) {
+		t.Errorf("Revoke(alice) error = %v, want ErrLastOwner", err)
+	}
+
+	uuids, err := authorizer.ProjectUUIDs(ctx, "alice")
+	if err != nil {
+		t.Fatalf("ProjectUUIDs() error = %v", err)
+	}
+	if !reflect.DeepEqual(uuids, []string{"p1", "p2"}) {
+		t.Errorf("ProjectUUIDs() = %v, unknown roles must be ignored", uuids)
+	}
+}
+
+func TestRemoteAuthorizer_ServiceError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	authorizer := NewRemoteAuthorizer(&config.RBACConfig{ServiceURL: server.URL})
+	if _, err := authorizer.Role(context.Background(), "p1", "alice"); err == nil {
+		t.Error("Role() should fail closed when the service errors")
+	}
+}
+
+func TestAllows(t *testing.T) {
+	tests := []struct {
+		role, required string
+		want           bool
+	}{
+		{models.ProjectRoleOwner, models.ProjectRoleMaintainer, true},
+		{models.ProjectRoleDeveloper, models.ProjectRoleDeveloper, true},
+		{models.ProjectRoleViewer, models.ProjectRoleDeveloper, false},
+		{"", models.ProjectRoleViewer, false},
+		{"admin", models.ProjectRoleViewer, false},
+	}
+	for _, tt := range tests {
+		if got := Allows(tt.role, tt.required); got != tt.want {
+			t.Errorf("Allows(%q, %q) = %v, want %v", tt.role, tt.required, got, tt.want)
+		}
+	}
+}
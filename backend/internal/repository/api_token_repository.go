@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+type APITokenRepository struct {
+	db DBTX
+}
+
+func NewAPITokenRepository(db *sqlx.DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+const apiTokenColumns = `id, uuid, user_id, name, token_prefix, token_hash, last_used_at, expires_at,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at`
+
+func (r *APITokenRepository) Create(token *models.APIToken) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	id := uuidToInt64(uuidV7)
+
+	query := `
+		INSERT INTO api_tokens (id, uuid, user_id, name, token_prefix, token_hash, expires_at, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+	`
+	_, err := r.db.Exec(query, id, uuidV7.String(), token.UserID, token.Name, token.TokenPrefix, token.TokenHash,
+		token.ExpiresAt, token.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	var created models.APIToken
+	err = r.db.Get(&created, `SELECT `+apiTokenColumns+` FROM api_tokens WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve created API token: %w", err)
+	}
+
+	*token = created
+	return nil
+}
+
+// GetByHash retrieves a token by the hash of its value
+func (r *APITokenRepository) GetByHash(hash string) (*models.APIToken, error) {
+	var token models.APIToken
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE token_hash = ? AND deleted_at IS NULL`
+
+	err := r.db.Get(&token, query, hash)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("API token")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// GetByUserID lists the tokens of a user
+func (r *APITokenRepository) GetByUserID(userID int64) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	query := `
+		SELECT ` + apiTokenColumns + `
+		FROM api_tokens
+		WHERE user_id = ? AND deleted_at IS NULL
+		ORDER BY created_at DESC
+	`
+
+	err := r.db.Select(&tokens, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// TouchLastUsed records the use of a token
+func (r *APITokenRepository) TouchLastUsed(id int64) error {
+	_, err := r.db.Exec(`UPDATE api_tokens SET last_used_at = NOW() WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to update API token: %w", err)
+	}
+	return nil
+}
+
+// DeleteByUUID revokes a token of a user (soft delete), reporting whether it existed
+func (r *APITokenRepository) DeleteByUUID(userID int64, uuid string, deletedBy string) (bool, error) {
+	query := `
+		UPDATE api_tokens SET deleted_by = ?, deleted_at = NOW()
+		WHERE uuid = ? AND user_id = ? AND deleted_at IS NULL
+	`
+	result, err := r.db.Exec(query, deletedBy, uuid, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete API token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete API token: %w", err)
+	}
+	return rows > 0, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// AuditRepository stores the audit trail; it only appends and reads
+type AuditRepository struct {
+	db DBTX
+}
+
+func NewAuditRepository(db *sqlx.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Create(entry *models.AuditLog) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	entry.ID = uuidToInt64(uuidV7)
+	entry.UUID = uuidV7.String()
+
+	query := `
+		INSERT INTO audit_logs (id, uuid, project_uuid, resource_type, resource_id, action, actor, request_id, changes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(6))
+	`
+	_, err := r.db.Exec(query, entry.ID, entry.UUID, entry.ProjectUUID, entry.ResourceType, entry.ResourceID,
+		entry.Action, entry.Actor, entry.RequestID, entry.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	return nil
+}
+
+// GetAll lists audit logs matching a filter, newest first
+func (r *AuditRepository) GetAll(filter *models.AuditLogFilter, limit, offset int) ([]models.AuditLog, int64, error) {
+	conditions := []string{"1 = 1"}
+	args := []interface{}{}
+
+	if filter.ProjectUUID != "" {
+		conditions = append(conditions, "project_uuid = ?")
+		args = append(args, filter.ProjectUUID)
+	}
+	if filter.ResourceType != "" {
+		conditions = append(conditions, "resource_type = ?")
+		args = append(args, filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		conditions = append(conditions, "resource_id = ?")
+		args = append(args, filter.ResourceID)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor = ?")
+		args = append(args, filter.Actor)
+	}
+	if filter.Since != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.Since)
+	}
+	if filter.Until != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *filter.Until)
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var logs []models.AuditLog
+	query := `
+		SELECT id, uuid, project_uuid, resource_type, resource_id, action, actor, request_id, changes, created_at
+		FROM audit_logs` + where + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.Select(&logs, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+
+	var total int64
+	err = r.db.Get(&total, `SELECT COUNT(*) FROM audit_logs`+where, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	return logs, total, nil
+}
+
+// GetProjectUUIDByResource returns the project of the most recent entry of a resource
+func (r *AuditRepository) GetProjectUUIDByResource(resourceType, resourceID string) (string, bool, error) {
+	var projectUUIDs []*string
+	query := `
+		SELECT project_uuid FROM audit_logs
+		WHERE resource_type = ? AND resource_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+
+	err := r.db.Select(&projectUUIDs, query, resourceType, resourceID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get audit log project: %w", err)
+	}
+	if len(projectUUIDs) == 0 {
+		return "", false, nil
+	}
+	if projectUUIDs[0] == nil {
+		return "", true, nil
+	}
+
+	return *projectUUIDs[0], true, nil
+}
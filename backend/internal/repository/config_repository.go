@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+type ConfigRepository struct {
+	db DBTX
+}
+
+func NewConfigRepository(db *sqlx.DB) *ConfigRepository {
+	return &ConfigRepository{db: db}
+}
+
+// Upsert creates a variable or replaces the value of an existing (or deleted) one
+func (r *ConfigRepository) Upsert(variable *models.ProjectConfigVar) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	id := uuidToInt64(uuidV7)
+
+	query := `
+		INSERT INTO project_config_vars (id, uuid, project_id, environment, name, value, is_secret, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+		ON DUPLICATE KEY UPDATE value = VALUES(value), is_secret = VALUES(is_secret), updated_by = VALUES(created_by),
+		                        updated_at = NOW(), deleted_by = NULL, deleted_at = NULL
+	`
+	_, err := r.db.Exec(query, id, uuidV7.String(), variable.ProjectID, variable.Environment, variable.Name,
+		variable.Value, variable.IsSecret, variable.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save config variable: %w", err)
+	}
+
+	saved, err := r.GetByName(variable.ProjectID, variable.Environment, variable.Name)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve saved config variable: %w", err)
+	}
+
+	*variable = *saved
+	return nil
+}
+
+// GetByName retrieves a variable of a project environment by name
+func (r *ConfigRepository) GetByName(projectID int64, environment, name string) (*models.ProjectConfigVar, error) {
+	var variable models.ProjectConfigVar
+	query := `
+		SELECT id, uuid, project_id, environment, name, value, is_secret,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM project_config_vars
+		WHERE project_id = ? AND environment = ? AND name = ? AND deleted_at IS NULL
+	`
+
+	err := r.db.Get(&variable, query, projectID, environment, name)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("config variable")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config variable: %w", err)
+	}
+
+	return &variable, nil
+}
+
+// GetByProjectID retrieves the variables of a project, optionally narrowed to one environment
+func (r *ConfigRepository) GetByProjectID(projectID int64, environment string) ([]models.ProjectConfigVar, error) {
+	var variables []models.ProjectConfigVar
+	query := `
+		SELECT id, uuid, project_id, environment, name, value, is_secret,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM project_config_vars
+		WHERE project_id = ? AND (? = '' OR environment = ?) AND deleted_at IS NULL
+		ORDER BY environment ASC, name ASC
+	`
+
+	err := r.db.Select(&variables, query, projectID, environment, environment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config variables: %w", err)
+	}
+
+	return variables, nil
+}
+
+func (r *ConfigRepository) DeleteByName(projectID int64, environment, name string, deletedBy string) error {
+	// Soft delete
+	query := `
+		UPDATE project_config_vars SET deleted_by = ?, deleted_at = NOW()
+		WHERE project_id = ? AND environment = ? AND name = ? AND deleted_at IS NULL
+	`
+	_, err := r.db.Exec(query, deletedBy, projectID, environment, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete config variable: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+type DeploymentRepository struct {
+	db DBTX
+}
+
+func NewDeploymentRepository(db *sqlx.DB) *DeploymentRepository {
+	return &DeploymentRepository{db: db}
+}
+
+// deploymentColumns selects a deployment together with its project and snapshot UUIDs
+const deploymentColumns = `
+	d.id, d.uuid, d.project_id, p.uuid AS project_uuid, d.snapshot_id, s.uuid AS snapshot_uuid,
+	d.environment, d.status, d.version, d.deployed_by, d.deployment_url, d.error_message,
+	d.started_at, d.completed_at, d.created_by, d.updated_by, d.deleted_by, d.created_at, d.updated_at, d.deleted_at
+`
+
+const deploymentJoins = `
+	FROM deployments d
+	JOIN projects p ON p.id = d.project_id
+	LEFT JOIN generation_snapshots s ON s.id = d.snapshot_id
+`
+
+func (r *DeploymentRepository) Create(deployment *models.Deployment) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	id := uuidToInt64(uuidV7)
+	uuidStr := uuidV7.String()
+
+	query := `
+		INSERT INTO deployments (id, uuid, project_id, snapshot_id, environment, status, version, deployed_by,
+								 created_by, started_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW(), NOW())
+	`
+	_, err := r.db.Exec(query, id, uuidStr, deployment.ProjectID, deployment.SnapshotID, deployment.Environment,
+		deployment.Status, deployment.Version, deployment.DeployedBy, deployment.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create deployment: %w", err)
+	}
+
+	// Get the created deployment to populate all fields including timestamps
+	created, err := r.GetByUUID(uuidStr)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve created deployment: %w", err)
+	}
+
+	*deployment = *created
+	return nil
+}
+
+// GetByUUID retrieves deployment by UUID (external identifier)
+func (r *DeploymentRepository) GetByUUID(uuid string) (*models.Deployment, error) {
+	var deployment models.Deployment
+	query := `SELECT ` + deploymentColumns + deploymentJoins + ` WHERE d.uuid = ? AND d.deleted_at IS NULL`
+
+	err := r.db.Get(&deployment, query, uuid)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("deployment")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	return &deployment, nil
+}
+
+// GetAll retrieves deployments matching the filter, newest first
+func (r *DeploymentRepository) GetAll(filter *models.DeploymentFilter, limit, offset int) ([]models.Deployment, int64, error) {
+	conditions := []string{"d.deleted_at IS NULL"}
+	args := []interface{}{}
+
+	if filter.ProjectID != 0 {
+		conditions = append(conditions, "d.project_id = ?")
+		args = append(args, filter.ProjectID)
+	}
+	if filter.ProjectUUIDs != nil {
+		if len(filter.ProjectUUIDs) == 0 {
+			return []models.Deployment{}, 0, nil
+		}
+		in, inArgs, err := sqlx.In("d.project_id IN (SELECT id FROM projects WHERE uuid IN (?))", filter.ProjectUUIDs)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to build deployment filter: %w", err)
+		}
+		conditions = append(conditions, in)
+		args = append(args, inArgs...)
+	}
+	if filter.Environment != "" {
+		conditions = append(conditions, "d.environment = ?")
+		args = append(args, filter.Environment)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "d.status = ?")
+		args = append(args, filter.Status)
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var deployments []models.Deployment
+	query := `SELECT ` + deploymentColumns + deploymentJoins + where + ` ORDER BY d.started_at DESC, d.id DESC LIMIT ? OFFSET ?`
+
+	err := r.db.Select(&deployments, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get deployments: %w", err)
+	}
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM deployments d` + where
+	err = r.db.Get(&total, countQuery, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count deployments: %w", err)
+	}
+
+	return deployments, total, nil
+}
+
+// UpdateStatus moves a deployment from one status to another.
+// Returns false when the deployment is no longer in the expected status.
+func (r *DeploymentRepository) UpdateStatus(deployment *models.Deployment, fromStatus string) (bool, error) {
+	completed := deployment.IsFinished()
+
+	query := `
+		UPDATE deployments
+		SET status = ?, deployment_url = ?, error_message = ?, updated_by = ?, updated_at = NOW(),
+		    completed_at = CASE WHEN ? THEN NOW() ELSE completed_at END
+		WHERE id = ? AND status = ? AND deleted_at IS NULL
+	`
+	result, err := r.db.Exec(query, deployment.Status, deployment.DeploymentURL, deployment.ErrorMessage,
+		deployment.UpdatedBy, completed, deployment.ID, fromStatus)
+	if err != nil {
+		return false, fmt.Errorf("failed to update deployment status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update deployment status: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// AppendLog adds a log entry to a deployment
+func (r *DeploymentRepository) AppendLog(log *models.DeploymentLog) error {
+	log.ID = uuidToInt64(uuid.Must(uuid.NewV7()))
+
+	query := `
+		INSERT INTO deployment_logs (id, deployment_id, level, message, created_at)
+		VALUES (?, ?, ?, ?, NOW())
+	`
+	_, err := r.db.Exec(query, log.ID, log.DeploymentID, log.Level, log.Message)
+	if err != nil {
+		return fmt.Errorf("failed to append deployment log: %w", err)
+	}
+
+	return nil
+}
+
+// GetLogs retrieves a page of a deployment's logs in the order they were written
+func (r *DeploymentRepository) GetLogs(deploymentID int64, limit, offset int) ([]models.DeploymentLog, int64, error) {
+	var logs []models.DeploymentLog
+	query := `
+		SELECT id, deployment_id, level, message, created_at
+		FROM deployment_logs
+		WHERE deployment_id = ?
+		ORDER BY created_at ASC, id ASC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.Select(&logs, query, deploymentID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get deployment logs: %w", err)
+	}
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM deployment_logs WHERE deployment_id = ?`
+	err = r.db.Get(&total, countQuery, deploymentID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count deployment logs: %w", err)
+	}
+
+	return logs, total, nil
+}
@@ -5,14 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/apperror"
 	"github.com/yourusername/lambra/internal/models"
 )
 
 type EndpointRepository struct {
-	db *sqlx.DB
+	db DBTX
 }
 
 func NewEndpointRepository(db *sqlx.DB) *EndpointRepository {
@@ -43,12 +45,12 @@ func (r *EndpointRepository) Create(endpoint *models.Endpoint) error {
 
 	query := `
 		INSERT INTO endpoints (id, uuid, entity_id, project_id, name, path, method, description,
-								request_schema, response_schema, require_auth, created_by, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+								request_schema, response_schema, mock, require_auth, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
 	`
 	_, err := r.db.Exec(query, id, uuidStr,
 		endpoint.EntityID, endpoint.ProjectID, endpoint.Name, endpoint.Path, endpoint.Method,
-		endpoint.Description, endpoint.RequestSchema, endpoint.ResponseSchema, endpoint.RequireAuth, endpoint.CreatedBy)
+		endpoint.Description, endpoint.RequestSchema, endpoint.ResponseSchema, endpoint.Mock, endpoint.RequireAuth, endpoint.CreatedBy)
 	if err != nil {
 		return fmt.Errorf("failed to create endpoint: %w", err)
 	}
@@ -68,7 +70,7 @@ func (r *EndpointRepository) GetByUUID(uuid string) (*models.Endpoint, error) {
 	var endpoint models.Endpoint
 	query := `
 		SELECT id, uuid, entity_id, project_id, name, path, method, description,
-		       request_schema, response_schema, require_auth,
+		       request_schema, response_schema, mock, require_auth, version,
 		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
 		FROM endpoints
 		WHERE uuid = ? AND deleted_at IS NULL
@@ -76,7 +78,7 @@ func (r *EndpointRepository) GetByUUID(uuid string) (*models.Endpoint, error) {
 
 	err := r.db.Get(&endpoint, query, uuid)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("endpoint not found")
+		return nil, apperror.NotFound("endpoint")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get endpoint: %w", err)
@@ -90,7 +92,7 @@ func (r *EndpointRepository) GetByID(id int64) (*models.Endpoint, error) {
 	var endpoint models.Endpoint
 	query := `
 		SELECT id, uuid, entity_id, project_id, name, path, method, description,
-		       request_schema, response_schema, require_auth,
+		       request_schema, response_schema, mock, require_auth, version,
 		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
 		FROM endpoints
 		WHERE id = ? AND deleted_at IS NULL
@@ -98,7 +100,7 @@ func (r *EndpointRepository) GetByID(id int64) (*models.Endpoint, error) {
 
 	err := r.db.Get(&endpoint, query, id)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("endpoint not found")
+		return nil, apperror.NotFound("endpoint")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get endpoint: %w", err)
@@ -111,7 +113,7 @@ func (r *EndpointRepository) GetByProjectID(projectID int64) ([]models.Endpoint,
 	var endpoints []models.Endpoint
 	query := `
 		SELECT id, uuid, entity_id, project_id, name, path, method, description,
-		       request_schema, response_schema, require_auth,
+		       request_schema, response_schema, mock, require_auth, version,
 		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
 		FROM endpoints
 		WHERE project_id = ? AND deleted_at IS NULL
@@ -130,7 +132,7 @@ func (r *EndpointRepository) GetByEntityID(entityID int64) ([]models.Endpoint, e
 	var endpoints []models.Endpoint
 	query := `
 		SELECT id, uuid, entity_id, project_id, name, path, method, description,
-		       request_schema, response_schema, require_auth,
+		       request_schema, response_schema, mock, require_auth, version,
 		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
 		FROM endpoints
 		WHERE entity_id = ? AND deleted_at IS NULL
@@ -145,30 +147,204 @@ func (r *EndpointRepository) GetByEntityID(entityID int64) ([]models.Endpoint, e
 	return endpoints, nil
 }
 
+// endpointSortColumns are the sort fields of endpoint listings
+var endpointSortColumns = map[string]string{
+	"name":       "name",
+	"path":       "path",
+	"method":     "method",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// List returns a page of the endpoints matching a filter, with the total number of matches
+func (r *EndpointRepository) List(filter *models.EndpointFilter, limit, offset int) ([]models.Endpoint, int64, error) {
+	q := newListQuery()
+	if filter.ProjectID != 0 {
+		q.add(`project_id = ?`, filter.ProjectID)
+	}
+	if filter.EntityID != 0 {
+		q.add(`entity_id = ?`, filter.EntityID)
+	}
+	if filter.Method != "" {
+		q.add(`method = ?`, filter.Method)
+	}
+	if filter.RequireAuth != nil {
+		q.add(`require_auth = ?`, *filter.RequireAuth)
+	}
+	q.addOptions(filter.ListOptions)
+
+	order, err := orderBy(filter.ListOptions, endpointSortColumns, "created_at ASC")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	endpoints := []models.Endpoint{}
+	query := `
+		SELECT id, uuid, entity_id, project_id, name, path, method, description,
+		       request_schema, response_schema, mock, require_auth, version,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM endpoints` + q.where() + order + `
+		LIMIT ? OFFSET ?
+	`
+
+	err = r.db.Select(&endpoints, query, append(q.args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get endpoints: %w", err)
+	}
+
+	var total int64
+	err = r.db.Get(&total, `SELECT COUNT(*) FROM endpoints`+q.where(), q.args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count endpoints: %w", err)
+	}
+
+	return endpoints, total, nil
+}
+
+// Update saves an endpoint read at endpoint.Version and increments the version, failing with
+// ErrStaleVersion when another update came first
 func (r *EndpointRepository) Update(endpoint *models.Endpoint) error {
 	query := `
 		UPDATE endpoints
 		SET name = ?, path = ?, method = ?, description = ?,
-			request_schema = ?, response_schema = ?, require_auth = ?, updated_by = ?, updated_at = NOW()
-		WHERE uuid = ? AND deleted_at IS NULL
+			request_schema = ?, response_schema = ?, mock = ?, require_auth = ?, updated_by = ?, updated_at = NOW(), version = version + 1
+		WHERE uuid = ? AND version = ? AND deleted_at IS NULL
 	`
-	_, err := r.db.Exec(query,
+	result, err := r.db.Exec(query,
 		endpoint.Name, endpoint.Path, endpoint.Method, endpoint.Description,
-		endpoint.RequestSchema, endpoint.ResponseSchema, endpoint.RequireAuth, endpoint.UpdatedBy, endpoint.UUID)
+		endpoint.RequestSchema, endpoint.ResponseSchema, endpoint.Mock, endpoint.RequireAuth, endpoint.UpdatedBy, endpoint.UUID, endpoint.Version)
 	if err != nil {
 		return fmt.Errorf("failed to update endpoint: %w", err)
 	}
+	if err := checkVersioned(result); err != nil {
+		return err
+	}
 
+	endpoint.Version++
 	return nil
 }
 
-func (r *EndpointRepository) DeleteByUUID(uuid string, deletedBy string) error {
-	// Soft delete
-	query := `UPDATE endpoints SET deleted_by = ?, deleted_at = NOW() WHERE uuid = ? AND deleted_at IS NULL`
-	_, err := r.db.Exec(query, deletedBy, uuid)
+// DeleteByUUID soft deletes an endpoint still at version, failing with ErrStaleVersion otherwise
+func (r *EndpointRepository) DeleteByUUID(uuid string, deletedBy string, version int64) error {
+	query := `
+		UPDATE endpoints SET deleted_by = ?, deleted_at = NOW(), deletion_id = uuid
+		WHERE uuid = ? AND version = ? AND deleted_at IS NULL
+	`
+	result, err := r.db.Exec(query, deletedBy, uuid, version)
 	if err != nil {
 		return fmt.Errorf("failed to delete endpoint: %w", err)
 	}
 
+	return checkVersioned(result)
+}
+
+// DeleteByEntityID soft deletes the live endpoints of an entity as part of the deletion of that entity
+func (r *EndpointRepository) DeleteByEntityID(entityID int64, deletedBy string, deletionID string) error {
+	return r.deleteWhere(`entity_id = ?`, entityID, deletedBy, deletionID)
+}
+
+// DeleteByProjectID soft deletes the live endpoints of a project as part of the deletion of that project
+func (r *EndpointRepository) DeleteByProjectID(projectID int64, deletedBy string, deletionID string) error {
+	return r.deleteWhere(`project_id = ?`, projectID, deletedBy, deletionID)
+}
+
+func (r *EndpointRepository) deleteWhere(condition string, id int64, deletedBy string, deletionID string) error {
+	query := `
+		UPDATE endpoints SET deleted_by = ?, deleted_at = NOW(), deletion_id = ?
+		WHERE ` + condition + ` AND deleted_at IS NULL
+	`
+	if _, err := r.db.Exec(query, deletedBy, deletionID, id); err != nil {
+		return fmt.Errorf("failed to delete endpoints: %w", err)
+	}
+
 	return nil
 }
+
+// GetDeletedByUUID retrieves a soft deleted endpoint by UUID
+func (r *EndpointRepository) GetDeletedByUUID(uuid string) (*models.Endpoint, error) {
+	endpoints, err := r.selectDeleted(`ep.uuid = ? AND ep.deleted_at IS NOT NULL`, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if len(endpoints) == 0 {
+		return nil, apperror.NotFound("deleted endpoint")
+	}
+
+	return &endpoints[0], nil
+}
+
+// GetDeletedByProjectID lists the endpoints of a project deleted on their own, most recently deleted first
+func (r *EndpointRepository) GetDeletedByProjectID(projectID int64) ([]models.Endpoint, error) {
+	return r.selectDeleted(`ep.project_id = ? AND ep.deleted_at IS NOT NULL AND ep.deletion_id = ep.uuid`, projectID)
+}
+
+// GetByDeletionID lists the endpoints deleted with the resource of a deletion ID
+func (r *EndpointRepository) GetByDeletionID(deletionID string) ([]models.Endpoint, error) {
+	return r.selectDeleted(`ep.deletion_id = ? AND ep.uuid <> ? AND ep.deleted_at IS NOT NULL`, deletionID, deletionID)
+}
+
+// GetDeletedBefore lists the endpoints of live entities soft deleted before a time. Endpoints of
+// deleted entities are left to the purge of their entity.
+func (r *EndpointRepository) GetDeletedBefore(before time.Time) ([]models.Endpoint, error) {
+	return r.selectDeleted(`ep.deleted_at < ? AND e.deleted_at IS NULL AND p.deleted_at IS NULL`, before)
+}
+
+func (r *EndpointRepository) selectDeleted(condition string, args ...interface{}) ([]models.Endpoint, error) {
+	endpoints := []models.Endpoint{}
+	query := `
+		SELECT ep.id, ep.uuid, ep.entity_id, ep.project_id, ep.name, ep.path, ep.method, ep.description,
+		       ep.request_schema, ep.response_schema, ep.mock, ep.require_auth, ep.version,
+		       ep.created_by, ep.updated_by, ep.deleted_by, ep.created_at, ep.updated_at, ep.deleted_at
+		FROM endpoints ep
+		JOIN entities e ON e.id = ep.entity_id
+		JOIN projects p ON p.id = ep.project_id
+		WHERE ` + condition + `
+		ORDER BY ep.deleted_at DESC, ep.id DESC
+	`
+
+	err := r.db.Select(&endpoints, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted endpoints: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+// Restore brings back a soft deleted endpoint and increments its version
+func (r *EndpointRepository) Restore(uuid string, restoredBy string) error {
+	query := `
+		UPDATE endpoints
+		SET deleted_by = NULL, deleted_at = NULL, deletion_id = NULL, updated_by = ?, updated_at = NOW(), version = version + 1
+		WHERE uuid = ? AND deleted_at IS NOT NULL
+	`
+	result, err := r.db.Exec(query, restoredBy, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to restore endpoint: %w", err)
+	}
+
+	return checkFound(result, "deleted endpoint")
+}
+
+// RestoreByDeletionID brings back the endpoints deleted with the resource of a deletion ID
+func (r *EndpointRepository) RestoreByDeletionID(deletionID string, restoredBy string) error {
+	query := `
+		UPDATE endpoints
+		SET deleted_by = NULL, deleted_at = NULL, deletion_id = NULL, updated_by = ?, updated_at = NOW(), version = version + 1
+		WHERE deletion_id = ? AND uuid <> ? AND deleted_at IS NOT NULL
+	`
+	if _, err := r.db.Exec(query, restoredBy, deletionID, deletionID); err != nil {
+		return fmt.Errorf("failed to restore endpoints: %w", err)
+	}
+
+	return nil
+}
+
+// Purge permanently deletes a soft deleted endpoint
+func (r *EndpointRepository) Purge(uuid string) error {
+	result, err := r.db.Exec(`DELETE FROM endpoints WHERE uuid = ? AND deleted_at IS NOT NULL`, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to purge endpoint: %w", err)
+	}
+
+	return checkFound(result, "deleted endpoint")
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// EndpointRunRepository stores the history of endpoint test runs; it only appends and reads
+type EndpointRunRepository struct {
+	db DBTX
+}
+
+func NewEndpointRunRepository(db *sqlx.DB) *EndpointRunRepository {
+	return &EndpointRunRepository{db: db}
+}
+
+func (r *EndpointRunRepository) Create(run *models.EndpointRun) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	run.ID = uuidToInt64(uuidV7)
+	run.UUID = uuidV7.String()
+
+	query := `
+		INSERT INTO endpoint_runs (id, uuid, endpoint_id, environment, method, url, status_code, request, response,
+								   created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(6))
+	`
+	_, err := r.db.Exec(query, run.ID, run.UUID, run.EndpointID, run.Environment, run.Method, run.URL,
+		run.StatusCode, run.Request, run.Response, run.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create endpoint run: %w", err)
+	}
+
+	return nil
+}
+
+// GetByEndpointID lists the runs of an endpoint, newest first
+func (r *EndpointRunRepository) GetByEndpointID(endpointID int64, limit, offset int) ([]models.EndpointRun, int64, error) {
+	var runs []models.EndpointRun
+	query := `
+		SELECT r.id, r.uuid, r.endpoint_id, e.uuid AS endpoint_uuid, r.environment, r.method, r.url,
+		       r.status_code, r.request, r.response, r.created_by, r.created_at
+		FROM endpoint_runs r
+		JOIN endpoints e ON e.id = r.endpoint_id
+		WHERE r.endpoint_id = ?
+		ORDER BY r.created_at DESC, r.id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.Select(&runs, query, endpointID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get endpoint runs: %w", err)
+	}
+
+	var total int64
+	err = r.db.Get(&total, `SELECT COUNT(*) FROM endpoint_runs WHERE endpoint_id = ?`, endpointID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count endpoint runs: %w", err)
+	}
+
+	return runs, total, nil
+}
@@ -4,14 +4,16 @@ import (
 	"database/sql"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/apperror"
 	"github.com/yourusername/lambra/internal/models"
 )
 
 type EntityRepository struct {
-	db *sqlx.DB
+	db DBTX
 }
 
 func NewEntityRepository(db *sqlx.DB) *EntityRepository {
@@ -55,7 +57,7 @@ func (r *EntityRepository) Create(entity *models.Entity) error {
 func (r *EntityRepository) GetByUUID(uuid string) (*models.Entity, error) {
 	var entity models.Entity
 	query := `
-		SELECT id, uuid, project_id, name, table_name, description, fields,
+		SELECT id, uuid, project_id, name, table_name, description, fields, version,
 		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
 		FROM entities
 		WHERE uuid = ? AND deleted_at IS NULL
@@ -63,7 +65,7 @@ func (r *EntityRepository) GetByUUID(uuid string) (*models.Entity, error) {
 
 	err := r.db.Get(&entity, query, uuid)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("entity not found")
+		return nil, apperror.NotFound("entity")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get entity: %w", err)
@@ -76,7 +78,7 @@ func (r *EntityRepository) GetByUUID(uuid string) (*models.Entity, error) {
 func (r *EntityRepository) GetByID(id int64) (*models.Entity, error) {
 	var entity models.Entity
 	query := `
-		SELECT id, uuid, project_id, name, table_name, description, fields,
+		SELECT id, uuid, project_id, name, table_name, description, fields, version,
 		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
 		FROM entities
 		WHERE id = ? AND deleted_at IS NULL
@@ -84,7 +86,7 @@ func (r *EntityRepository) GetByID(id int64) (*models.Entity, error) {
 
 	err := r.db.Get(&entity, query, id)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("entity not found")
+		return nil, apperror.NotFound("entity")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get entity: %w", err)
@@ -96,7 +98,7 @@ func (r *EntityRepository) GetByID(id int64) (*models.Entity, error) {
 func (r *EntityRepository) GetByProjectID(projectID int64) ([]models.Entity, error) {
 	var entities []models.Entity
 	query := `
-		SELECT id, uuid, project_id, name, table_name, description, fields,
+		SELECT id, uuid, project_id, name, table_name, description, fields, version,
 		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
 		FROM entities
 		WHERE project_id = ? AND deleted_at IS NULL
@@ -111,27 +113,174 @@ func (r *EntityRepository) GetByProjectID(projectID int64) ([]models.Entity, err
 	return entities, nil
 }
 
+// entitySortColumns are the sort fields of entity listings
+var entitySortColumns = map[string]string{
+	"name":       "name",
+	"table_name": "table_name",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// List returns a page of the entities of a project matching a filter, with the total number of matches
+func (r *EntityRepository) List(filter *models.EntityFilter, limit, offset int) ([]models.Entity, int64, error) {
+	q := newListQuery()
+	q.add(`project_id = ?`, filter.ProjectID)
+	q.addOptions(filter.ListOptions)
+
+	order, err := orderBy(filter.ListOptions, entitySortColumns, "created_at ASC")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entities := []models.Entity{}
+	query := `
+		SELECT id, uuid, project_id, name, table_name, description, fields, version,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM entities` + q.where() + order + `
+		LIMIT ? OFFSET ?
+	`
+
+	err = r.db.Select(&entities, query, append(q.args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get entities: %w", err)
+	}
+
+	var total int64
+	err = r.db.Get(&total, `SELECT COUNT(*) FROM entities`+q.where(), q.args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count entities: %w", err)
+	}
+
+	return entities, total, nil
+}
+
+// Update saves an entity read at entity.Version and increments the version, failing with
+// ErrStaleVersion when another update came first
 func (r *EntityRepository) Update(entity *models.Entity) error {
 	query := `
 		UPDATE entities
-		SET name = ?, table_name = ?, description = ?, fields = ?, updated_by = ?, updated_at = NOW()
-		WHERE uuid = ? AND deleted_at IS NULL
+		SET name = ?, table_name = ?, description = ?, fields = ?, updated_by = ?, updated_at = NOW(), version = version + 1
+		WHERE uuid = ? AND version = ? AND deleted_at IS NULL
 	`
-	_, err := r.db.Exec(query, entity.Name, entity.TableName, entity.Description, entity.Fields, entity.UpdatedBy, entity.UUID)
+	result, err := r.db.Exec(query, entity.Name, entity.TableName, entity.Description, entity.Fields, entity.UpdatedBy,
+		entity.UUID, entity.Version)
 	if err != nil {
 		return fmt.Errorf("failed to update entity: %w", err)
 	}
+	if err := checkVersioned(result); err != nil {
+		return err
+	}
 
+	entity.Version++
 	return nil
 }
 
-func (r *EntityRepository) DeleteByUUID(uuid string, deletedBy string) error {
-	// Soft delete
-	query := `UPDATE entities SET deleted_by = ?, deleted_at = NOW() WHERE uuid = ? AND deleted_at IS NULL`
-	_, err := r.db.Exec(query, deletedBy, uuid)
+// DeleteByUUID soft deletes an entity still at version, failing with ErrStaleVersion otherwise. The
+// entity UUID is the deletion ID of the endpoints deleted with it.
+func (r *EntityRepository) DeleteByUUID(uuid string, deletedBy string, version int64) error {
+	query := `
+		UPDATE entities SET deleted_by = ?, deleted_at = NOW(), deletion_id = uuid
+		WHERE uuid = ? AND version = ? AND deleted_at IS NULL
+	`
+	result, err := r.db.Exec(query, deletedBy, uuid, version)
 	if err != nil {
 		return fmt.Errorf("failed to delete entity: %w", err)
 	}
 
+	return checkVersioned(result)
+}
+
+// DeleteByProjectID soft deletes the live entities of a project as part of the deletion of that project
+func (r *EntityRepository) DeleteByProjectID(projectID int64, deletedBy string, deletionID string) error {
+	query := `
+		UPDATE entities SET deleted_by = ?, deleted_at = NOW(), deletion_id = ?
+		WHERE project_id = ? AND deleted_at IS NULL
+	`
+	if _, err := r.db.Exec(query, deletedBy, deletionID, projectID); err != nil {
+		return fmt.Errorf("failed to delete entities: %w", err)
+	}
+
 	return nil
 }
+
+// GetDeletedByUUID retrieves a soft deleted entity by UUID
+func (r *EntityRepository) GetDeletedByUUID(uuid string) (*models.Entity, error) {
+	entities, err := r.selectDeleted(`e.uuid = ? AND e.deleted_at IS NOT NULL`, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return nil, apperror.NotFound("deleted entity")
+	}
+
+	return &entities[0], nil
+}
+
+// GetDeletedByProjectID lists the entities of a project deleted on their own, most recently deleted first
+func (r *EntityRepository) GetDeletedByProjectID(projectID int64) ([]models.Entity, error) {
+	return r.selectDeleted(`e.project_id = ? AND e.deleted_at IS NOT NULL AND e.deletion_id = e.uuid`, projectID)
+}
+
+// GetDeletedBefore lists the entities of live projects soft deleted before a time. Entities of
+// deleted projects are left to the purge of their project.
+func (r *EntityRepository) GetDeletedBefore(before time.Time) ([]models.Entity, error) {
+	return r.selectDeleted(`e.deleted_at < ? AND p.deleted_at IS NULL`, before)
+}
+
+func (r *EntityRepository) selectDeleted(condition string, args ...interface{}) ([]models.Entity, error) {
+	entities := []models.Entity{}
+	query := `
+		SELECT e.id, e.uuid, e.project_id, e.name, e.table_name, e.description, e.fields, e.version,
+		       e.created_by, e.updated_by, e.deleted_by, e.created_at, e.updated_at, e.deleted_at
+		FROM entities e
+		JOIN projects p ON p.id = e.project_id
+		WHERE ` + condition + `
+		ORDER BY e.deleted_at DESC, e.id DESC
+	`
+
+	err := r.db.Select(&entities, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted entities: %w", err)
+	}
+
+	return entities, nil
+}
+
+// Restore brings back a soft deleted entity and increments its version
+func (r *EntityRepository) Restore(uuid string, restoredBy string) error {
+	query := `
+		UPDATE entities
+		SET deleted_by = NULL, deleted_at = NULL, deletion_id = NULL, updated_by = ?, updated_at = NOW(), version = version + 1
+		WHERE uuid = ? AND deleted_at IS NOT NULL
+	`
+	result, err := r.db.Exec(query, restoredBy, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to restore entity: %w", err)
+	}
+
+	return checkFound(result, "deleted entity")
+}
+
+// RestoreByDeletionID brings back the entities deleted with the resource of a deletion ID
+func (r *EntityRepository) RestoreByDeletionID(deletionID string, restoredBy string) error {
+	query := `
+		UPDATE entities
+		SET deleted_by = NULL, deleted_at = NULL, deletion_id = NULL, updated_by = ?, updated_at = NOW(), version = version + 1
+		WHERE deletion_id = ? AND uuid <> ? AND deleted_at IS NOT NULL
+	`
+	if _, err := r.db.Exec(query, restoredBy, deletionID, deletionID); err != nil {
+		return fmt.Errorf("failed to restore entities: %w", err)
+	}
+
+	return nil
+}
+
+// Purge permanently deletes a soft deleted entity; foreign keys remove its endpoints with it
+func (r *EntityRepository) Purge(uuid string) error {
+	result, err := r.db.Exec(`DELETE FROM entities WHERE uuid = ? AND deleted_at IS NOT NULL`, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to purge entity: %w", err)
+	}
+
+	return checkFound(result, "deleted entity")
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/yourusername/lambra/internal/apperror"
+)
+
+// mysqlDuplicateEntry is the MySQL error number of a unique key violation
+const mysqlDuplicateEntry = 1062
+
+// isDuplicateEntry reports whether err violates a unique key
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
+
+// duplicateAs returns a conflict error with message when err violates a unique key, and nil otherwise
+func duplicateAs(err error, message string) error {
+	if isDuplicateEntry(err) {
+		return apperror.Conflict(message)
+	}
+	return nil
+}
+
+// ErrStaleVersion is returned when a versioned row changed between reading and writing it
+var ErrStaleVersion = apperror.PreconditionFailed("resource was modified by another request")
+
+// checkVersioned returns ErrStaleVersion when a versioned update or delete matched no row
+func checkVersioned(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrStaleVersion
+	}
+	return nil
+}
+
+// checkFound returns the not found error of a resource when an update or delete matched no row
+func checkFound(result sql.Result, resource string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rows == 0 {
+		return apperror.NotFound(resource)
+	}
+	return nil
+}
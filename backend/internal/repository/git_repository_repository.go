@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+type GitRepositoryRepository struct {
+	db DBTX
+}
+
+func NewGitRepositoryRepository(db *sqlx.DB) *GitRepositoryRepository {
+	return &GitRepositoryRepository{db: db}
+}
+
+func (r *GitRepositoryRepository) Create(repo *models.GitRepository) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	id := uuidToInt64(uuidV7)
+	uuidStr := uuidV7.String()
+
+	query := `
+		INSERT INTO git_repositories (id, uuid, project_id, provider, repo_url, repo_name, repo_path, gitlab_repo_id,
+									  default_branch, develop_branch, staging_branch, production_branch,
+									  last_commit_hash, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+	`
+	_, err := r.db.Exec(query, id, uuidStr, repo.ProjectID, repo.Provider, repo.RepoURL, repo.RepoName, repo.RepoPath, repo.GitLabRepoID,
+		repo.DefaultBranch, repo.DevelopBranch, repo.StagingBranch, repo.ProductionBranch,
+		repo.LastCommitHash, repo.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create git repository: %w", err)
+	}
+
+	// Get the created repository to populate all fields including timestamps
+	created, err := r.GetByUUID(uuidStr)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve created git repository: %w", err)
+	}
+
+	*repo = *created
+	return nil
+}
+
+// GetByUUID retrieves git repository by UUID (external identifier)
+func (r *GitRepositoryRepository) GetByUUID(uuid string) (*models.GitRepository, error) {
+	var repo models.GitRepository
+	query := `
+		SELECT id, uuid, project_id, provider, repo_url, repo_name, repo_path, gitlab_repo_id,
+		       default_branch, develop_branch, staging_branch, production_branch, last_commit_hash,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM git_repositories
+		WHERE uuid = ? AND deleted_at IS NULL
+	`
+
+	err := r.db.Get(&repo, query, uuid)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("git repository")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git repository: %w", err)
+	}
+
+	return &repo, nil
+}
+
+// GetByProjectID retrieves the git repository linked to a project
+func (r *GitRepositoryRepository) GetByProjectID(projectID int64) (*models.GitRepository, error) {
+	var repo models.GitRepository
+	query := `
+		SELECT id, uuid, project_id, provider, repo_url, repo_name, repo_path, gitlab_repo_id,
+		       default_branch, develop_branch, staging_branch, production_branch, last_commit_hash,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM git_repositories
+		WHERE project_id = ? AND deleted_at IS NULL
+	`
+
+	err := r.db.Get(&repo, query, projectID)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("git repository")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git repository: %w", err)
+	}
+
+	return &repo, nil
+}
+
+// GetByRemoteID retrieves a git repository by its provider and remote repository ID
+func (r *GitRepositoryRepository) GetByRemoteID(provider string, remoteID int64) (*models.GitRepository, error) {
+	var repo models.GitRepository
+	query := `
+		SELECT id, uuid, project_id, provider, repo_url, repo_name, repo_path, gitlab_repo_id,
+		       default_branch, develop_branch, staging_branch, production_branch, last_commit_hash,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM git_repositories
+		WHERE provider = ? AND gitlab_repo_id = ? AND deleted_at IS NULL
+	`
+
+	err := r.db.Get(&repo, query, provider, remoteID)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("git repository")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git repository: %w", err)
+	}
+
+	return &repo, nil
+}
+
+func (r *GitRepositoryRepository) UpdateLastCommitHash(id int64, commitHash string, updatedBy string) error {
+	query := `
+		UPDATE git_repositories
+		SET last_commit_hash = ?, updated_by = ?, updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+	_, err := r.db.Exec(query, commitHash, updatedBy, id)
+	if err != nil {
+		return fmt.Errorf("failed to update last commit hash: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByProjectID soft deletes the live git repositories of a project as part of the deletion of that project
+func (r *GitRepositoryRepository) DeleteByProjectID(projectID int64, deletedBy string, deletionID string) error {
+	query := `
+		UPDATE git_repositories SET deleted_by = ?, deleted_at = NOW(), deletion_id = ?
+		WHERE project_id = ? AND deleted_at IS NULL
+	`
+	if _, err := r.db.Exec(query, deletedBy, deletionID, projectID); err != nil {
+		return fmt.Errorf("failed to delete git repositories: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreByDeletionID brings back the git repositories deleted with the resource of a deletion ID
+func (r *GitRepositoryRepository) RestoreByDeletionID(deletionID string, restoredBy string) error {
+	query := `
+		UPDATE git_repositories
+		SET deleted_by = NULL, deleted_at = NULL, deletion_id = NULL, updated_by = ?, updated_at = NOW()
+		WHERE deletion_id = ? AND deleted_at IS NOT NULL
+	`
+	if _, err := r.db.Exec(query, restoredBy, deletionID); err != nil {
+		return fmt.Errorf("failed to restore git repositories: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// listQuery collects the conditions and arguments of a listing's WHERE clause
+type listQuery struct {
+	conditions []string
+	args       []interface{}
+}
+
+func newListQuery() *listQuery {
+	return &listQuery{conditions: []string{"deleted_at IS NULL"}}
+}
+
+// add appends a condition with its arguments
+func (q *listQuery) add(condition string, args ...interface{}) {
+	q.conditions = append(q.conditions, condition)
+	q.args = append(q.args, args...)
+}
+
+// addOptions appends the conditions of the shared search, creator and date range options
+func (q *listQuery) addOptions(opts models.ListOptions) {
+	if opts.Search != "" {
+		pattern := "%" + likeEscaper.Replace(opts.Search) + "%"
+		q.add("(name LIKE ? OR description LIKE ?)", pattern, pattern)
+	}
+	if opts.CreatedBy != "" {
+		q.add("created_by = ?", opts.CreatedBy)
+	}
+	if opts.CreatedAfter != nil {
+		q.add("created_at >= ?", *opts.CreatedAfter)
+	}
+	if opts.CreatedBefore != nil {
+		q.add("created_at < ?", *opts.CreatedBefore)
+	}
+}
+
+// where returns the WHERE clause of the collected conditions
+func (q *listQuery) where() string {
+	return " WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+// likeEscaper escapes the LIKE wildcards of a search term
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// orderBy returns the ORDER BY clause of a whitelisted sort field, or of fallback when no sort is set.
+// Ties are broken by id so pages stay stable.
+func orderBy(opts models.ListOptions, columns map[string]string, fallback string) (string, error) {
+	if opts.Sort == "" {
+		return " ORDER BY " + fallback + ", id", nil
+	}
+
+	column, ok := columns[opts.Sort]
+	if !ok {
+		allowed := make([]string, 0, len(columns))
+		for field := range columns {
+			allowed = append(allowed, field)
+		}
+		sort.Strings(allowed)
+		return "", apperror.Validation("invalid sort field", apperror.FieldError{
+			Field:   "sort",
+			Message: "must be one of " + strings.Join(allowed, ", "),
+		})
+	}
+
+	direction := "ASC"
+	if opts.Descending {
+		direction = "DESC"
+	}
+	return " ORDER BY " + column + " " + direction + ", id " + direction, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+func TestOrderBy(t *testing.T) {
+	columns := map[string]string{"name": "name", "created_at": "created_at"}
+
+	tests := []struct {
+		name string
+		opts models.ListOptions
+		want string
+	}{
+		{"default", models.ListOptions{}, " ORDER BY created_at DESC, id"},
+		{"ascending", models.ListOptions{Sort: "name"}, " ORDER BY name ASC, id ASC"},
+		{"descending", models.ListOptions{Sort: "created_at", Descending: true}, " ORDER BY created_at DESC, id DESC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := orderBy(tt.opts, columns, "created_at DESC")
+			if err != nil {
+				t.Fatalf("orderBy() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("orderBy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderByRejectsUnknownField(t *testing.T) {
+	columns := map[string]string{"name": "name", "created_at": "created_at"}
+
+	for _, sort := range []string{"id; DROP TABLE projects", "password", "NAME"} {
+		_, err := orderBy(models.ListOptions{Sort: sort}, columns, "created_at DESC")
+		if !errors.Is(err, apperror.ErrValidation) {
+			t.Fatalf("orderBy(%q) error = %v, want a validation error", sort, err)
+		}
+		want := []apperror.FieldError{{Field: "sort", Message: "must be one of created_at, name"}}
+		if got := apperror.Fields(err); !reflect.DeepEqual(got, want) {
+			t.Errorf("orderBy(%q) fields = %v, want %v", sort, got, want)
+		}
+	}
+}
+
+func TestListQuerySearchEscapesWildcards(t *testing.T) {
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{"shop", "%shop%"},
+		{"50%", `%50\%%`},
+		{"user_id", `%user\_id%`},
+		{`a\b`, `%a\\b%`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			q := newListQuery()
+			q.addOptions(models.ListOptions{Search: tt.search})
+
+			if want := " WHERE deleted_at IS NULL AND (name LIKE ? OR description LIKE ?)"; q.where() != want {
+				t.Errorf("where() = %q, want %q", q.where(), want)
+			}
+			if want := []interface{}{tt.want, tt.want}; !reflect.DeepEqual(q.args, want) {
+				t.Errorf("args = %v, want %v", q.args, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+type ProjectMemberRepository struct {
+	db DBTX
+}
+
+func NewProjectMemberRepository(db *sqlx.DB) *ProjectMemberRepository {
+	return &ProjectMemberRepository{db: db}
+}
+
+const projectMemberColumns = `
+		SELECT m.id, m.uuid, m.project_id, p.uuid AS project_uuid, m.user_id, u.username, m.role,
+		       m.created_by, m.updated_by, m.deleted_by, m.created_at, m.updated_at, m.deleted_at
+		FROM project_members m
+		JOIN projects p ON p.id = m.project_id
+		JOIN users u ON u.id = m.user_id`
+
+// Upsert adds a member or changes the role of an existing (or removed) one
+func (r *ProjectMemberRepository) Upsert(member *models.ProjectMember) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	id := uuidToInt64(uuidV7)
+
+	query := `
+		INSERT INTO project_members (id, uuid, project_id, user_id, role, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())
+		ON DUPLICATE KEY UPDATE role = VALUES(role), updated_by = VALUES(created_by),
+		                        updated_at = NOW(), deleted_by = NULL, deleted_at = NULL
+	`
+	_, err := r.db.Exec(query, id, uuidV7.String(), member.ProjectID, member.UserID, member.Role, member.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save project member: %w", err)
+	}
+
+	var saved models.ProjectMember
+	err = r.db.Get(&saved, projectMemberColumns+` WHERE m.project_id = ? AND m.user_id = ?`, member.ProjectID, member.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve saved project member: %w", err)
+	}
+
+	*member = saved
+	return nil
+}
+
+// GetRole returns the role of a user on a project, or an empty string when the user is not a member.
+// Roles outlive a soft delete of the project so its owners can restore or purge it.
+func (r *ProjectMemberRepository) GetRole(projectUUID, username string) (string, error) {
+	var role string
+	query := `
+		SELECT m.role
+		FROM project_members m
+		JOIN projects p ON p.id = m.project_id
+		JOIN users u ON u.id = m.user_id
+		WHERE p.uuid = ? AND u.username = ? AND m.deleted_at IS NULL
+	`
+
+	err := r.db.Get(&role, query, projectUUID, username)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get project role: %w", err)
+	}
+
+	return role, nil
+}
+
+// GetProjectUUIDsByUsername lists the projects a user is a member of
+func (r *ProjectMemberRepository) GetProjectUUIDsByUsername(username string) ([]string, error) {
+	var uuids []string
+	query := `
+		SELECT p.uuid
+		FROM project_members m
+		JOIN projects p ON p.id = m.project_id
+		JOIN users u ON u.id = m.user_id
+		WHERE u.username = ? AND m.deleted_at IS NULL AND p.deleted_at IS NULL
+	`
+
+	err := r.db.Select(&uuids, query, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member projects: %w", err)
+	}
+
+	return uuids, nil
+}
+
+// GetByProjectUUID lists the members of a project
+func (r *ProjectMemberRepository) GetByProjectUUID(projectUUID string) ([]models.ProjectMember, error) {
+	var members []models.ProjectMember
+	query := projectMemberColumns + `
+		WHERE p.uuid = ? AND m.deleted_at IS NULL
+		ORDER BY FIELD(m.role, 'owner', 'maintainer', 'developer', 'viewer'), u.username ASC
+	`
+
+	err := r.db.Select(&members, query, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project members: %w", err)
+	}
+
+	return members, nil
+}
+
+func (r *ProjectMemberRepository) Delete(projectID, userID int64, deletedBy string) error {
+	// Soft delete
+	query := `
+		UPDATE project_members SET deleted_by = ?, deleted_at = NOW()
+		WHERE project_id = ? AND user_id = ? AND deleted_at IS NULL
+	`
+	_, err := r.db.Exec(query, deletedBy, projectID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete project member: %w", err)
+	}
+
+	return nil
+}
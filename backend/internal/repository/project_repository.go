@@ -4,14 +4,16 @@ import (
 	"database/sql"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/apperror"
 	"github.com/yourusername/lambra/internal/models"
 )
 
 type ProjectRepository struct {
-	db *sqlx.DB
+	db DBTX
 }
 
 func NewProjectRepository(db *sqlx.DB) *ProjectRepository {
@@ -27,6 +29,10 @@ func uuidToInt64(u uuid.UUID) int64 {
 	return num.Int64()
 }
 
+// ErrNamespaceTaken is returned when another live project of the same team already uses a namespace.
+// The unique key on team and namespace enforces it for concurrent writes.
+var ErrNamespaceTaken = apperror.Conflict("namespace already used in this team")
+
 func (r *ProjectRepository) Create(project *models.Project) error {
 	// Generate UUID v7
 	uuidV7 := uuid.Must(uuid.NewV7())
@@ -34,10 +40,15 @@ func (r *ProjectRepository) Create(project *models.Project) error {
 	uuidStr := uuidV7.String()
 
 	query := `
-		INSERT INTO projects (id, uuid, name, description, status, namespace, created_by, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+		INSERT INTO projects (id, uuid, name, description, status, namespace, image, port, module_path, dialect, environments,
+		                      team_id, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
 	`
-	_, err := r.db.Exec(query, id, uuidStr, project.Name, project.Description, project.Status, project.Namespace, project.CreatedBy)
+	_, err := r.db.Exec(query, id, uuidStr, project.Name, project.Description, project.Status, project.Namespace,
+		project.Image, project.Port, project.ModulePath, project.Dialect, project.Environments, project.TeamID, project.CreatedBy)
+	if isDuplicateEntry(err) {
+		return fmt.Errorf("%w: %s", ErrNamespaceTaken, project.Namespace)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create project: %w", err)
 	}
@@ -52,19 +63,23 @@ func (r *ProjectRepository) Create(project *models.Project) error {
 	return nil
 }
 
+const projectColumns = `
+		       id, uuid, name, description, status, namespace, image, port, module_path, dialect, environments,
+		       git_repo_id, team_id, version, (SELECT t.uuid FROM teams t WHERE t.id = projects.team_id) AS team_uuid,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at`
+
 // GetByUUID retrieves project by UUID (external identifier)
 func (r *ProjectRepository) GetByUUID(uuid string) (*models.Project, error) {
 	var project models.Project
 	query := `
-		SELECT id, uuid, name, description, status, namespace, git_repo_id,
-		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		SELECT` + projectColumns + `
 		FROM projects
 		WHERE uuid = ? AND deleted_at IS NULL
 	`
 
 	err := r.db.Get(&project, query, uuid)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("project not found")
+		return nil, apperror.NotFound("project")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project: %w", err)
@@ -77,15 +92,14 @@ func (r *ProjectRepository) GetByUUID(uuid string) (*models.Project, error) {
 func (r *ProjectRepository) GetByID(id int64) (*models.Project, error) {
 	var project models.Project
 	query := `
-		SELECT id, uuid, name, description, status, namespace, git_repo_id,
-		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		SELECT` + projectColumns + `
 		FROM projects
 		WHERE id = ? AND deleted_at IS NULL
 	`
 
 	err := r.db.Get(&project, query, id)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("project not found")
+		return nil, apperror.NotFound("project")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project: %w", err)
@@ -94,25 +108,58 @@ func (r *ProjectRepository) GetByID(id int64) (*models.Project, error) {
 	return &project, nil
 }
 
-func (r *ProjectRepository) GetAll(limit, offset int) ([]models.Project, int64, error) {
-	var projects []models.Project
+// projectSortColumns are the sort fields of project listings
+var projectSortColumns = map[string]string{
+	"name":       "name",
+	"namespace":  "namespace",
+	"status":     "status",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+func (r *ProjectRepository) GetAll(filter *models.ProjectFilter, limit, offset int) ([]models.Project, int64, error) {
+	q := newListQuery()
+	if filter.UUIDs != nil {
+		if len(filter.UUIDs) == 0 {
+			return []models.Project{}, 0, nil
+		}
+		in, inArgs, err := sqlx.In(`uuid IN (?)`, filter.UUIDs)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to build project filter: %w", err)
+		}
+		q.add(in, inArgs...)
+	}
+	if filter.TeamID != 0 {
+		q.add(`team_id = ?`, filter.TeamID)
+	}
+	if filter.Status != "" {
+		q.add(`status = ?`, filter.Status)
+	}
+	if filter.Namespace != "" {
+		q.add(`namespace = ?`, filter.Namespace)
+	}
+	q.addOptions(filter.ListOptions)
+
+	order, err := orderBy(filter.ListOptions, projectSortColumns, "created_at DESC")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	projects := []models.Project{}
 	query := `
-		SELECT id, uuid, name, description, status, namespace, git_repo_id,
-		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
-		FROM projects
-		WHERE deleted_at IS NULL
-		ORDER BY created_at DESC
+		SELECT` + projectColumns + `
+		FROM projects` + q.where() + order + `
 		LIMIT ? OFFSET ?
 	`
 
-	err := r.db.Select(&projects, query, limit, offset)
+	err = r.db.Select(&projects, query, append(q.args, limit, offset)...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get projects: %w", err)
 	}
 
 	var total int64
-	countQuery := `SELECT COUNT(*) FROM projects WHERE deleted_at IS NULL`
-	err = r.db.Get(&total, countQuery)
+	countQuery := `SELECT COUNT(*) FROM projects` + q.where()
+	err = r.db.Get(&total, countQuery, q.args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count projects: %w", err)
 	}
@@ -120,22 +167,60 @@ func (r *ProjectRepository) GetAll(limit, offset int) ([]models.Project, int64,
 	return projects, total, nil
 }
 
+// GetUUIDs lists the UUIDs of all live projects
+func (r *ProjectRepository) GetUUIDs() ([]string, error) {
+	uuids := []string{}
+	err := r.db.Select(&uuids, `SELECT uuid FROM projects WHERE deleted_at IS NULL ORDER BY created_at DESC, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project uuids: %w", err)
+	}
+	return uuids, nil
+}
+
+// NamespaceExists reports whether another live project of the same team (or of no team) uses a namespace
+func (r *ProjectRepository) NamespaceExists(teamID sql.NullInt64, namespace, excludeUUID string) (bool, error) {
+	var count int
+	query := `
+		SELECT COUNT(*) FROM projects
+		WHERE namespace = ? AND team_id <=> ? AND uuid <> ? AND deleted_at IS NULL
+	`
+
+	err := r.db.Get(&count, query, namespace, teamID, excludeUUID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check project namespace: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// Update saves a project read at project.Version and increments the version, failing with
+// ErrStaleVersion when another update came first
 func (r *ProjectRepository) Update(project *models.Project) error {
 	query := `
 		UPDATE projects
-		SET name = ?, description = ?, status = ?, namespace = ?, updated_by = ?, updated_at = NOW()
-		WHERE uuid = ? AND deleted_at IS NULL
+		SET name = ?, description = ?, status = ?, namespace = ?, image = ?, port = ?, module_path = ?, dialect = ?,
+		    environments = ?, updated_by = ?, updated_at = NOW(), version = version + 1
+		WHERE uuid = ? AND version = ? AND deleted_at IS NULL
 	`
-	_, err := r.db.Exec(query, project.Name, project.Description, project.Status, project.Namespace, project.UpdatedBy, project.UUID)
+	result, err := r.db.Exec(query, project.Name, project.Description, project.Status, project.Namespace,
+		project.Image, project.Port, project.ModulePath, project.Dialect, project.Environments, project.UpdatedBy,
+		project.UUID, project.Version)
+	if isDuplicateEntry(err) {
+		return fmt.Errorf("%w: %s", ErrNamespaceTaken, project.Namespace)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update project: %w", err)
 	}
+	if err := checkVersioned(result); err != nil {
+		return err
+	}
 
+	project.Version++
 	return nil
 }
 
 func (r *ProjectRepository) UpdateStatusByUUID(uuid string, status string) error {
-	query := `UPDATE projects SET status = ?, updated_at = NOW() WHERE uuid = ? AND deleted_at IS NULL`
+	query := `UPDATE projects SET status = ?, updated_at = NOW(), version = version + 1 WHERE uuid = ? AND deleted_at IS NULL`
 	_, err := r.db.Exec(query, status, uuid)
 	if err != nil {
 		return fmt.Errorf("failed to update project status: %w", err)
@@ -144,14 +229,114 @@ func (r *ProjectRepository) UpdateStatusByUUID(uuid string, status string) error
 	return nil
 }
 
-func (r *ProjectRepository) DeleteByUUID(uuid string, deletedBy string) error {
-	// Soft delete
-	query := `UPDATE projects SET deleted_by = ?, deleted_at = NOW() WHERE uuid = ? AND deleted_at IS NULL`
-	_, err := r.db.Exec(query, deletedBy, uuid)
+func (r *ProjectRepository) UpdateGitRepoID(id int64, gitRepoID int64) error {
+	query := `UPDATE projects SET git_repo_id = ?, updated_at = NOW(), version = version + 1 WHERE id = ? AND deleted_at IS NULL`
+	_, err := r.db.Exec(query, gitRepoID, id)
+	if err != nil {
+		return fmt.Errorf("failed to update project git repository: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByUUID soft deletes a project still at version, failing with ErrStaleVersion otherwise. The
+// project UUID is the deletion ID of the rows deleted with it.
+func (r *ProjectRepository) DeleteByUUID(uuid string, deletedBy string, version int64) error {
+	query := `
+		UPDATE projects SET deleted_by = ?, deleted_at = NOW(), deletion_id = uuid
+		WHERE uuid = ? AND version = ? AND deleted_at IS NULL
+	`
+	result, err := r.db.Exec(query, deletedBy, uuid, version)
 	if err != nil {
 		return fmt.Errorf("failed to delete project: %w", err)
 	}
 
+	return checkVersioned(result)
+}
+
+// GetDeletedByUUID retrieves a soft deleted project by UUID
+func (r *ProjectRepository) GetDeletedByUUID(uuid string) (*models.Project, error) {
+	var project models.Project
+	query := `
+		SELECT` + projectColumns + `
+		FROM projects
+		WHERE uuid = ? AND deleted_at IS NOT NULL
+	`
+
+	err := r.db.Get(&project, query, uuid)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("deleted project")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted project: %w", err)
+	}
+
+	return &project, nil
+}
+
+// GetDeleted lists the soft deleted projects, most recently deleted first
+func (r *ProjectRepository) GetDeleted() ([]models.Project, error) {
+	return r.selectDeleted(`deleted_at IS NOT NULL`)
+}
+
+// GetDeletedBefore lists the projects soft deleted before a time
+func (r *ProjectRepository) GetDeletedBefore(before time.Time) ([]models.Project, error) {
+	return r.selectDeleted(`deleted_at < ?`, before)
+}
+
+func (r *ProjectRepository) selectDeleted(condition string, args ...interface{}) ([]models.Project, error) {
+	projects := []models.Project{}
+	query := `
+		SELECT` + projectColumns + `
+		FROM projects
+		WHERE ` + condition + `
+		ORDER BY deleted_at DESC, id DESC
+	`
+
+	err := r.db.Select(&projects, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// Restore brings back a soft deleted project and increments its version
+func (r *ProjectRepository) Restore(uuid string, restoredBy string) error {
+	query := `
+		UPDATE projects
+		SET deleted_by = NULL, deleted_at = NULL, deletion_id = NULL, updated_by = ?, updated_at = NOW(), version = version + 1
+		WHERE uuid = ? AND deleted_at IS NOT NULL
+	`
+	result, err := r.db.Exec(query, restoredBy, uuid)
+	if isDuplicateEntry(err) {
+		return ErrNamespaceTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restore project: %w", err)
+	}
+
+	return checkFound(result, "deleted project")
+}
+
+// Purge permanently deletes a soft deleted project. Foreign keys remove its entities, endpoints,
+// git repository, snapshots, deployments, variables and members with it.
+func (r *ProjectRepository) Purge(uuid string) error {
+	result, err := r.db.Exec(`DELETE FROM projects WHERE uuid = ? AND deleted_at IS NOT NULL`, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to purge project: %w", err)
+	}
+
+	return checkFound(result, "deleted project")
+}
+
+// Discard permanently deletes a project that was just created, undoing Create when a later step
+// of creating it fails. Foreign keys remove the rows created with it.
+func (r *ProjectRepository) Discard(uuid string) error {
+	if _, err := r.db.Exec(`DELETE FROM projects WHERE uuid = ?`, uuid); err != nil {
+		return fmt.Errorf("failed to discard project: %w", err)
+	}
+
 	return nil
 }
 
@@ -168,7 +353,13 @@ func (r *ProjectRepository) GetWithGitRepoByUUID(uuid string) (*models.ProjectWi
 	// Get Git repository if exists
 	if project.GitRepoID.Valid {
 		var gitRepo models.GitRepository
-		query := `SELECT * FROM git_repositories WHERE id = ? AND deleted_at IS NULL`
+		query := `
+			SELECT id, uuid, project_id, provider, repo_url, repo_name, repo_path, gitlab_repo_id,
+			       default_branch, develop_branch, staging_branch, production_branch, last_commit_hash,
+			       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+			FROM git_repositories
+			WHERE id = ? AND deleted_at IS NULL
+		`
 		err = r.db.Get(&gitRepo, query, project.GitRepoID.Int64)
 		if err == nil {
 			result.GitRepo = &gitRepo
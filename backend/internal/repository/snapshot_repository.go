@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+type SnapshotRepository struct {
+	db DBTX
+}
+
+func NewSnapshotRepository(db *sqlx.DB) *SnapshotRepository {
+	return &SnapshotRepository{db: db}
+}
+
+func (r *SnapshotRepository) Create(snapshot *models.GenerationSnapshot) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	id := uuidToInt64(uuidV7)
+	uuidStr := uuidV7.String()
+
+	query := `
+		INSERT INTO generation_snapshots (id, uuid, project_id, version, git_commit_hash, git_tag, metadata,
+										  database_snapshot, status, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+	`
+	_, err := r.db.Exec(query, id, uuidStr, snapshot.ProjectID, snapshot.Version, snapshot.GitCommitHash, snapshot.GitTag,
+		snapshot.Metadata, snapshot.DatabaseSnapshot, snapshot.Status, snapshot.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	// Get the created snapshot to populate all fields including timestamps
+	created, err := r.GetByUUID(uuidStr)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve created snapshot: %w", err)
+	}
+
+	*snapshot = *created
+	return nil
+}
+
+// GetByUUID retrieves snapshot by UUID (external identifier)
+func (r *SnapshotRepository) GetByUUID(uuid string) (*models.GenerationSnapshot, error) {
+	var snapshot models.GenerationSnapshot
+	query := `
+		SELECT id, uuid, project_id, version, git_commit_hash, git_tag, metadata, database_snapshot, status,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM generation_snapshots
+		WHERE uuid = ? AND deleted_at IS NULL
+	`
+
+	err := r.db.Get(&snapshot, query, uuid)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("snapshot")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// GetByProjectAndVersion retrieves a project's snapshot by version
+func (r *SnapshotRepository) GetByProjectAndVersion(projectID int64, version string) (*models.GenerationSnapshot, error) {
+	var snapshot models.GenerationSnapshot
+	query := `
+		SELECT id, uuid, project_id, version, git_commit_hash, git_tag, metadata, database_snapshot, status,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM generation_snapshots
+		WHERE project_id = ? AND version = ? AND deleted_at IS NULL
+	`
+
+	err := r.db.Get(&snapshot, query, projectID, version)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("snapshot")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// GetByProjectID retrieves a page of the snapshots of a project, newest first, with their total
+func (r *SnapshotRepository) GetByProjectID(projectID int64, limit, offset int) ([]models.GenerationSnapshot, int64, error) {
+	snapshots := []models.GenerationSnapshot{}
+	query := `
+		SELECT id, uuid, project_id, version, git_commit_hash, git_tag, metadata, database_snapshot, status,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM generation_snapshots
+		WHERE project_id = ? AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.Select(&snapshots, query, projectID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get snapshots: %w", err)
+	}
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM generation_snapshots WHERE project_id = ? AND deleted_at IS NULL`
+	err = r.db.Get(&total, countQuery, projectID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count snapshots: %w", err)
+	}
+
+	return snapshots, total, nil
+}
+
+// DeleteByProjectID soft deletes the live snapshots of a project as part of the deletion of that project
+func (r *SnapshotRepository) DeleteByProjectID(projectID int64, deletedBy string, deletionID string) error {
+	query := `
+		UPDATE generation_snapshots SET deleted_by = ?, deleted_at = NOW(), deletion_id = ?
+		WHERE project_id = ? AND deleted_at IS NULL
+	`
+	if _, err := r.db.Exec(query, deletedBy, deletionID, projectID); err != nil {
+		return fmt.Errorf("failed to delete snapshots: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreByDeletionID brings back the snapshots deleted with the resource of a deletion ID
+func (r *SnapshotRepository) RestoreByDeletionID(deletionID string, restoredBy string) error {
+	query := `
+		UPDATE generation_snapshots
+		SET deleted_by = NULL, deleted_at = NULL, deletion_id = NULL, updated_by = ?, updated_at = NOW()
+		WHERE deletion_id = ? AND deleted_at IS NOT NULL
+	`
+	if _, err := r.db.Exec(query, restoredBy, deletionID); err != nil {
+		return fmt.Errorf("failed to restore snapshots: %w", err)
+	}
+
+	return nil
+}
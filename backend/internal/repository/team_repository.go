@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+type TeamRepository struct {
+	db DBTX
+}
+
+func NewTeamRepository(db *sqlx.DB) *TeamRepository {
+	return &TeamRepository{db: db}
+}
+
+const teamMemberColumns = `
+		SELECT m.id, m.uuid, m.team_id, t.uuid AS team_uuid, m.user_id, u.username, m.role,
+		       m.created_by, m.updated_by, m.deleted_by, m.created_at, m.updated_at, m.deleted_at
+		FROM team_members m
+		JOIN teams t ON t.id = m.team_id
+		JOIN users u ON u.id = m.user_id`
+
+func (r *TeamRepository) Create(team *models.Team) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	id := uuidToInt64(uuidV7)
+	uuidStr := uuidV7.String()
+
+	query := `
+		INSERT INTO teams (id, uuid, name, slug, description, settings, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+	`
+	_, err := r.db.Exec(query, id, uuidStr, team.Name, team.Slug, team.Description, team.Settings, team.CreatedBy)
+	if err != nil {
+		if conflict := duplicateAs(err, "team slug already exists"); conflict != nil {
+			return conflict
+		}
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+
+	createdTeam, err := r.GetByUUID(uuidStr)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve created team: %w", err)
+	}
+
+	*team = *createdTeam
+	return nil
+}
+
+// GetByUUID retrieves team by UUID (external identifier)
+func (r *TeamRepository) GetByUUID(uuid string) (*models.Team, error) {
+	var team models.Team
+	query := `
+		SELECT id, uuid, name, slug, description, settings,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM teams
+		WHERE uuid = ? AND deleted_at IS NULL
+	`
+
+	err := r.db.Get(&team, query, uuid)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("team")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	return &team, nil
+}
+
+// GetByID retrieves team by internal ID (for internal use/FK joins)
+func (r *TeamRepository) GetByID(id int64) (*models.Team, error) {
+	var team models.Team
+	query := `
+		SELECT id, uuid, name, slug, description, settings,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at
+		FROM teams
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	err := r.db.Get(&team, query, id)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("team")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	return &team, nil
+}
+
+// SlugExists reports whether a team, including a deleted one, already uses a slug
+func (r *TeamRepository) SlugExists(slug string) (bool, error) {
+	var count int
+	err := r.db.Get(&count, `SELECT COUNT(*) FROM teams WHERE slug = ?`, slug)
+	if err != nil {
+		return false, fmt.Errorf("failed to check team slug: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// GetByUsername lists the teams a user is a member of
+func (r *TeamRepository) GetByUsername(username string, limit, offset int) ([]models.Team, int64, error) {
+	where := `
+		WHERE t.deleted_at IS NULL AND m.deleted_at IS NULL AND u.username = ?`
+
+	var teams []models.Team
+	query := `
+		SELECT t.id, t.uuid, t.name, t.slug, t.description, t.settings,
+		       t.created_by, t.updated_by, t.deleted_by, t.created_at, t.updated_at, t.deleted_at
+		FROM teams t
+		JOIN team_members m ON m.team_id = t.id
+		JOIN users u ON u.id = m.user_id` + where + `
+		ORDER BY t.name ASC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.Select(&teams, query, username, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get teams: %w", err)
+	}
+
+	var total int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM teams t
+		JOIN team_members m ON m.team_id = t.id
+		JOIN users u ON u.id = m.user_id` + where
+	err = r.db.Get(&total, countQuery, username)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count teams: %w", err)
+	}
+
+	return teams, total, nil
+}
+
+func (r *TeamRepository) Update(team *models.Team) error {
+	query := `
+		UPDATE teams
+		SET name = ?, description = ?, settings = ?, updated_by = ?, updated_at = NOW()
+		WHERE uuid = ? AND deleted_at IS NULL
+	`
+	_, err := r.db.Exec(query, team.Name, team.Description, team.Settings, team.UpdatedBy, team.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to update team: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) DeleteByUUID(uuid string, deletedBy string) error {
+	// Soft delete
+	query := `UPDATE teams SET deleted_by = ?, deleted_at = NOW() WHERE uuid = ? AND deleted_at IS NULL`
+	_, err := r.db.Exec(query, deletedBy, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertMember adds a member or changes the role of an existing (or removed) one
+func (r *TeamRepository) UpsertMember(member *models.TeamMember) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	id := uuidToInt64(uuidV7)
+
+	query := `
+		INSERT INTO team_members (id, uuid, team_id, user_id, role, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())
+		ON DUPLICATE KEY UPDATE role = VALUES(role), updated_by = VALUES(created_by),
+		                        updated_at = NOW(), deleted_by = NULL, deleted_at = NULL
+	`
+	_, err := r.db.Exec(query, id, uuidV7.String(), member.TeamID, member.UserID, member.Role, member.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save team member: %w", err)
+	}
+
+	var saved models.TeamMember
+	err = r.db.Get(&saved, teamMemberColumns+` WHERE m.team_id = ? AND m.user_id = ?`, member.TeamID, member.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve saved team member: %w", err)
+	}
+
+	*member = saved
+	return nil
+}
+
+// GetMemberRole returns the role of a user in a team, or an empty string when the user is not a member
+func (r *TeamRepository) GetMemberRole(teamID int64, username string) (string, error) {
+	var role string
+	query := `
+		SELECT m.role
+		FROM team_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.team_id = ? AND u.username = ? AND m.deleted_at IS NULL
+	`
+
+	err := r.db.Get(&role, query, teamID, username)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get team role: %w", err)
+	}
+
+	return role, nil
+}
+
+// GetMembers lists the members of a team, owners first
+func (r *TeamRepository) GetMembers(teamID int64) ([]models.TeamMember, error) {
+	var members []models.TeamMember
+	query := teamMemberColumns + `
+		WHERE m.team_id = ? AND m.deleted_at IS NULL
+		ORDER BY FIELD(m.role, 'owner', 'member'), u.username ASC
+	`
+
+	err := r.db.Select(&members, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	return members, nil
+}
+
+func (r *TeamRepository) DeleteMember(teamID, userID int64, deletedBy string) error {
+	// Soft delete
+	query := `
+		UPDATE team_members SET deleted_by = ?, deleted_at = NOW()
+		WHERE team_id = ? AND user_id = ? AND deleted_at IS NULL
+	`
+	_, err := r.db.Exec(query, deletedBy, teamID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete team member: %w", err)
+	}
+
+	return nil
+}
+
+// GetStats counts the live projects of a team and what they contain
+func (r *TeamRepository) GetStats(teamID int64) (*models.TeamStats, error) {
+	stats := &models.TeamStats{ProjectsByStatus: map[string]int64{}}
+
+	var statuses []struct {
+		Status string `db:"status"`
+		Count  int64  `db:"count"`
+	}
+	query := `
+		SELECT status, COUNT(*) AS count FROM projects
+		WHERE team_id = ? AND deleted_at IS NULL
+		GROUP BY status
+	`
+	if err := r.db.Select(&statuses, query, teamID); err != nil {
+		return nil, fmt.Errorf("failed to count team projects: %w", err)
+	}
+	for _, status := range statuses {
+		stats.ProjectsByStatus[status.Status] = status.Count
+		stats.Projects += status.Count
+	}
+
+	counts := []struct {
+		target *int64
+		query  string
+	}{
+		{&stats.Entities, `SELECT COUNT(*) FROM entities e JOIN projects p ON p.id = e.project_id
+			WHERE p.team_id = ? AND p.deleted_at IS NULL AND e.deleted_at IS NULL`},
+		{&stats.Endpoints, `SELECT COUNT(*) FROM endpoints e JOIN projects p ON p.id = e.project_id
+			WHERE p.team_id = ? AND p.deleted_at IS NULL AND e.deleted_at IS NULL`},
+		{&stats.Deployments, `SELECT COUNT(*) FROM deployments d JOIN projects p ON p.id = d.project_id
+			WHERE p.team_id = ? AND p.deleted_at IS NULL AND d.deleted_at IS NULL`},
+		{&stats.Members, `SELECT COUNT(*) FROM team_members WHERE team_id = ? AND deleted_at IS NULL`},
+	}
+	for _, count := range counts {
+		if err := r.db.Get(count.target, count.query, teamID); err != nil {
+			return nil, fmt.Errorf("failed to get team stats: %w", err)
+		}
+	}
+
+	return stats, nil
+}
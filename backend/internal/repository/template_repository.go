@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+type TemplateRepository struct {
+	db DBTX
+}
+
+func NewTemplateRepository(db *sqlx.DB) *TemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+const templateColumns = `
+		       id, uuid, name, type, content, description, is_default, team_id, version,
+		       (SELECT t.uuid FROM teams t WHERE t.id = templates.team_id) AS team_uuid,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at`
+
+func (r *TemplateRepository) Create(template *models.Template) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	id := uuidToInt64(uuidV7)
+	uuidStr := uuidV7.String()
+
+	query := `
+		INSERT INTO templates (id, uuid, name, type, content, description, is_default, team_id, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+	`
+	_, err := r.db.Exec(query, id, uuidStr, template.Name, template.Type, template.Content, template.Description,
+		template.IsDefault, template.TeamID, template.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+
+	createdTemplate, err := r.GetByUUID(uuidStr)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve created template: %w", err)
+	}
+
+	*template = *createdTemplate
+	return nil
+}
+
+// GetByUUID retrieves template by UUID (external identifier)
+func (r *TemplateRepository) GetByUUID(uuid string) (*models.Template, error) {
+	var template models.Template
+	query := `
+		SELECT` + templateColumns + `
+		FROM templates
+		WHERE uuid = ? AND deleted_at IS NULL
+	`
+
+	err := r.db.Get(&template, query, uuid)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("template")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	return &template, nil
+}
+
+// GetByUUIDs retrieves the live templates among a set of UUIDs
+func (r *TemplateRepository) GetByUUIDs(uuids []string) ([]models.Template, error) {
+	query, args, err := sqlx.In(`
+		SELECT`+templateColumns+`
+		FROM templates
+		WHERE uuid IN (?) AND deleted_at IS NULL
+	`, uuids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build template query: %w", err)
+	}
+
+	var templates []models.Template
+	err = r.db.Select(&templates, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// GetAll lists templates, optionally of a single type
+func (r *TemplateRepository) GetAll(templateType string, limit, offset int) ([]models.Template, int64, error) {
+	where := " WHERE deleted_at IS NULL"
+	args := []interface{}{}
+	if templateType != "" {
+		where += " AND type = ?"
+		args = append(args, templateType)
+	}
+
+	var templates []models.Template
+	query := `
+		SELECT` + templateColumns + `
+		FROM templates` + where + `
+		ORDER BY type ASC, name ASC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.Select(&templates, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get templates: %w", err)
+	}
+
+	var total int64
+	err = r.db.Get(&total, `SELECT COUNT(*) FROM templates`+where, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count templates: %w", err)
+	}
+
+	return templates, total, nil
+}
+
+// Update saves a template read at template.Version and increments the version, failing with
+// ErrStaleVersion when another update came first
+func (r *TemplateRepository) Update(template *models.Template) error {
+	query := `
+		UPDATE templates
+		SET name = ?, type = ?, content = ?, description = ?, is_default = ?, updated_by = ?, updated_at = NOW(), version = version + 1
+		WHERE uuid = ? AND version = ? AND deleted_at IS NULL
+	`
+	result, err := r.db.Exec(query, template.Name, template.Type, template.Content, template.Description,
+		template.IsDefault, template.UpdatedBy, template.UUID, template.Version)
+	if err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+	if err := checkVersioned(result); err != nil {
+		return err
+	}
+
+	template.Version++
+	return nil
+}
+
+// DeleteByUUID soft deletes a template still at version, failing with ErrStaleVersion otherwise
+func (r *TemplateRepository) DeleteByUUID(uuid string, deletedBy string, version int64) error {
+	query := `UPDATE templates SET deleted_by = ?, deleted_at = NOW() WHERE uuid = ? AND version = ? AND deleted_at IS NULL`
+	result, err := r.db.Exec(query, deletedBy, uuid, version)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	return checkVersioned(result)
+}
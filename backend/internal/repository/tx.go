@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DBTX is the part of sqlx shared by *sqlx.DB and *sqlx.Tx, so repositories run the same
+// queries inside and outside a transaction
+type DBTX interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+}
+
+// UnitOfWork runs groups of repository calls atomically
+type UnitOfWork struct {
+	db *sqlx.DB
+}
+
+func NewUnitOfWork(db *sqlx.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// Tx hands out repositories bound to one transaction
+type Tx struct {
+	tx *sqlx.Tx
+}
+
+func (t *Tx) Projects() *ProjectRepository {
+	return &ProjectRepository{db: t.tx}
+}
+
+func (t *Tx) Entities() *EntityRepository {
+	return &EntityRepository{db: t.tx}
+}
+
+func (t *Tx) Endpoints() *EndpointRepository {
+	return &EndpointRepository{db: t.tx}
+}
+
+func (t *Tx) GitRepositories() *GitRepositoryRepository {
+	return &GitRepositoryRepository{db: t.tx}
+}
+
+func (t *Tx) Snapshots() *SnapshotRepository {
+	return &SnapshotRepository{db: t.tx}
+}
+
+func (t *Tx) Templates() *TemplateRepository {
+	return &TemplateRepository{db: t.tx}
+}
+
+func (t *Tx) Audit() *AuditRepository {
+	return &AuditRepository{db: t.tx}
+}
+
+// Do runs fn in a transaction. The transaction is committed when fn returns nil and rolled back
+// when it returns an error or panics.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(tx *Tx) error) error {
+	tx, err := u.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(&Tx{tx: tx}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+type UserRepository struct {
+	db DBTX
+}
+
+func NewUserRepository(db *sqlx.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+const userColumns = `id, uuid, username, email, password_hash, full_name, is_active, last_login_at,
+		       created_by, updated_by, deleted_by, created_at, updated_at, deleted_at`
+
+func (r *UserRepository) Create(user *models.User) error {
+	// Generate UUID v7
+	uuidV7 := uuid.Must(uuid.NewV7())
+	id := uuidToInt64(uuidV7)
+	uuidStr := uuidV7.String()
+
+	query := `
+		INSERT INTO users (id, uuid, username, email, password_hash, full_name, is_active, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+	`
+	_, err := r.db.Exec(query, id, uuidStr, user.Username, user.Email, user.PasswordHash, user.FullName,
+		user.IsActive, user.CreatedBy)
+	if err != nil {
+		if conflict := duplicateAs(err, "username or email already exists"); conflict != nil {
+			return conflict
+		}
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	createdUser, err := r.GetByUUID(uuidStr)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve created user: %w", err)
+	}
+
+	*user = *createdUser
+	return nil
+}
+
+// GetByUUID retrieves user by UUID (external identifier)
+func (r *UserRepository) GetByUUID(uuid string) (*models.User, error) {
+	return r.get(`uuid = ?`, uuid)
+}
+
+// GetByID retrieves user by internal ID
+func (r *UserRepository) GetByID(id int64) (*models.User, error) {
+	return r.get(`id = ?`, id)
+}
+
+// GetByUsername retrieves user by username
+func (r *UserRepository) GetByUsername(username string) (*models.User, error) {
+	return r.get(`username = ?`, username)
+}
+
+// GetByLogin retrieves user by username or email
+func (r *UserRepository) GetByLogin(login string) (*models.User, error) {
+	return r.get(`(username = ? OR email = ?)`, login, login)
+}
+
+// ExistsByUsernameOrEmail checks whether the username or email is taken, including deleted accounts
+func (r *UserRepository) ExistsByUsernameOrEmail(username, email string) (bool, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM users WHERE username = ? OR email = ?`
+	err := r.db.Get(&count, query, username, email)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user: %w", err)
+	}
+	return count > 0, nil
+}
+
+// UpdateLastLogin records a successful login
+func (r *UserRepository) UpdateLastLogin(id int64) error {
+	query := `UPDATE users SET last_login_at = NOW() WHERE id = ?`
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to update last login: %w", err)
+	}
+	return nil
+}
+
+func (r *UserRepository) get(condition string, args ...interface{}) (*models.User, error) {
+	var user models.User
+	query := `SELECT ` + userColumns + ` FROM users WHERE ` + condition + ` AND deleted_at IS NULL`
+
+	err := r.db.Get(&user, query, args...)
+	if err == sql.ErrNoRows {
+		return nil, apperror.NotFound("user")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &user, nil
+}
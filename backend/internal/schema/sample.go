@@ -0,0 +1,207 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	sampleItems    = 2 // Items of sample arrays unless minItems or maxItems say otherwise
+	maxSampleDepth = 8 // Nesting where samples of recursive schemas stop
+)
+
+// sampleNamespace derives the UUIDs of samples, so the same schema always yields the same sample
+var sampleNamespace = uuid.MustParse("6f1c1b7e-5d4a-4c39-9a2e-4b8f0c7d2e91")
+
+// Sample returns a realistic value matching a schema, e.g. to mock a response. Strings take their
+// format or the name of their property into account; the same schema always yields the same value.
+func (s *Schema) Sample() interface{} {
+	return s.sample("", 0)
+}
+
+func (s *Schema) sample(path string, depth int) interface{} {
+	s = s.Resolve()
+	if s == nil {
+		return map[string]interface{}{}
+	}
+	if s.Example != nil {
+		return s.Example
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+
+	switch {
+	case s.Type == TypeObject, s.Type == "" && len(s.Properties) > 0:
+		if depth >= maxSampleDepth {
+			return nil
+		}
+		object := make(map[string]interface{}, len(s.Properties))
+		for _, name := range s.PropertyNames() {
+			object[name] = s.Properties[name].sample(join(path, name), depth+1)
+		}
+		return object
+
+	case s.Type == TypeArray, s.Type == "" && s.Items != nil:
+		count := sampleItems
+		if s.MaxItems != nil && count > *s.MaxItems {
+			count = *s.MaxItems
+		}
+		if s.MinItems != nil && count < *s.MinItems {
+			count = *s.MinItems
+		}
+		if depth >= maxSampleDepth {
+			count = 0
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			items[i] = s.Items.sample(join(path, fmt.Sprint(i)), depth+1)
+		}
+		return items
+
+	case s.Type == TypeString:
+		return s.sampleString(path)
+	case s.Type == TypeInteger:
+		return s.clamp(sampleNumber(path, true), true)
+	case s.Type == TypeNumber:
+		return s.clamp(sampleNumber(path, false), false)
+	case s.Type == TypeBoolean:
+		return true
+	case s.Type == TypeNull:
+		return nil
+	}
+
+	return map[string]interface{}{}
+}
+
+// sampleString picks a string by format, else by the name of its property, within the length limits
+func (s *Schema) sampleString(path string) string {
+	var value string
+	switch s.Format {
+	case "date-time":
+		value = "2024-01-15T09:30:00Z"
+	case "date":
+		value = "2024-01-15"
+	case "uuid":
+		value = uuid.NewSHA1(sampleNamespace, []byte(path)).String()
+	case "email":
+		value = "jane.doe@example.com"
+	case "uri":
+		value = "https://example.com"
+	default:
+		value = sampleText(path)
+	}
+
+	if s.MaxLength != nil && utf8.RuneCountInString(value) > *s.MaxLength {
+		value = strings.TrimRight(string([]rune(value)[:*s.MaxLength]), " ")
+	}
+	if s.MinLength != nil {
+		if missing := *s.MinLength - utf8.RuneCountInString(value); missing > 0 {
+			value += strings.Repeat("x", missing)
+		}
+	}
+	return value
+}
+
+// sampleText picks a string for a property by its name
+func sampleText(path string) string {
+	name := strings.ToLower(lastSegment(path))
+	switch {
+	case name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "uuid"):
+		return uuid.NewSHA1(sampleNamespace, []byte(path)).String()
+	case strings.Contains(name, "email"):
+		return "jane.doe@example.com"
+	case strings.Contains(name, "url") || strings.Contains(name, "link") || strings.Contains(name, "website"):
+		return "https://example.com"
+	case strings.Contains(name, "phone"):
+		return "+1-555-0100"
+	case name == "first_name" || name == "firstname":
+		return "Jane"
+	case name == "last_name" || name == "lastname":
+		return "Doe"
+	case name == "username":
+		return "jane.doe"
+	case name == "name" || name == "fullname" || strings.HasSuffix(name, "_name"):
+		return "Jane Doe"
+	case strings.Contains(name, "title"):
+		return "Example title"
+	case strings.Contains(name, "description") || strings.Contains(name, "note") || strings.Contains(name, "comment"):
+		return "Lorem ipsum dolor sit amet"
+	case strings.Contains(name, "status"):
+		return "active"
+	case strings.Contains(name, "city"):
+		return "Springfield"
+	case strings.Contains(name, "country"):
+		return "US"
+	case strings.Contains(name, "currency"):
+		return "USD"
+	case name == "":
+		return "example"
+	}
+	return "Example " + strings.ReplaceAll(name, "_", " ")
+}
+
+// sampleNumber picks a number for a property by its name; ids count up with the array index
+func sampleNumber(path string, integer bool) float64 {
+	name := strings.ToLower(lastSegment(path))
+	switch {
+	case name == "id" || strings.HasSuffix(name, "_id"):
+		segments := strings.Split(path, ".")
+		if len(segments) > 1 {
+			if index, err := strconv.Atoi(segments[len(segments)-2]); err == nil {
+				return float64(index + 1)
+			}
+		}
+		return 1
+	case name == "age" || strings.HasSuffix(name, "_age"):
+		return 30
+	case strings.Contains(name, "year"):
+		return 2024
+	case strings.Contains(name, "quantity") || strings.Contains(name, "count"):
+		return 2
+	case strings.Contains(name, "price") || strings.Contains(name, "amount") || strings.Contains(name, "total"):
+		if integer {
+			return 20
+		}
+		return 19.99
+	case strings.HasSuffix(name, "rate") || strings.HasSuffix(name, "ratio"):
+		return 0.5
+	}
+	if integer {
+		return 1
+	}
+	return 1.5
+}
+
+// clamp moves a number into the minimum and maximum of a schema, rounding it when integer
+func (s *Schema) clamp(value float64, integer bool) float64 {
+	if s.Minimum != nil && value < *s.Minimum {
+		value = *s.Minimum
+	}
+	if s.Maximum != nil && value > *s.Maximum {
+		value = *s.Maximum
+	}
+	if integer {
+		value = math.Ceil(value)
+		if s.Maximum != nil && value > *s.Maximum {
+			value = math.Floor(*s.Maximum)
+		}
+	}
+	return value
+}
+
+// lastSegment returns the property name a path ends with, skipping array indexes
+func lastSegment(path string) string {
+	segments := strings.Split(path, ".")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if _, err := strconv.Atoi(segments[i]); err != nil {
+			return segments[i]
+		}
+	}
+	return ""
+}
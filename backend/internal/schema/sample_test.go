@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSchema_Sample(t *testing.T) {
+	entities := entityFixture(t)
+	s, err := Parse(json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"status": {"type": "string", "enum": ["pending", "paid"]},
+			"code": {"type": "string", "minLength": 12, "maxLength": 14},
+			"total": {"type": "number", "minimum": 0},
+			"page": {"type": "integer", "minimum": 1, "maximum": 0.5e1},
+			"created_at": {"type": "string", "format": "date-time"},
+			"buyers": {"type": "array", "maxItems": 5, "items": {"$ref": "#/entities/User"}},
+			"tags": {"type": "array", "minItems": 3, "items": {"type": "string"}},
+			"tree": {"$ref": "#/$defs/node"},
+			"meta": {"example": {"source": "web"}}
+		},
+		"$defs": {
+			"node": {"type": "object", "required": ["children"], "properties": {"children": {"type": "array", "minItems": 1, "items": {"$ref": "#/$defs/node"}}}}
+		}
+	}`), entities)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	sample := s.Sample()
+
+	// A recursive definition is cut short, so only its start has to match
+	object := sample.(map[string]interface{})
+	if object["tree"] == nil {
+		t.Error("Sample() has no tree")
+	}
+	delete(object, "tree")
+	delete(s.Properties, "tree")
+	if violations := s.Validate(object); len(violations) > 0 {
+		t.Errorf("Sample() = %v does not match its schema: %v", object, violations[0])
+	}
+
+	if object["status"] != "pending" {
+		t.Errorf("status = %v, want the first enum value", object["status"])
+	}
+	if meta := object["meta"]; !reflect.DeepEqual(meta, map[string]interface{}{"source": "web"}) {
+		t.Errorf("meta = %v, want the example", meta)
+	}
+	buyers := object["buyers"].([]interface{})
+	if len(buyers) != 2 {
+		t.Fatalf("buyers = %v, want 2 items", buyers)
+	}
+	first, second := buyers[0].(map[string]interface{}), buyers[1].(map[string]interface{})
+	if first["email"] != "jane.doe@example.com" || first["id"] == second["id"] {
+		t.Errorf("buyers = %v, want an email and distinct ids", buyers)
+	}
+	if len(object["tags"].([]interface{})) != 3 {
+		t.Errorf("tags = %v, want minItems items", object["tags"])
+	}
+
+	if again := s.Sample(); !reflect.DeepEqual(again, s.Sample()) {
+		t.Error("Sample() is not deterministic")
+	}
+}
+
+func TestSchema_SampleWithoutSchema(t *testing.T) {
+	var s *Schema
+	if got := s.Sample(); !reflect.DeepEqual(got, map[string]interface{}{}) {
+		t.Errorf("Sample() = %v, want an empty object", got)
+	}
+}
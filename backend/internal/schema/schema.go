@@ -0,0 +1,348 @@
+// Package schema parses the JSON Schemas describing endpoint request and response bodies. It
+// supports the subset the generator can turn into Go types: typed objects, arrays and scalars with
+// their common constraints, enums, local $defs and references to the entities of the project.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// Types a schema can declare
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeNull    = "null"
+)
+
+// Prefixes of the references a schema can make
+const (
+	EntityRefPrefix = "#/entities/" // An entity of the project, e.g. #/entities/User
+	DefRefPrefix    = "#/$defs/"    // A definition in $defs of the root schema
+)
+
+// Schema is a JSON Schema. Keywords outside the supported subset are ignored.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Example              interface{}        `json:"example,omitempty"`
+
+	target  *Schema        // Schema a $ref resolves to
+	pattern *regexp.Regexp // Compiled Pattern
+}
+
+// Error is a problem with a schema at a path of keywords, e.g. properties.email.type
+type Error struct {
+	Path    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Parse decodes and checks a schema, resolving its references against the schemas of the project
+// entities by name. An absent or null schema returns nil.
+func Parse(raw json.RawMessage, entities map[string]*Schema) (*Schema, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+
+	var s Schema
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, &Error{Message: "must be a JSON Schema object: " + strings.TrimPrefix(err.Error(), "json: ")}
+	}
+
+	c := &checker{root: &s, entities: entities}
+	for _, name := range sortedKeys(s.Defs) {
+		if err := c.check(s.Defs[name], join("$defs", name)); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.check(&s, ""); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Resolve follows the references of a schema to the schema describing values
+func (s *Schema) Resolve() *Schema {
+	for s != nil && s.target != nil {
+		s = s.target
+	}
+	return s
+}
+
+// EntityRef returns the entity a schema refers to, or "" when it is no entity reference
+func (s *Schema) EntityRef() string {
+	if s == nil || !strings.HasPrefix(s.Ref, EntityRefPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(s.Ref, EntityRefPrefix)
+}
+
+// DefRef returns the $defs entry a schema refers to, or "" when it is no local reference
+func (s *Schema) DefRef() string {
+	if s == nil || !strings.HasPrefix(s.Ref, DefRefPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(s.Ref, DefRefPrefix)
+}
+
+// IsEmpty reports whether a schema accepts any value, like {}
+func (s *Schema) IsEmpty() bool {
+	return s == nil || (s.Ref == "" && s.Type == "" && len(s.Properties) == 0 && s.Items == nil && len(s.Enum) == 0)
+}
+
+// IsRequired reports whether an object schema requires a property
+func (s *Schema) IsRequired(property string) bool {
+	for _, name := range s.Required {
+		if name == property {
+			return true
+		}
+	}
+	return false
+}
+
+// PropertyNames returns the names of the properties of an object schema in a stable order
+func (s *Schema) PropertyNames() []string {
+	return sortedKeys(s.Properties)
+}
+
+// checker checks the keywords of a schema and resolves its references
+type checker struct {
+	root     *Schema
+	entities map[string]*Schema
+}
+
+func (c *checker) check(s *Schema, path string) error {
+	if s == nil {
+		return &Error{Path: path, Message: "must be a schema object"}
+	}
+
+	if s.Ref != "" {
+		switch {
+		case strings.HasPrefix(s.Ref, EntityRefPrefix):
+			s.target = c.entities[strings.TrimPrefix(s.Ref, EntityRefPrefix)]
+		case strings.HasPrefix(s.Ref, DefRefPrefix):
+			s.target = c.root.Defs[strings.TrimPrefix(s.Ref, DefRefPrefix)]
+		default:
+			return &Error{Path: join(path, "$ref"), Message: fmt.Sprintf("must start with %s or %s", EntityRefPrefix, DefRefPrefix)}
+		}
+		if s.target == nil {
+			return &Error{Path: join(path, "$ref"), Message: fmt.Sprintf("%s does not exist", s.Ref)}
+		}
+		return nil
+	}
+
+	switch s.Type {
+	case "", TypeObject, TypeArray, TypeString, TypeInteger, TypeNumber, TypeBoolean, TypeNull:
+	default:
+		return &Error{Path: join(path, "type"), Message: fmt.Sprintf("unknown type %q", s.Type)}
+	}
+
+	if len(s.Properties) > 0 || len(s.Required) > 0 {
+		if s.Type != "" && s.Type != TypeObject {
+			return &Error{Path: join(path, "properties"), Message: "only applies to type object"}
+		}
+	}
+	for _, name := range s.PropertyNames() {
+		if err := c.check(s.Properties[name], join(path, "properties", name)); err != nil {
+			return err
+		}
+	}
+	for _, name := range s.Required {
+		if _, ok := s.Properties[name]; !ok && len(s.Properties) > 0 {
+			return &Error{Path: join(path, "required"), Message: fmt.Sprintf("%q is not a property", name)}
+		}
+	}
+
+	if s.Items != nil {
+		if s.Type != "" && s.Type != TypeArray {
+			return &Error{Path: join(path, "items"), Message: "only applies to type array"}
+		}
+		if err := c.check(s.Items, join(path, "items")); err != nil {
+			return err
+		}
+	}
+
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return &Error{Path: join(path, "pattern"), Message: "must be a valid regular expression"}
+		}
+		s.pattern = pattern
+	}
+
+	if err := checkRange(path, "Length", s.MinLength, s.MaxLength); err != nil {
+		return err
+	}
+	if err := checkRange(path, "Items", s.MinItems, s.MaxItems); err != nil {
+		return err
+	}
+	if s.Minimum != nil && s.Maximum != nil && *s.Minimum > *s.Maximum {
+		return &Error{Path: join(path, "minimum"), Message: "must not exceed maximum"}
+	}
+
+	if s.Enum != nil && len(s.Enum) == 0 {
+		return &Error{Path: join(path, "enum"), Message: "must list at least one value"}
+	}
+	if s.Type != "" {
+		for i, value := range s.Enum {
+			if !hasType(value, s.Type) {
+				return &Error{Path: join(path, "enum", fmt.Sprint(i)), Message: fmt.Sprintf("must be of type %s", s.Type)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkRange checks the min and max of a length or item count keyword pair
+func checkRange(path, keyword string, min, max *int) error {
+	if min != nil && *min < 0 {
+		return &Error{Path: join(path, "min"+keyword), Message: "must not be negative"}
+	}
+	if max != nil && *max < 0 {
+		return &Error{Path: join(path, "max"+keyword), Message: "must not be negative"}
+	}
+	if min != nil && max != nil && *min > *max {
+		return &Error{Path: join(path, "min"+keyword), Message: "must not exceed max" + keyword}
+	}
+	return nil
+}
+
+// hasType reports whether a decoded JSON value is of a schema type
+func hasType(value interface{}, typ string) bool {
+	switch v := value.(type) {
+	case nil:
+		return typ == TypeNull
+	case bool:
+		return typ == TypeBoolean
+	case string:
+		return typ == TypeString
+	case float64:
+		return typ == TypeNumber || (typ == TypeInteger && v == float64(int64(v)))
+	case []interface{}:
+		return typ == TypeArray
+	case map[string]interface{}:
+		return typ == TypeObject
+	}
+	return false
+}
+
+// EntitySchemas returns the schemas of the entities of a project by name, the targets of
+// #/entities/<name> references
+func EntitySchemas(entities []models.Entity) (map[string]*Schema, error) {
+	schemas := make(map[string]*Schema, len(entities))
+	for i := range entities {
+		s, err := EntitySchema(&entities[i])
+		if err != nil {
+			return nil, err
+		}
+		schemas[entities[i].Name] = s
+	}
+	return schemas, nil
+}
+
+// EntitySchema describes the JSON form of an entity: its fields by name, plus its id
+func EntitySchema(entity *models.Entity) (*Schema, error) {
+	var fields []models.EntityField
+	if len(entity.Fields) > 0 {
+		if err := json.Unmarshal(entity.Fields, &fields); err != nil {
+			return nil, fmt.Errorf("failed to parse fields of entity %s: %w", entity.Name, err)
+		}
+	}
+
+	s := &Schema{
+		Type:        TypeObject,
+		Description: entity.Description.String,
+		Properties:  map[string]*Schema{"id": {Type: TypeString, Format: "uuid"}},
+	}
+	for _, field := range fields {
+		property := fieldSchema(field)
+		s.Properties[field.Name] = property
+		if field.Required {
+			s.Required = append(s.Required, field.Name)
+		}
+	}
+
+	return s, nil
+}
+
+// fieldSchema maps the type of an entity field to a schema
+func fieldSchema(field models.EntityField) *Schema {
+	s := &Schema{Description: field.Description}
+	switch strings.ToLower(field.Type) {
+	case "int", "integer", "bigint":
+		s.Type = TypeInteger
+	case "float", "decimal":
+		s.Type = TypeNumber
+	case "bool", "boolean":
+		s.Type = TypeBoolean
+	case "date":
+		s.Type, s.Format = TypeString, "date"
+	case "datetime", "timestamp":
+		s.Type, s.Format = TypeString, "date-time"
+	case "uuid":
+		s.Type, s.Format = TypeString, "uuid"
+	case "json":
+	default:
+		s.Type = TypeString
+		if field.Length > 0 {
+			length := field.Length
+			s.MaxLength = &length
+		}
+	}
+	return s
+}
+
+// join builds the keyword path of a nested schema
+func join(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, ".")
+}
+
+func sortedKeys(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,110 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+func entityFixture(t *testing.T) map[string]*Schema {
+	t.Helper()
+	entities, err := EntitySchemas([]models.Entity{{
+		Name:   "User",
+		Fields: json.RawMessage(`[{"name":"email","type":"string","required":true,"length":120},{"name":"age","type":"int"},{"name":"born_at","type":"datetime"}]`),
+	}})
+	if err != nil {
+		t.Fatalf("EntitySchemas() error = %v", err)
+	}
+	return entities
+}
+
+func TestParse(t *testing.T) {
+	entities := entityFixture(t)
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"empty", `{}`},
+		{"object", `{"type":"object","required":["name"],"properties":{"name":{"type":"string","minLength":1}}}`},
+		{"array of entities", `{"type":"array","items":{"$ref":"#/entities/User"}}`},
+		{"local definition", `{"$defs":{"tag":{"type":"string"}},"type":"array","items":{"$ref":"#/$defs/tag"}}`},
+		{"recursive definition", `{"$defs":{"node":{"type":"object","properties":{"children":{"type":"array","items":{"$ref":"#/$defs/node"}}}}},"$ref":"#/$defs/node"}`},
+		{"enum", `{"type":"integer","enum":[1,2,3]}`},
+		{"unknown keywords", `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"Anything"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(json.RawMessage(tt.raw), entities); err != nil {
+				t.Errorf("Parse(%s) error = %v", tt.raw, err)
+			}
+		})
+	}
+}
+
+func TestParseAbsent(t *testing.T) {
+	for _, raw := range []string{"", "null", "  "} {
+		s, err := Parse(json.RawMessage(raw), nil)
+		if err != nil || s != nil {
+			t.Errorf("Parse(%q) = %v, %v, want nil, nil", raw, s, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	entities := entityFixture(t)
+
+	tests := []struct {
+		name string
+		raw  string
+		path string
+	}{
+		{"not an object", `[1, 2]`, ""},
+		{"unknown type", `{"type":"text"}`, "type"},
+		{"unknown entity", `{"$ref":"#/entities/Order"}`, "$ref"},
+		{"unknown definition", `{"type":"array","items":{"$ref":"#/$defs/line"}}`, "items.$ref"},
+		{"external reference", `{"$ref":"https://example.com/user.json"}`, "$ref"},
+		{"required without property", `{"type":"object","required":["name"],"properties":{"email":{"type":"string"}}}`, "required"},
+		{"properties of a string", `{"type":"string","properties":{"a":{}}}`, "properties"},
+		{"nested error", `{"type":"object","properties":{"tags":{"type":"array","items":{"type":"strin"}}}}`, "properties.tags.items.type"},
+		{"enum of another type", `{"type":"string","enum":["a", 1]}`, "enum.1"},
+		{"empty enum", `{"enum":[]}`, "enum"},
+		{"bad pattern", `{"type":"string","pattern":"("}`, "pattern"},
+		{"inverted length", `{"type":"string","minLength":5,"maxLength":2}`, "minLength"},
+		{"inverted range", `{"type":"number","minimum":5,"maximum":2}`, "minimum"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(json.RawMessage(tt.raw), entities)
+			var schemaErr *Error
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("Parse(%s) error = %v, want *Error", tt.raw, err)
+			}
+			if schemaErr.Path != tt.path {
+				t.Errorf("Parse(%s) error path = %q, want %q (%v)", tt.raw, schemaErr.Path, tt.path, err)
+			}
+		})
+	}
+}
+
+func TestEntitySchema(t *testing.T) {
+	user := entityFixture(t)["User"]
+
+	if user.Type != TypeObject || !user.IsRequired("email") || user.IsRequired("age") {
+		t.Fatalf("EntitySchema() = %+v, want an object requiring email", user)
+	}
+	if got := user.Properties["email"]; got.Type != TypeString || got.MaxLength == nil || *got.MaxLength != 120 {
+		t.Errorf("email = %+v, want a string of at most 120", got)
+	}
+	if got := user.Properties["age"]; got.Type != TypeInteger {
+		t.Errorf("age type = %q, want integer", got.Type)
+	}
+	if got := user.Properties["born_at"]; got.Type != TypeString || got.Format != "date-time" {
+		t.Errorf("born_at = %+v, want a date-time string", got)
+	}
+	if _, ok := user.Properties["id"]; !ok {
+		t.Error("EntitySchema() has no id property")
+	}
+}
@@ -0,0 +1,137 @@
+package schema
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Validate checks a decoded JSON value against a parsed schema and returns every violation, with
+// paths into the value such as items.0.sku. A nil schema accepts any value.
+func (s *Schema) Validate(value interface{}) []*Error {
+	var violations []*Error
+	s.validate(value, "", &violations)
+	return violations
+}
+
+func (s *Schema) validate(value interface{}, path string, violations *[]*Error) {
+	s = s.Resolve()
+	if s == nil {
+		return
+	}
+	report := func(format string, args ...interface{}) {
+		*violations = append(*violations, &Error{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Type != "" && !hasType(value, s.Type) {
+		report("must be of type %s", s.Type)
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(value, s.Enum) {
+		report("must be one of %v", s.Enum)
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			report("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			report("must be at most %d characters", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			report("must match %s", s.Pattern)
+		}
+		if !hasFormat(v, s.Format) {
+			report("must be a valid %s", s.Format)
+		}
+
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			report("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			report("must be at most %v", *s.Maximum)
+		}
+
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			report("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			report("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(item, join(path, fmt.Sprint(i)), violations)
+			}
+		}
+
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*violations = append(*violations, &Error{Path: join(path, name), Message: "is required"})
+			}
+		}
+		for _, name := range s.PropertyNames() {
+			if property, ok := v[name]; ok {
+				s.Properties[name].validate(property, join(path, name), violations)
+			}
+		}
+		if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+			for _, name := range sortedValueKeys(v) {
+				if _, ok := s.Properties[name]; !ok {
+					*violations = append(*violations, &Error{Path: join(path, name), Message: "is not allowed"})
+				}
+			}
+		}
+	}
+}
+
+// inEnum reports whether a decoded JSON value equals one of the values of an enum
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, candidate := range enum {
+		if reflect.DeepEqual(value, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFormat reports whether a string is of a format; formats without a check accept any string
+func hasFormat(value, format string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	case "date":
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	case "uuid":
+		return uuidPattern.MatchString(value)
+	case "email":
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	case "uri":
+		u, err := url.Parse(value)
+		return err == nil && u.Scheme != ""
+	}
+	return true
+}
+
+func sortedValueKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,105 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	entities := entityFixture(t)
+	s, err := Parse(json.RawMessage(`{
+		"type": "object",
+		"required": ["status", "items"],
+		"additionalProperties": false,
+		"properties": {
+			"status": {"type": "string", "enum": ["pending", "paid"]},
+			"note": {"type": "string", "maxLength": 5},
+			"buyer": {"$ref": "#/entities/User"},
+			"items": {"type": "array", "minItems": 1, "items": {"$ref": "#/$defs/line"}}
+		},
+		"$defs": {
+			"line": {
+				"type": "object",
+				"required": ["sku"],
+				"properties": {
+					"sku": {"type": "string", "pattern": "^[A-Z]+$"},
+					"quantity": {"type": "integer", "minimum": 1}
+				}
+			}
+		}
+	}`), entities)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		want  map[string]string
+	}{
+		{"valid", `{"status":"paid","items":[{"sku":"AB","quantity":2}],"buyer":{"email":"a@example.com","age":3}}`, nil},
+		{"not an object", `[]`, map[string]string{"": "must be of type object"}},
+		{"missing required", `{"items":[{}]}`, map[string]string{"status": "is required", "items.0.sku": "is required"}},
+		{"enum", `{"status":"lost","items":[{"sku":"A"}]}`, map[string]string{"status": "must be one of [pending paid]"}},
+		{"constraints", `{"status":"paid","note":"too long","items":[{"sku":"a1","quantity":0.5}]}`, map[string]string{
+			"note":             "must be at most 5 characters",
+			"items.0.sku":      "must match ^[A-Z]+$",
+			"items.0.quantity": "must be of type integer",
+		}},
+		{"empty array", `{"status":"paid","items":[]}`, map[string]string{"items": "must have at least 1 items"}},
+		{"entity reference", `{"status":"paid","items":[{"sku":"A"}],"buyer":{"age":"old"}}`, map[string]string{
+			"buyer.email": "is required",
+			"buyer.age":   "must be of type integer",
+		}},
+		{"additional property", `{"status":"paid","items":[{"sku":"A"}],"coupon":"X"}`, map[string]string{"coupon": "is not allowed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[string]string)
+			for _, violation := range s.Validate(value) {
+				got[violation.Path] = violation.Message
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("Validate(%s) = %v, want %v", tt.value, got, tt.want)
+			}
+			for path, message := range tt.want {
+				if got[path] != message {
+					t.Errorf("Validate(%s) at %q = %q, want %q", tt.value, path, got[path], message)
+				}
+			}
+		})
+	}
+}
+
+func TestSchema_ValidateFormats(t *testing.T) {
+	tests := []struct {
+		format string
+		valid  string
+		bad    string
+	}{
+		{"date-time", "2024-01-02T03:04:05Z", "2024-01-02"},
+		{"date", "2024-01-02", "02/01/2024"},
+		{"uuid", "0190b6c4-7a6e-7c3e-9d5a-2f1e8c4b7a10", "not-a-uuid"},
+		{"email", "dev@example.com", "dev"},
+		{"uri", "https://example.com/a", "example"},
+	}
+	for _, tt := range tests {
+		s := &Schema{Type: TypeString, Format: tt.format}
+		if violations := s.Validate(tt.valid); len(violations) != 0 {
+			t.Errorf("%s %q: %v, want valid", tt.format, tt.valid, violations[0])
+		}
+		if violations := s.Validate(tt.bad); len(violations) != 1 {
+			t.Errorf("%s %q: %d violations, want 1", tt.format, tt.bad, len(violations))
+		}
+	}
+
+	var nilSchema *Schema
+	if violations := nilSchema.Validate(map[string]interface{}{"a": 1.0}); len(violations) != 0 {
+		t.Errorf("nil schema rejected a value: %v", violations[0])
+	}
+}
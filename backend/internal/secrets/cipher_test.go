@@ -0,0 +1,62 @@
+package secrets
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+func TestCipher_RoundTrip(t *testing.T) {
+	c, err := NewCipher(testKey('k'))
+	if err != nil {
+		t.Fatalf("NewCipher() error = %v", err)
+	}
+
+	encrypted, err := c.Encrypt("s3cret-password")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if strings.Contains(encrypted, "s3cret-password") {
+		t.Fatalf("Encrypt() leaked plaintext: %q", encrypted)
+	}
+
+	again, _ := c.Encrypt("s3cret-password")
+	if again == encrypted {
+		t.Errorf("Encrypt() should use a fresh nonce per call")
+	}
+
+	decrypted, err := c.Decrypt(encrypted)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if decrypted != "s3cret-password" {
+		t.Errorf("Decrypt() = %q", decrypted)
+	}
+}
+
+func TestCipher_DecryptWithWrongKey(t *testing.T) {
+	c1, _ := NewCipher(testKey('a'))
+	c2, _ := NewCipher(testKey('b'))
+
+	encrypted, _ := c1.Encrypt("value")
+
+	for _, value := range []string{encrypted, "plaintext", "v1:not-base64!"} {
+		if _, err := c2.Decrypt(value); !errors.Is(err, ErrDecrypt) {
+			t.Errorf("Decrypt(%q) error = %v, want ErrDecrypt", value, err)
+		}
+	}
+}
+
+func TestNewCipher_InvalidKey(t *testing.T) {
+	if _, err := NewCipher(base64.StdEncoding.EncodeToString([]byte("short"))); err == nil {
+		t.Error("NewCipher() expected error for short key")
+	}
+	if _, err := NewCipher("%%%"); err == nil {
+		t.Error("NewCipher() expected error for invalid base64")
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yourusername/lambra/internal/audit"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+// AuditService records changes to project definitions and templates and serves the audit trail
+type AuditService struct {
+	repo  *repository.AuditRepository
+	guard *projectGuard
+}
+
+func NewAuditService(repo *repository.AuditRepository, projectRepo *repository.ProjectRepository, authz rbac.Authorizer) *AuditService {
+	return &AuditService{
+		repo:  repo,
+		guard: newProjectGuard(authz, projectRepo),
+	}
+}
+
+// Record appends an audit entry with the before/after diff of a resource. Before is nil for
+// creates and after is nil for deletes. The entry is written in the transaction of the audited
+// change, so a change is never committed without its entry; an error fails the operation.
+func (s *AuditService) Record(ctx context.Context, tx *repository.Tx, projectUUID, resourceType, resourceID, action string, before, after interface{}) error {
+	changes, err := audit.Diff(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to audit %s of %s %s: %w", action, resourceType, resourceID, err)
+	}
+	if action == models.AuditActionUpdate && len(changes) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to audit %s of %s %s: %w", action, resourceType, resourceID, err)
+	}
+
+	entry := &models.AuditLog{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Action:       action,
+		Actor:        auth.Actor(ctx),
+		Changes:      data,
+	}
+	if projectUUID != "" {
+		entry.ProjectUUID = sql.NullString{String: projectUUID, Valid: true}
+	}
+	if requestID := audit.RequestIDFromContext(ctx); requestID != "" {
+		entry.RequestID = sql.NullString{String: requestID, Valid: true}
+	}
+
+	return tx.Audit().Create(entry)
+}
+
+// GetProjectLogs lists the audit trail of a project and everything inside it
+func (s *AuditService) GetProjectLogs(ctx context.Context, projectUUID string, filter *models.AuditLogFilter, page, limit int) ([]models.AuditLog, int64, error) {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleViewer); err != nil {
+		return nil, 0, err
+	}
+
+	filter.ProjectUUID = projectUUID
+	offset := (page - 1) * limit
+	return s.repo.GetAll(filter, limit, offset)
+}
+
+// GetResourceLogs lists the audit trail of a single resource, which stays available after it is deleted
+func (s *AuditService) GetResourceLogs(ctx context.Context, resourceType, resourceID string, filter *models.AuditLogFilter, page, limit int) ([]models.AuditLog, int64, error) {
+	projectUUID, found, err := s.repo.GetProjectUUIDByResource(resourceType, resourceID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !found {
+		return []models.AuditLog{}, 0, nil
+	}
+
+	// Resources outside projects (templates) are visible to every user
+	if projectUUID != "" {
+		if err := s.guard.require(ctx, projectUUID, models.ProjectRoleViewer); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	filter.ResourceType = resourceType
+	filter.ResourceID = resourceID
+	offset := (page - 1) * limit
+	return s.repo.GetAll(filter, limit, offset)
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/config"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+var (
+	// ErrRegistrationDisabled is returned when self-service registration is turned off
+	ErrRegistrationDisabled = apperror.Forbidden("registration is disabled")
+	// ErrUserExists is returned when the username or email is already taken
+	ErrUserExists = apperror.Conflict("username or email already exists")
+	// ErrUnauthenticated is returned for bad credentials, unknown tokens and inactive accounts
+	ErrUnauthenticated = apperror.Unauthorized("authentication failed")
+	// ErrAPITokenNotFound is returned when revoking a token the user does not own
+	ErrAPITokenNotFound = apperror.NotFound("API token")
+)
+
+// AuthService manages user accounts and authenticates requests by JWT or API token
+type AuthService struct {
+	userRepo  *repository.UserRepository
+	tokenRepo *repository.APITokenRepository
+	tokens    *auth.TokenManager
+	cfg       *config.AuthConfig
+}
+
+// NewAuthService creates a new auth service
+func NewAuthService(
+	userRepo *repository.UserRepository,
+	tokenRepo *repository.APITokenRepository,
+	tokens *auth.TokenManager,
+	cfg *config.AuthConfig,
+) *AuthService {
+	return &AuthService{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		tokens:    tokens,
+		cfg:       cfg,
+	}
+}
+
+// Register creates a user account
+func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest) (*models.User, error) {
+	if !s.cfg.AllowRegistration {
+		return nil, ErrRegistrationDisabled
+	}
+
+	exists, err := s.userRepo.ExistsByUsernameOrEmail(req.Username, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrUserExists
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Username:     req.Username,
+		Email:        req.Email,
+		PasswordHash: hash,
+		IsActive:     true,
+	}
+	if req.FullName != "" {
+		user.FullName = sql.NullString{String: req.FullName, Valid: true}
+	}
+
+	// Self-registered accounts are created by themselves
+	actor := auth.Actor(ctx)
+	if actor == auth.SystemActor {
+		actor = req.Username
+	}
+	user.SetCreatedBy(actor)
+
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+// Login verifies a password and issues a JWT
+func (s *AuthService) Login(req *models.LoginRequest) (*models.LoginResponse, error) {
+	user, err := s.userRepo.GetByLogin(req.Username)
+	if err != nil || !user.IsActive {
+		return nil, ErrUnauthenticated
+	}
+
+	if err := auth.CheckPassword(user.PasswordHash, req.Password); err != nil {
+		return nil, ErrUnauthenticated
+	}
+
+	token, expiresAt, err := s.tokens.Issue(user.UUID, user.Username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue token: %w", err)
+	}
+
+	if err := s.userRepo.UpdateLastLogin(user.ID); err != nil {
+		log.Printf("Failed to record login of %s: %v", user.Username, err)
+	}
+
+	return &models.LoginResponse{
+		Token:     token,
+		TokenType: "Bearer",
+		ExpiresAt: expiresAt,
+		User:      user,
+	}, nil
+}
+
+// Authenticate resolves the user of a bearer token, which is either a JWT or an API token
+func (s *AuthService) Authenticate(ctx context.Context, token string) (*models.User, error) {
+	var user *models.User
+
+	if auth.IsAPIToken(token) {
+		apiToken, err := s.tokenRepo.GetByHash(auth.HashAPIToken(token))
+		if err != nil || apiToken.IsExpired() {
+			return nil, ErrUnauthenticated
+		}
+
+		user, err = s.userRepo.GetByID(apiToken.UserID)
+		if err != nil {
+			return nil, ErrUnauthenticated
+		}
+
+		if err := s.tokenRepo.TouchLastUsed(apiToken.ID); err != nil {
+			log.Printf("Failed to record use of API token %s: %v", apiToken.UUID, err)
+		}
+	} else {
+		claims, err := s.tokens.Parse(token)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+		}
+
+		user, err = s.userRepo.GetByUUID(claims.Subject)
+		if err != nil {
+			return nil, ErrUnauthenticated
+		}
+	}
+
+	if !user.IsActive {
+		return nil, ErrUnauthenticated
+	}
+
+	return user, nil
+}
+
+// CreateAPIToken creates a long-lived token for the current user; the plain token is only returned here
+func (s *AuthService) CreateAPIToken(ctx context.Context, req *models.CreateAPITokenRequest) (*models.CreateAPITokenResponse, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	token, hash, prefix, err := auth.GenerateAPIToken()
+	if err != nil {
+		return nil, err
+	}
+
+	apiToken := &models.APIToken{
+		UserID:      user.ID,
+		Name:        req.Name,
+		TokenPrefix: prefix,
+		TokenHash:   hash,
+	}
+	if req.ExpiresInDays > 0 {
+		apiToken.ExpiresAt = sql.NullTime{Time: time.Now().AddDate(0, 0, req.ExpiresInDays), Valid: true}
+	}
+	apiToken.SetCreatedBy(user.Username)
+
+	if err := s.tokenRepo.Create(apiToken); err != nil {
+		return nil, err
+	}
+
+	return &models.CreateAPITokenResponse{Token: token, APIToken: apiToken}, nil
+}
+
+// GetAPITokens lists the tokens of the current user
+func (s *AuthService) GetAPITokens(ctx context.Context) ([]models.APIToken, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	return s.tokenRepo.GetByUserID(user.ID)
+}
+
+// RevokeAPIToken deletes a token of the current user
+func (s *AuthService) RevokeAPIToken(ctx context.Context, uuid string) error {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	deleted, err := s.tokenRepo.DeleteByUUID(user.ID, uuid, user.Username)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrAPITokenNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+// ErrForbidden is returned when the current user lacks the project role an operation requires
+var ErrForbidden = apperror.Forbidden("insufficient project permissions")
+
+// ErrTeamForbidden is returned when the current user lacks the team role an operation requires
+var ErrTeamForbidden = apperror.Forbidden("insufficient team permissions")
+
+// projectGuard checks the role of the current user on the project an operation touches
+type projectGuard struct {
+	authz    rbac.Authorizer
+	projects *repository.ProjectRepository
+}
+
+func newProjectGuard(authz rbac.Authorizer, projects *repository.ProjectRepository) *projectGuard {
+	return &projectGuard{authz: authz, projects: projects}
+}
+
+// require checks that the user of ctx holds at least the required role on a project
+func (g *projectGuard) require(ctx context.Context, projectUUID, required string) error {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+
+	role, err := g.authz.Role(ctx, projectUUID, user.Username)
+	if err != nil {
+		return fmt.Errorf("failed to check project access: %w", err)
+	}
+	if !rbac.Allows(role, required) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// requireID is require for callers holding the internal project ID; it returns the project
+func (g *projectGuard) requireID(ctx context.Context, projectID int64, required string) (*models.Project, error) {
+	project, err := g.projects.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.require(ctx, project.UUID, required); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// visibleProjects lists the projects the user of ctx can see
+func (g *projectGuard) visibleProjects(ctx context.Context) ([]string, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	uuids, err := g.authz.ProjectUUIDs(ctx, user.Username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accessible projects: %w", err)
+	}
+
+	return uuids, nil
+}
+
+// teamGuard checks the role of the current user in the team an operation touches
+type teamGuard struct {
+	teams *repository.TeamRepository
+}
+
+// require checks that the user of ctx is a member of a team, and an owner when owner is required
+func (g *teamGuard) require(ctx context.Context, teamID int64, required string) error {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return ErrTeamForbidden
+	}
+
+	role, err := g.teams.GetMemberRole(teamID, user.Username)
+	if err != nil {
+		return fmt.Errorf("failed to check team access: %w", err)
+	}
+	if role == "" || (required == models.TeamRoleOwner && role != models.TeamRoleOwner) {
+		return ErrTeamForbidden
+	}
+
+	return nil
+}
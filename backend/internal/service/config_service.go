@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
+	"github.com/yourusername/lambra/internal/repository"
+	"github.com/yourusername/lambra/internal/secrets"
+)
+
+var (
+	// ErrInvalidEnvironment is returned for environments other than dev, staging and production
+	ErrInvalidEnvironment = apperror.Validation("invalid environment",
+		apperror.FieldError{Field: "environment", Message: "must be one of dev, staging, production"})
+	// ErrInvalidConfigName is returned when a variable name is not a valid environment variable name
+	ErrInvalidConfigName = apperror.Validation("invalid config variable name",
+		apperror.FieldError{Field: "name", Message: "must match [A-Z_][A-Z0-9_]*"})
+	// ErrSecretsDisabled is returned when secrets are used without an encryption key
+	ErrSecretsDisabled = apperror.Validation("secrets are disabled: SECRETS_ENCRYPTION_KEY is not configured",
+		apperror.FieldError{Field: "secret", Message: "secrets are disabled"})
+)
+
+var configNamePattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+type ConfigService struct {
+	repo        *repository.ConfigRepository
+	projectRepo *repository.ProjectRepository
+	cipher      *secrets.Cipher
+	guard       *projectGuard
+}
+
+// NewConfigService creates a config service; a nil cipher disables secrets
+func NewConfigService(
+	repo *repository.ConfigRepository,
+	projectRepo *repository.ProjectRepository,
+	cipher *secrets.Cipher,
+	authz rbac.Authorizer,
+) *ConfigService {
+	return &ConfigService{
+		repo:        repo,
+		projectRepo: projectRepo,
+		cipher:      cipher,
+		guard:       newProjectGuard(authz, projectRepo),
+	}
+}
+
+// SetVariable creates or replaces a variable, encrypting it when it is a secret
+func (s *ConfigService) SetVariable(ctx context.Context, projectUUID, environment, name string, req *models.SetConfigVarRequest) (*models.ProjectConfigVar, error) {
+	if err := validateConfigKey(environment, name); err != nil {
+		return nil, err
+	}
+
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	variable := &models.ProjectConfigVar{
+		ProjectID:   project.ID,
+		Environment: environment,
+		Name:        name,
+		Value:       req.Value,
+		IsSecret:    req.Secret,
+	}
+
+	if req.Secret {
+		if s.cipher == nil {
+			return nil, ErrSecretsDisabled
+		}
+		variable.Value, err = s.cipher.Encrypt(req.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	variable.SetCreatedBy(auth.Actor(ctx))
+
+	err = s.repo.Upsert(variable)
+	if err != nil {
+		return nil, err
+	}
+
+	return variable, nil
+}
+
+// GetVariables lists the variables of a project environment; secret values are masked on output
+func (s *ConfigService) GetVariables(ctx context.Context, projectUUID, environment string) ([]models.ProjectConfigVar, error) {
+	if !isEnvironment(environment) {
+		return nil, ErrInvalidEnvironment
+	}
+
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	return s.repo.GetByProjectID(project.ID, environment)
+}
+
+func (s *ConfigService) DeleteVariable(ctx context.Context, projectUUID, environment, name string) error {
+	if err := validateConfigKey(environment, name); err != nil {
+		return err
+	}
+
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleMaintainer); err != nil {
+		return err
+	}
+
+	project, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	if _, err := s.repo.GetByName(project.ID, environment, name); err != nil {
+		return err
+	}
+
+	// Soft delete with deleted_by
+	return s.repo.DeleteByName(project.ID, environment, name, auth.Actor(ctx))
+}
+
+// ResolveSecrets decrypts the secrets of a project environment for a deployment.
+// The result must only be handed to executors, never persisted.
+func (s *ConfigService) ResolveSecrets(projectID int64, environment string) (map[string]string, error) {
+	variables, err := s.repo.GetByProjectID(projectID, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[string]string)
+	for _, variable := range variables {
+		if !variable.IsSecret {
+			continue
+		}
+		if s.cipher == nil {
+			return nil, ErrSecretsDisabled
+		}
+
+		value, err := s.cipher.Decrypt(variable.Value)
+		if err != nil {
+			return nil, fmt.Errorf("secret %s: %w", variable.Name, err)
+		}
+		resolved[variable.Name] = value
+	}
+
+	return resolved, nil
+}
+
+// environmentConfigs groups project variables per environment, keeping only secret names
+func environmentConfigs(variables []models.ProjectConfigVar) map[string]*models.EnvironmentConfig {
+	configs := make(map[string]*models.EnvironmentConfig)
+	for _, variable := range variables {
+		config, ok := configs[variable.Environment]
+		if !ok {
+			config = &models.EnvironmentConfig{Variables: map[string]string{}}
+			configs[variable.Environment] = config
+		}
+
+		if variable.IsSecret {
+			config.SecretKeys = append(config.SecretKeys, variable.Name)
+		} else {
+			config.Variables[variable.Name] = variable.Value
+		}
+	}
+
+	return configs
+}
+
+func validateConfigKey(environment, name string) error {
+	if !isEnvironment(environment) {
+		return ErrInvalidEnvironment
+	}
+	if !configNamePattern.MatchString(name) {
+		return ErrInvalidConfigName
+	}
+	return nil
+}
+
+func isEnvironment(environment string) bool {
+	for _, env := range models.DeploymentEnvironments {
+		if env == environment {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/generator"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// checkEntityConflicts returns a conflict when another entity of the project has the name or table
+// of entity; the generated service would declare its type or migrate its table twice
+func checkEntityConflicts(entity *models.Entity, existing []models.Entity) error {
+	for _, other := range existing {
+		if other.UUID == entity.UUID {
+			continue
+		}
+		if other.Name == entity.Name {
+			return apperror.Conflict(fmt.Sprintf("entity %q already exists in the project", entity.Name))
+		}
+		if strings.EqualFold(other.TableName, entity.TableName) {
+			return apperror.Conflict(fmt.Sprintf("table %q is already used by entity %q", entity.TableName, other.Name))
+		}
+	}
+	return nil
+}
+
+// checkRouteConflicts returns a conflict when the route of endpoint cannot be registered on the
+// generated Gin router next to another endpoint of the project
+func checkRouteConflicts(endpoint *models.Endpoint, existing []models.Endpoint) error {
+	for _, other := range existing {
+		if other.UUID == endpoint.UUID {
+			continue
+		}
+		if reason := generator.RouteConflict(endpoint.Method, endpoint.Path, other.Method, other.Path); reason != "" {
+			return apperror.Conflict(fmt.Sprintf("endpoint %s %s conflicts with endpoint %q (%s %s): %s",
+				endpoint.Method, endpoint.Path, other.Name, other.Method, other.Path, reason))
+		}
+	}
+	return nil
+}
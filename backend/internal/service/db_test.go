@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// newTestDB returns a database whose queries are answered by the returned mock. Expectations
+// match queries in order and must all be met when the test ends.
+func newTestDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		db.Close()
+	})
+	return sqlx.NewDb(db, "mysql"), mock
+}
+
+// userContext returns a context authenticated as username
+func userContext(username string) context.Context {
+	return auth.WithUser(context.Background(), &models.User{Username: username})
+}
+
+// roleAuthorizer gives every user the same role on every project
+type roleAuthorizer struct {
+	role string
+}
+
+func (a roleAuthorizer) Role(ctx context.Context, projectUUID, username string) (string, error) {
+	return a.role, nil
+}
+
+func (a roleAuthorizer) ProjectUUIDs(ctx context.Context, username string) ([]string, error) {
+	return nil, nil
+}
+
+func (a roleAuthorizer) Members(ctx context.Context, projectUUID string) ([]models.ProjectMember, error) {
+	return nil, nil
+}
+
+func (a roleAuthorizer) Grant(ctx context.Context, projectUUID, username, role string) error {
+	return nil
+}
+
+func (a roleAuthorizer) Revoke(ctx context.Context, projectUUID, username string) error {
+	return nil
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/deploy"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+type DeploymentService struct {
+	repo         *repository.DeploymentRepository
+	snapshotRepo *repository.SnapshotRepository
+	projectRepo  *repository.ProjectRepository
+	configs      *ConfigService
+	executor     deploy.Executor
+	guard        *projectGuard
+}
+
+func NewDeploymentService(
+	repo *repository.DeploymentRepository,
+	snapshotRepo *repository.SnapshotRepository,
+	projectRepo *repository.ProjectRepository,
+	configs *ConfigService,
+	executor deploy.Executor,
+	authz rbac.Authorizer,
+) *DeploymentService {
+	return &DeploymentService{
+		repo:         repo,
+		snapshotRepo: snapshotRepo,
+		projectRepo:  projectRepo,
+		configs:      configs,
+		executor:     executor,
+		guard:        newProjectGuard(authz, projectRepo),
+	}
+}
+
+// deploymentLogger appends executor output to a deployment's logs
+type deploymentLogger struct {
+	repo         *repository.DeploymentRepository
+	deploymentID int64
+}
+
+func (l *deploymentLogger) Log(level, message string) {
+	err := l.repo.AppendLog(&models.DeploymentLog{
+		DeploymentID: l.deploymentID,
+		Level:        level,
+		Message:      message,
+	})
+	if err != nil {
+		log.Printf("deployment %d: %v", l.deploymentID, err)
+	}
+}
+
+// CreateDeployment queues a deployment of a snapshot and starts the executor in the background.
+// Without an executor the deployment stays pending until an external executor reports its status.
+func (s *DeploymentService) CreateDeployment(ctx context.Context, req *models.CreateDeploymentRequest) (*models.Deployment, error) {
+	if err := s.guard.require(ctx, req.ProjectUUID, models.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByUUID(req.ProjectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	snapshot, err := s.snapshotRepo.GetByUUID(req.SnapshotUUID)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.ProjectID != project.ID {
+		return nil, apperror.NotFound("snapshot")
+	}
+
+	deployment := &models.Deployment{
+		ProjectID:   project.ID,
+		SnapshotID:  sql.NullInt64{Int64: snapshot.ID, Valid: true},
+		Environment: req.Environment,
+		Status:      models.DeploymentStatusPending,
+		Version:     req.Version,
+	}
+	if deployment.Version == "" {
+		deployment.Version = snapshot.Version
+	}
+
+	actor := auth.Actor(ctx)
+	deployment.DeployedBy = sql.NullString{String: actor, Valid: true}
+	deployment.SetCreatedBy(actor)
+
+	err = s.repo.Create(deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment: %w", err)
+	}
+
+	logger := &deploymentLogger{repo: s.repo, deploymentID: deployment.ID}
+	logger.Log(models.DeploymentLogInfo, fmt.Sprintf("Deployment of snapshot %s queued for %s", snapshot.Version, deployment.Environment))
+
+	if s.executor == nil {
+		logger.Log(models.DeploymentLogInfo, "Waiting for an external executor to report the deployment status")
+		return deployment, nil
+	}
+
+	execution := *deployment
+	go s.execute(&execution, project, snapshot, logger)
+
+	return deployment, nil
+}
+
+// execute drives a pending deployment through deploying to success or failed on behalf of the user who deployed it
+func (s *DeploymentService) execute(deployment *models.Deployment, project *models.Project, snapshot *models.GenerationSnapshot, logger deploy.Logger) {
+	actor := deployment.DeployedBy.String
+
+	if err := s.transition(deployment, actor, models.DeploymentStatusDeploying, "", ""); err != nil {
+		logger.Log(models.DeploymentLogError, err.Error())
+		return
+	}
+
+	secrets, err := s.configs.ResolveSecrets(project.ID, deployment.Environment)
+	if err != nil {
+		if err := s.transition(deployment, actor, models.DeploymentStatusFailed, "", err.Error()); err != nil {
+			logger.Log(models.DeploymentLogError, err.Error())
+		}
+		return
+	}
+
+	result, err := s.executor.Execute(context.Background(), &deploy.Request{
+		DeploymentUUID: deployment.UUID,
+		ProjectName:    project.Name,
+		Namespace:      project.EnvironmentNamespace(deployment.Environment),
+		Environment:    deployment.Environment,
+		Version:        deployment.Version,
+		Snapshot:       snapshot,
+		Secrets:        secrets,
+	}, logger)
+
+	if err != nil {
+		err = s.transition(deployment, actor, models.DeploymentStatusFailed, "", err.Error())
+	} else {
+		err = s.transition(deployment, actor, models.DeploymentStatusSuccess, result.URL, "")
+	}
+	if err != nil {
+		logger.Log(models.DeploymentLogError, err.Error())
+	}
+}
+
+// transition moves a deployment to a new status if the state machine allows it
+func (s *DeploymentService) transition(deployment *models.Deployment, actor, status, deploymentURL, errorMessage string) error {
+	from := deployment.Status
+	if !deploy.CanTransition(from, status) {
+		return fmt.Errorf("%w: %s -> %s", deploy.ErrInvalidTransition, from, status)
+	}
+
+	deployment.Status = status
+	if deploymentURL != "" {
+		deployment.DeploymentURL = sql.NullString{String: deploymentURL, Valid: true}
+	}
+	if errorMessage != "" {
+		deployment.ErrorMessage = sql.NullString{String: errorMessage, Valid: true}
+	}
+
+	deployment.SetUpdatedBy(actor)
+
+	updated, err := s.repo.UpdateStatus(deployment, from)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		// Another writer moved the deployment first
+		return fmt.Errorf("%w: deployment is no longer %s", deploy.ErrInvalidTransition, from)
+	}
+
+	return nil
+}
+
+// UpdateDeploymentStatus records progress reported by an external executor
+func (s *DeploymentService) UpdateDeploymentStatus(ctx context.Context, uuid string, req *models.UpdateDeploymentStatusRequest) (*models.Deployment, error) {
+	deployment, err := s.repo.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.guard.require(ctx, deployment.ProjectUUID, models.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+
+	if err := s.transition(deployment, auth.Actor(ctx), req.Status, req.DeploymentURL, req.ErrorMessage); err != nil {
+		return nil, err
+	}
+
+	level := models.DeploymentLogInfo
+	message := "Deployment status changed to " + req.Status
+	if req.ErrorMessage != "" {
+		level = models.DeploymentLogError
+		message += ": " + req.ErrorMessage
+	}
+	(&deploymentLogger{repo: s.repo, deploymentID: deployment.ID}).Log(level, message)
+
+	return s.repo.GetByUUID(uuid)
+}
+
+func (s *DeploymentService) GetDeploymentByUUID(ctx context.Context, uuid string) (*models.Deployment, error) {
+	deployment, err := s.repo.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.guard.require(ctx, deployment.ProjectUUID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	return deployment, nil
+}
+
+// GetDeployments lists deployments of the projects the current user can see, optionally narrowed to a single project
+func (s *DeploymentService) GetDeployments(ctx context.Context, projectUUID, environment, status string, page, limit int) ([]models.Deployment, int64, error) {
+	filter := &models.DeploymentFilter{Environment: environment, Status: status}
+
+	if projectUUID != "" {
+		if err := s.guard.require(ctx, projectUUID, models.ProjectRoleViewer); err != nil {
+			return nil, 0, err
+		}
+
+		project, err := s.projectRepo.GetByUUID(projectUUID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("project not found: %w", err)
+		}
+		filter.ProjectID = project.ID
+	} else {
+		uuids, err := s.guard.visibleProjects(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(uuids) == 0 {
+			return []models.Deployment{}, 0, nil
+		}
+		filter.ProjectUUIDs = uuids
+	}
+
+	offset := (page - 1) * limit
+	return s.repo.GetAll(filter, limit, offset)
+}
+
+func (s *DeploymentService) GetDeploymentLogs(ctx context.Context, uuid string, page, limit int) ([]models.DeploymentLog, int64, error) {
+	deployment, err := s.GetDeploymentByUUID(ctx, uuid)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	return s.repo.GetLogs(deployment.ID, limit, offset)
+}
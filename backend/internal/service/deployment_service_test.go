@@ -0,0 +1,74 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yourusername/lambra/internal/deploy"
+	"github.com/yourusername/lambra/internal/generator"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+type discardLogger struct{}
+
+func (discardLogger) Log(level, message string) {}
+
+// The executor must apply secrets and resources in the namespace the rendered manifests use
+func TestDeploymentService_ExecuteUsesEnvironmentNamespace(t *testing.T) {
+	environments, _ := json.Marshal(map[string]models.EnvironmentSettings{
+		models.DeploymentEnvProduction: {Namespace: "shop-live"},
+	})
+	project := &models.Project{Name: "Orders", Namespace: "shop", Environments: environments}
+	project.ID = 1
+
+	tests := []struct {
+		environment string
+		want        string
+	}{
+		{models.DeploymentEnvDev, "shop-dev"},
+		{models.DeploymentEnvStaging, "shop-staging"},
+		{models.DeploymentEnvProduction, "shop-live"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.environment, func(t *testing.T) {
+			db, mock := newTestDB(t)
+			executor := deploy.NewFakeExecutor()
+			svc := NewDeploymentService(
+				repository.NewDeploymentRepository(db),
+				repository.NewSnapshotRepository(db),
+				repository.NewProjectRepository(db),
+				NewConfigService(repository.NewConfigRepository(db), repository.NewProjectRepository(db), nil, nil),
+				executor,
+				nil,
+			)
+
+			mock.ExpectExec(`UPDATE deployments`).WithArgs(models.DeploymentStatusDeploying, sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), false, int64(5), models.DeploymentStatusPending).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(`FROM project_config_vars`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			mock.ExpectExec(`UPDATE deployments`).WithArgs(models.DeploymentStatusSuccess, sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), true, int64(5), models.DeploymentStatusDeploying).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			deployment := &models.Deployment{Environment: tt.environment, Status: models.DeploymentStatusPending, Version: "1.0.0"}
+			deployment.ID = 5
+			svc.execute(deployment, project, &models.GenerationSnapshot{}, discardLogger{})
+
+			requests := executor.Requests()
+			if len(requests) != 1 {
+				t.Fatalf("executor received %d requests, want 1", len(requests))
+			}
+			if requests[0].Namespace != tt.want {
+				t.Errorf("request namespace = %q, want %q", requests[0].Namespace, tt.want)
+			}
+
+			deployCtx, err := generator.NewCodeGenerator().PrepareDeployContext(project, tt.environment, "1.0.0", nil)
+			if err != nil {
+				t.Fatalf("PrepareDeployContext() error = %v", err)
+			}
+			if deployCtx.Namespace != requests[0].Namespace {
+				t.Errorf("manifests use namespace %q, request uses %q", deployCtx.Namespace, requests[0].Namespace)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
+	"github.com/yourusername/lambra/internal/repository"
+	"github.com/yourusername/lambra/internal/schema"
+)
+
+const (
+	// BaseURLConfigVar is the config variable holding the URL of the service of an environment,
+	// used before the URL of its latest successful deployment
+	BaseURLConfigVar = "BASE_URL"
+
+	endpointRunTimeout = 30 * time.Second
+	maxRunBodyBytes    = 1 << 20
+	redactedValue      = "[redacted]"
+)
+
+// Headers whose values are not kept in the run history
+var credentialHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+}
+
+// EndpointRunService tests endpoints against running services and keeps the history of the runs
+type EndpointRunService struct {
+	repo           *repository.EndpointRunRepository
+	endpointRepo   *repository.EndpointRepository
+	entityRepo     *repository.EntityRepository
+	configRepo     *repository.ConfigRepository
+	deploymentRepo *repository.DeploymentRepository
+	guard          *projectGuard
+	targets        *targetPolicy
+	client         *http.Client
+}
+
+func NewEndpointRunService(
+	repo *repository.EndpointRunRepository,
+	endpointRepo *repository.EndpointRepository,
+	entityRepo *repository.EntityRepository,
+	projectRepo *repository.ProjectRepository,
+	configRepo *repository.ConfigRepository,
+	deploymentRepo *repository.DeploymentRepository,
+	authz rbac.Authorizer,
+	allowedTargets []string,
+) (*EndpointRunService, error) {
+	targets, err := newTargetPolicy(allowedTargets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EndpointRunService{
+		repo:           repo,
+		endpointRepo:   endpointRepo,
+		entityRepo:     entityRepo,
+		configRepo:     configRepo,
+		deploymentRepo: deploymentRepo,
+		guard:          newProjectGuard(authz, projectRepo),
+		targets:        targets,
+		client:         targets.client(endpointRunTimeout),
+	}, nil
+}
+
+// TestEndpoint sends a request to an endpoint of a running service, checks the response against the
+// response schema of the endpoint and records the run. A failed request is reported in the result,
+// not as an error.
+func (s *EndpointRunService) TestEndpoint(ctx context.Context, endpointUUID string, req *models.TestEndpointRequest) (*models.TestEndpointResponse, error) {
+	endpoint, err := s.endpointRepo.GetByUUID(endpointUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.guard.requireID(ctx, endpoint.ProjectID, models.ProjectRoleDeveloper)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := s.baseURL(project, req)
+	if err != nil {
+		return nil, err
+	}
+	target, err := targetURL(baseURL, endpoint.Path, req.Params, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTarget(target, req); err != nil {
+		return nil, err
+	}
+
+	entities, err := s.entityRepo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	entitySchemas, err := schema.EntitySchemas(entities)
+	if err != nil {
+		return nil, err
+	}
+	responseSchema, err := schema.Parse(endpoint.ResponseSchema, entitySchemas)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response schema of endpoint %s: %w", endpoint.UUID, err)
+	}
+
+	result, isJSON := s.send(ctx, endpoint.Method, target, req)
+	checkResponse(result, isJSON, responseSchema)
+
+	run := &models.EndpointRun{
+		EndpointID:  endpoint.ID,
+		Environment: sql.NullString{String: req.Environment, Valid: req.BaseURL == "" && req.Environment != ""},
+		Method:      endpoint.Method,
+		URL:         target,
+		StatusCode:  result.StatusCode,
+		CreatedBy:   sql.NullString{String: auth.Actor(ctx), Valid: auth.Actor(ctx) != ""},
+	}
+	if run.Request, err = json.Marshal(redactRequest(req)); err != nil {
+		return nil, err
+	}
+	if run.Response, err = json.Marshal(redactResponse(result)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(run); err != nil {
+		return nil, err
+	}
+	result.RunID = run.UUID
+
+	return result, nil
+}
+
+// GetRuns lists the test runs of an endpoint, newest first
+func (s *EndpointRunService) GetRuns(ctx context.Context, endpointUUID string, page, limit int) ([]models.EndpointRun, int64, error) {
+	endpoint, err := s.endpointRepo.GetByUUID(endpointUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := s.guard.requireID(ctx, endpoint.ProjectID, models.ProjectRoleViewer); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	return s.repo.GetByEndpointID(endpoint.ID, limit, offset)
+}
+
+// baseURL picks the service a test is sent to: the base URL of the request, else the BASE_URL
+// config variable of its environment, else the URL of the latest successful deployment there
+func (s *EndpointRunService) baseURL(project *models.Project, req *models.TestEndpointRequest) (string, error) {
+	if req.BaseURL != "" {
+		return req.BaseURL, nil
+	}
+	if req.Environment == "" {
+		return "", apperror.Validation("no target for the endpoint test",
+			apperror.FieldError{Field: "base_url", Message: "is required without environment"})
+	}
+
+	variable, err := s.configRepo.GetByName(project.ID, req.Environment, BaseURLConfigVar)
+	if err == nil && !variable.IsSecret && variable.Value != "" {
+		return variable.Value, nil
+	}
+	if err != nil && !errors.Is(err, apperror.ErrNotFound) {
+		return "", err
+	}
+
+	filter := &models.DeploymentFilter{
+		ProjectID:   project.ID,
+		Environment: req.Environment,
+		Status:      models.DeploymentStatusSuccess,
+	}
+	deployments, _, err := s.deploymentRepo.GetAll(filter, 1, 0)
+	if err != nil {
+		return "", err
+	}
+	if len(deployments) == 0 || deployments[0].DeploymentURL.String == "" {
+		return "", apperror.Validation("no target for the endpoint test",
+			apperror.FieldError{Field: "environment", Message: fmt.Sprintf("has no %s config variable or successful deployment with a URL", BaseURLConfigVar)})
+	}
+
+	return deployments[0].DeploymentURL.String, nil
+}
+
+// checkTarget rejects a target the policy does not allow before anything is sent. Names resolving
+// to addresses the policy does not allow are rejected when the request connects.
+func (s *EndpointRunService) checkTarget(target string, req *models.TestEndpointRequest) error {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return err
+	}
+	if err := s.targets.checkURL(parsed); err != nil {
+		field := "base_url"
+		if req.BaseURL == "" {
+			field = "environment"
+		}
+		return apperror.Validation("invalid endpoint test target", apperror.FieldError{Field: field, Message: err.Error()})
+	}
+	return nil
+}
+
+// targetURL joins a base URL with an endpoint path whose parameters are substituted
+func targetURL(baseURL, path string, params, query map[string]string) (string, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return "", apperror.Validation("invalid endpoint test target",
+			apperror.FieldError{Field: "base_url", Message: "must be an http or https URL"})
+	}
+
+	segments := strings.Split(path, "/")
+	declared := make(map[string]bool)
+	var fieldErrs []apperror.FieldError
+	for i, segment := range segments {
+		if !isWildcard(segment) {
+			continue
+		}
+		name := segment[1:]
+		declared[name] = true
+		value := params[name]
+		if value == "" {
+			fieldErrs = append(fieldErrs, apperror.FieldError{Field: "params." + name, Message: "is required"})
+			continue
+		}
+		if segment[0] == '*' {
+			segments[i] = strings.TrimPrefix(value, "/")
+		} else {
+			segments[i] = url.PathEscape(value)
+		}
+	}
+	for _, name := range sortedStringKeys(params) {
+		if !declared[name] {
+			fieldErrs = append(fieldErrs, apperror.FieldError{Field: "params." + name, Message: "is not a parameter of " + path})
+		}
+	}
+	if len(fieldErrs) > 0 {
+		return "", apperror.Validation("invalid endpoint test parameters", fieldErrs...)
+	}
+
+	target := strings.TrimSuffix(base.String(), "/") + strings.Join(segments, "/")
+	if len(query) > 0 {
+		values := url.Values{}
+		for name, value := range query {
+			values.Set(name, value)
+		}
+		target += "?" + values.Encode()
+	}
+
+	return target, nil
+}
+
+// send performs the request of a test, reporting transport failures in the result. A body that is
+// not JSON is returned as a JSON string and reported by isJSON.
+func (s *EndpointRunService) send(ctx context.Context, method, target string, req *models.TestEndpointRequest) (result *models.TestEndpointResponse, isJSON bool) {
+	result = &models.TestEndpointResponse{URL: target, Headers: map[string]string{}}
+
+	var body io.Reader
+	if len(bytes.TrimSpace(req.Body)) > 0 {
+		body = bytes.NewReader(req.Body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		result.Error = err.Error()
+		return result, false
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range req.Headers {
+		httpReq.Header.Set(name, value)
+	}
+
+	start := time.Now()
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		result.ResponseTime = time.Since(start).Milliseconds()
+		result.Error = err.Error()
+		return result, false
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxRunBodyBytes+1))
+	result.ResponseTime = time.Since(start).Milliseconds()
+	result.StatusCode = resp.StatusCode
+	for name, values := range resp.Header {
+		result.Headers[name] = strings.Join(values, ", ")
+	}
+	if err != nil {
+		result.Error = "failed to read response body: " + err.Error()
+		return result, false
+	}
+	if len(raw) > maxRunBodyBytes {
+		raw = raw[:maxRunBodyBytes]
+		result.Error = fmt.Sprintf("response body exceeds %d bytes and was truncated", maxRunBodyBytes)
+	}
+
+	if json.Valid(raw) {
+		result.Body = raw
+		return result, true
+	}
+	if len(raw) > 0 {
+		result.Body, _ = json.Marshal(string(raw))
+	}
+	return result, false
+}
+
+// checkResponse validates the body of a successful response against the response schema
+func checkResponse(result *models.TestEndpointResponse, isJSON bool, responseSchema *schema.Schema) {
+	if responseSchema.IsEmpty() || result.Error != "" || result.StatusCode < 200 || result.StatusCode >= 300 {
+		return
+	}
+
+	if !isJSON {
+		result.SchemaErrors = []string{"body is not JSON"}
+	} else {
+		var value interface{}
+		if err := json.Unmarshal(result.Body, &value); err != nil {
+			return
+		}
+		for _, violation := range responseSchema.Validate(value) {
+			result.SchemaErrors = append(result.SchemaErrors, violation.Error())
+		}
+	}
+
+	valid := len(result.SchemaErrors) == 0
+	result.SchemaValid = &valid
+}
+
+// redactRequest copies a test request for the run history without the values of credential headers
+func redactRequest(req *models.TestEndpointRequest) *models.TestEndpointRequest {
+	redacted := *req
+	redacted.Headers = redactHeaders(req.Headers)
+	return &redacted
+}
+
+// redactResponse copies a test result for the run history without the values of credential headers
+func redactResponse(result *models.TestEndpointResponse) *models.TestEndpointResponse {
+	redacted := *result
+	redacted.Headers = redactHeaders(result.Headers)
+	return &redacted
+}
+
+func redactHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if credentialHeaders[http.CanonicalHeaderKey(name)] {
+			value = redactedValue
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// isWildcard reports whether a path segment is a :param or *catchAll segment
+func isWildcard(segment string) bool {
+	return len(segment) > 1 && (segment[0] == ':' || segment[0] == '*')
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/apperror"
+)
+
+func TestTargetURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		base   string
+		path   string
+		params map[string]string
+		query  map[string]string
+		want   string
+	}{
+		{"static path", "http://localhost:8080", "/users", nil, nil, "http://localhost:8080/users"},
+		{"base with trailing slash", "http://localhost:8080/", "/users", nil, nil, "http://localhost:8080/users"},
+		{"base with prefix", "https://api.example.com/v1", "/users", nil, nil, "https://api.example.com/v1/users"},
+		{"param", "http://localhost:8080", "/users/:id", map[string]string{"id": "42"}, nil, "http://localhost:8080/users/42"},
+		{"escaped param", "http://localhost:8080", "/users/:id", map[string]string{"id": "a b/c"}, nil, "http://localhost:8080/users/a%20b%2Fc"},
+		{"catch-all keeps slashes", "http://localhost:8080", "/files/*path", map[string]string{"path": "/a/b.txt"}, nil, "http://localhost:8080/files/a/b.txt"},
+		{"query", "http://localhost:8080", "/users", nil, map[string]string{"limit": "10", "q": "a&b"}, "http://localhost:8080/users?limit=10&q=a%26b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := targetURL(tt.base, tt.path, tt.params, tt.query)
+			if err != nil {
+				t.Fatalf("targetURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("targetURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargetURLErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		base   string
+		path   string
+		params map[string]string
+		fields []string
+	}{
+		{"not http", "ftp://example.com", "/users", nil, []string{"base_url"}},
+		{"no host", "http://", "/users", nil, []string{"base_url"}},
+		{"relative", "/users", "/users", nil, []string{"base_url"}},
+		{"missing param", "http://localhost", "/users/:id", nil, []string{"params.id"}},
+		{"empty param", "http://localhost", "/users/:id", map[string]string{"id": ""}, []string{"params.id"}},
+		{"missing catch-all", "http://localhost", "/files/*path", nil, []string{"params.path"}},
+		{"extra params", "http://localhost", "/users/:id", map[string]string{"id": "1", "b": "2", "a": "3"}, []string{"params.a", "params.b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := targetURL(tt.base, tt.path, tt.params, nil)
+			if !errors.Is(err, apperror.ErrValidation) {
+				t.Fatalf("targetURL() error = %v, want a validation error", err)
+			}
+			var fields []string
+			for _, field := range apperror.Fields(err) {
+				fields = append(fields, field.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("targetURL() error fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestTargetPolicy(t *testing.T) {
+	open, err := newTargetPolicy(nil)
+	if err != nil {
+		t.Fatalf("newTargetPolicy() error = %v", err)
+	}
+	allowlist, err := newTargetPolicy([]string{"localhost", "10.1.0.0/16", "192.168.1.5", " "})
+	if err != nil {
+		t.Fatalf("newTargetPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		policy *targetPolicy
+		host   string
+		ip     string
+		allow  bool
+	}{
+		{"public address", open, "example.com", "93.184.216.34", true},
+		{"loopback", open, "localhost", "127.0.0.1", false},
+		{"loopback v6", open, "localhost", "::1", false},
+		{"metadata", open, "169.254.169.254", "169.254.169.254", false},
+		{"private", open, "db.internal", "10.0.0.5", false},
+		{"private 172", open, "db.internal", "172.16.3.4", false},
+		{"private 192", open, "router", "192.168.0.1", false},
+		{"unique local v6", open, "db.internal", "fd00::1", false},
+		{"shared address space", open, "svc", "100.64.0.1", false},
+		{"unspecified", open, "0.0.0.0", "0.0.0.0", false},
+		{"v4 mapped loopback", open, "evil", "::ffff:127.0.0.1", false},
+		{"allowed host", allowlist, "localhost", "127.0.0.1", true},
+		{"allowed host any case", allowlist, "LocalHost.", "127.0.0.1", true},
+		{"allowed network", allowlist, "db.internal", "10.1.2.3", true},
+		{"allowed address", allowlist, "router", "192.168.1.5", true},
+		{"outside the allowlist", allowlist, "db.internal", "10.2.0.1", false},
+		{"public outside the allowlist", allowlist, "example.com", "93.184.216.34", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.allowIP(tt.host, net.ParseIP(tt.ip))
+			if (err == nil) != tt.allow {
+				t.Errorf("allowIP(%s, %s) error = %v, want allowed %v", tt.host, tt.ip, err, tt.allow)
+			}
+		})
+	}
+
+	if _, err := newTargetPolicy([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("newTargetPolicy() accepted an invalid CIDR range")
+	}
+}
+
+func TestTargetPolicyCheckURL(t *testing.T) {
+	open, _ := newTargetPolicy(nil)
+	allowlist, _ := newTargetPolicy([]string{"api.example.com"})
+
+	tests := []struct {
+		name   string
+		policy *targetPolicy
+		target string
+		allow  bool
+	}{
+		{"public name", open, "https://api.example.com/users", true},
+		{"loopback literal", open, "http://127.0.0.1:8080/users", false},
+		{"metadata literal", open, "http://169.254.169.254/latest/meta-data", false},
+		{"v6 literal", open, "http://[::1]/", false},
+		{"other scheme", open, "file:///etc/passwd", false},
+		{"listed name", allowlist, "https://api.example.com/users", true},
+		{"unlisted name", allowlist, "https://other.example.com/users", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := url.Parse(tt.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = tt.policy.checkURL(target)
+			if (err == nil) != tt.allow {
+				t.Errorf("checkURL(%s) error = %v, want allowed %v", tt.target, err, tt.allow)
+			}
+		})
+	}
+}
+
+func TestTargetPolicyClient(t *testing.T) {
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("internal"))
+	}))
+	defer internal.Close()
+
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	open, _ := newTargetPolicy(nil)
+	get := func(client *http.Client, target string) error {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, target, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// The test servers listen on loopback, which is only reachable when allowlisted
+	if err := get(open.client(endpointRunTimeout), internal.URL); !errors.Is(err, errTargetNotAllowed) {
+		t.Errorf("GET %s error = %v, want the target to be rejected", internal.URL, err)
+	}
+
+	loopback, _ := newTargetPolicy([]string{"127.0.0.0/8"})
+	if err := get(loopback.client(endpointRunTimeout), internal.URL); err != nil {
+		t.Errorf("GET %s with loopback allowlisted error = %v", internal.URL, err)
+	}
+	if err := get(loopback.client(endpointRunTimeout), redirect.URL); !errors.Is(err, errTargetNotAllowed) {
+		t.Errorf("GET %s error = %v, want the redirect to be rejected", redirect.URL, err)
+	}
+}
@@ -1,33 +1,173 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/generator"
 	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
 	"github.com/yourusername/lambra/internal/repository"
+	"github.com/yourusername/lambra/internal/schema"
 )
 
 type EndpointService struct {
 	repo        *repository.EndpointRepository
 	entityRepo  *repository.EntityRepository
 	projectRepo *repository.ProjectRepository
+	uow         *repository.UnitOfWork
+	audit       *AuditService
+	guard       *projectGuard
 }
 
-func NewEndpointService(repo *repository.EndpointRepository, entityRepo *repository.EntityRepository, projectRepo *repository.ProjectRepository) *EndpointService {
+func NewEndpointService(
+	repo *repository.EndpointRepository,
+	entityRepo *repository.EntityRepository,
+	projectRepo *repository.ProjectRepository,
+	uow *repository.UnitOfWork,
+	audit *AuditService,
+	authz rbac.Authorizer,
+) *EndpointService {
 	return &EndpointService{
 		repo:        repo,
 		entityRepo:  entityRepo,
 		projectRepo: projectRepo,
+		uow:         uow,
+		audit:       audit,
+		guard:       newProjectGuard(authz, projectRepo),
 	}
 }
 
-func (s *EndpointService) CreateEndpoint(req *models.CreateEndpointRequest) (*models.Endpoint, error) {
+func (s *EndpointService) CreateEndpoint(ctx context.Context, req *models.CreateEndpointRequest) (*models.Endpoint, error) {
 	// Validate entity exists and get internal ID
 	entity, err := s.entityRepo.GetByUUID(req.EntityUUID)
 	if err != nil {
 		return nil, fmt.Errorf("entity not found: %w", err)
 	}
 
+	project, err := s.guard.requireID(ctx, entity.ProjectID, models.ProjectRoleDeveloper)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := newEndpoint(ctx, entity, req)
+	if err := s.checkSchemas(project, endpoint); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRouteConflicts(endpoint, existing); err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Endpoints().Create(endpoint); err != nil {
+			return fmt.Errorf("failed to create endpoint: %w", err)
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEndpoint, endpoint.UUID, models.AuditActionCreate, nil, endpoint)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+// BulkCreateEndpoints creates the endpoints of a request in one transaction, so either all of them
+// are created or none. Every endpoint must belong to an entity of the project; invalid items are
+// reported together as field errors of their index.
+func (s *EndpointService) BulkCreateEndpoints(ctx context.Context, projectUUID string, req *models.BulkCreateEndpointsRequest) ([]models.Endpoint, error) {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleDeveloper); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	entitySchemas, err := s.entitySchemas(project)
+	if err != nil {
+		return nil, err
+	}
+
+	var fieldErrs []apperror.FieldError
+	entities := make(map[string]*models.Entity)
+	for i, item := range req.Endpoints {
+		prefix := fmt.Sprintf("endpoints[%d].", i)
+		fieldErrs = append(fieldErrs, schemaErrors(prefix, item.RequestSchema, item.ResponseSchema, entitySchemas)...)
+		fieldErrs = append(fieldErrs, mockErrors(prefix, normalizeMock(item.Mock), item.ResponseSchema, entitySchemas)...)
+
+		entity, ok := entities[item.EntityUUID]
+		if !ok {
+			entity, err = s.entityRepo.GetByUUID(item.EntityUUID)
+			if err != nil || entity.ProjectID != project.ID {
+				entity = nil
+			}
+			entities[item.EntityUUID] = entity
+		}
+		if entity == nil {
+			fieldErrs = append(fieldErrs, apperror.FieldError{
+				Field:   fmt.Sprintf("endpoints[%d].entity_id", i),
+				Message: "references an unknown entity of this project",
+			})
+		}
+
+		for j, earlier := range req.Endpoints[:i] {
+			if reason := generator.RouteConflict(item.Method, item.Path, earlier.Method, earlier.Path); reason != "" {
+				fieldErrs = append(fieldErrs, apperror.FieldError{
+					Field:   fmt.Sprintf("endpoints[%d].path", i),
+					Message: fmt.Sprintf("conflicts with the route of endpoints[%d]: %s", j, reason),
+				})
+				break
+			}
+		}
+	}
+	if len(fieldErrs) > 0 {
+		return nil, apperror.Validation("invalid endpoints", fieldErrs...)
+	}
+
+	existing, err := s.repo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range req.Endpoints {
+		if err := checkRouteConflicts(&models.Endpoint{Method: item.Method, Path: item.Path}, existing); err != nil {
+			return nil, apperror.Conflict(fmt.Sprintf("endpoints[%d]: %s", i, err))
+		}
+	}
+
+	endpoints := make([]models.Endpoint, len(req.Endpoints))
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		for i := range req.Endpoints {
+			endpoint := newEndpoint(ctx, entities[req.Endpoints[i].EntityUUID], &req.Endpoints[i])
+			if err := tx.Endpoints().Create(endpoint); err != nil {
+				return fmt.Errorf("failed to create endpoints[%d]: %w", i, err)
+			}
+			if err := s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEndpoint, endpoint.UUID, models.AuditActionCreate, nil, endpoint); err != nil {
+				return err
+			}
+			endpoints[i] = *endpoint
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoints, nil
+}
+
+// newEndpoint builds the endpoint of an entity a create request describes
+func newEndpoint(ctx context.Context, entity *models.Entity, req *models.CreateEndpointRequest) *models.Endpoint {
 	endpoint := &models.Endpoint{
 		EntityID:       entity.ID,        // Use internal entity ID
 		ProjectID:      entity.ProjectID, // Get project ID from entity
@@ -36,7 +176,12 @@ func (s *EndpointService) CreateEndpoint(req *models.CreateEndpointRequest) (*mo
 		Method:         req.Method,
 		RequestSchema:  req.RequestSchema,
 		ResponseSchema: req.ResponseSchema,
-		RequireAuth:    req.RequireAuth,
+		Mock:           normalizeMock(req.Mock),
+		RequireAuth:    true,
+	}
+
+	if req.RequireAuth != nil {
+		endpoint.RequireAuth = *req.RequireAuth
 	}
 
 	if req.Description != "" {
@@ -44,47 +189,180 @@ func (s *EndpointService) CreateEndpoint(req *models.CreateEndpointRequest) (*mo
 		endpoint.Description.Valid = true
 	}
 
-	// Set created_by (in future, get from auth context)
-	endpoint.SetCreatedBy("system")
+	endpoint.SetCreatedBy(auth.Actor(ctx))
+	return endpoint
+}
 
-	err = s.repo.Create(endpoint)
+// checkSchemas validates the request and response schemas of an endpoint as JSON Schemas whose
+// references resolve to entities of the project, and its mock against the response schema
+func (s *EndpointService) checkSchemas(project *models.Project, endpoint *models.Endpoint) error {
+	entitySchemas, err := s.entitySchemas(project)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+		return err
+	}
+	fieldErrs := schemaErrors("", endpoint.RequestSchema, endpoint.ResponseSchema, entitySchemas)
+	fieldErrs = append(fieldErrs, mockErrors("", endpoint.Mock, endpoint.ResponseSchema, entitySchemas)...)
+	if len(fieldErrs) > 0 {
+		return apperror.Validation("invalid endpoint schema", fieldErrs...)
 	}
+	return nil
+}
 
-	return endpoint, nil
+// entitySchemas returns the schemas of the live entities of a project by name
+func (s *EndpointService) entitySchemas(project *models.Project) (map[string]*schema.Schema, error) {
+	entities, err := s.entityRepo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	return schema.EntitySchemas(entities)
 }
 
-func (s *EndpointService) GetEndpointByUUID(uuid string) (*models.Endpoint, error) {
-	return s.repo.GetByUUID(uuid)
+// schemaErrors reports the problems of a request and response schema as field errors under prefix
+func schemaErrors(prefix string, requestSchema, responseSchema []byte, entitySchemas map[string]*schema.Schema) []apperror.FieldError {
+	var fieldErrs []apperror.FieldError
+	for _, body := range []struct {
+		field string
+		raw   []byte
+	}{
+		{"request_schema", requestSchema},
+		{"response_schema", responseSchema},
+	} {
+		_, err := schema.Parse(body.raw, entitySchemas)
+		var schemaErr *schema.Error
+		if errors.As(err, &schemaErr) {
+			field := prefix + body.field
+			if schemaErr.Path != "" {
+				field += "." + schemaErr.Path
+			}
+			fieldErrs = append(fieldErrs, apperror.FieldError{Field: field, Message: schemaErr.Message})
+		}
+	}
+	return fieldErrs
+}
+
+// mockErrors reports the problems of the mock configuration of an endpoint as field errors under
+// prefix. A fixed example of a successful response must match the response schema.
+func mockErrors(prefix string, raw []byte, responseSchema []byte, entitySchemas map[string]*schema.Schema) []apperror.FieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var mock models.EndpointMock
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&mock); err != nil {
+		return []apperror.FieldError{{Field: prefix + "mock", Message: "must be an object with status, example and scenarios"}}
+	}
+
+	var fieldErrs []apperror.FieldError
+	if mock.Status != 0 && !isHTTPStatus(mock.Status) {
+		fieldErrs = append(fieldErrs, apperror.FieldError{Field: prefix + "mock.status", Message: "must be an HTTP status code"})
+	}
+	names := make(map[string]bool)
+	for i, scenario := range mock.Scenarios {
+		field := fmt.Sprintf("%smock.scenarios[%d]", prefix, i)
+		switch {
+		case scenario.Name == "":
+			fieldErrs = append(fieldErrs, apperror.FieldError{Field: field + ".name", Message: "is required"})
+		case names[scenario.Name]:
+			fieldErrs = append(fieldErrs, apperror.FieldError{Field: field + ".name", Message: fmt.Sprintf("%q is used by another scenario", scenario.Name)})
+		}
+		names[scenario.Name] = true
+		if !isHTTPStatus(scenario.Status) {
+			fieldErrs = append(fieldErrs, apperror.FieldError{Field: field + ".status", Message: "must be an HTTP status code"})
+		}
+	}
+
+	responseBodySchema, err := schema.Parse(responseSchema, entitySchemas)
+	if err != nil || len(mock.Example) == 0 || (mock.Status != 0 && (mock.Status < 200 || mock.Status >= 300)) {
+		return fieldErrs
+	}
+	var example interface{}
+	if err := json.Unmarshal(mock.Example, &example); err != nil {
+		return append(fieldErrs, apperror.FieldError{Field: prefix + "mock.example", Message: "must be JSON"})
+	}
+	for _, violation := range responseBodySchema.Validate(example) {
+		field := prefix + "mock.example"
+		if violation.Path != "" {
+			field += "." + violation.Path
+		}
+		fieldErrs = append(fieldErrs, apperror.FieldError{Field: field, Message: violation.Message})
+	}
+	return fieldErrs
+}
+
+// normalizeMock returns nil for an absent or null mock, which removes the mock of an endpoint
+func normalizeMock(raw json.RawMessage) json.RawMessage {
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil
+	}
+	return raw
+}
+
+func isHTTPStatus(status int) bool {
+	return status >= 100 && status <= 599
 }
 
-func (s *EndpointService) GetEndpointsByProjectUUID(projectUUID string) ([]models.Endpoint, error) {
+func (s *EndpointService) GetEndpointByUUID(ctx context.Context, uuid string) (*models.Endpoint, error) {
+	endpoint, err := s.repo.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.guard.requireID(ctx, endpoint.ProjectID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (s *EndpointService) GetEndpointsByProjectUUID(ctx context.Context, projectUUID string, filter *models.EndpointFilter, page, limit int) ([]models.Endpoint, int64, error) {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleViewer); err != nil {
+		return nil, 0, err
+	}
+
 	// Validate project exists and get internal ID
 	project, err := s.projectRepo.GetByUUID(projectUUID)
 	if err != nil {
-		return nil, fmt.Errorf("project not found: %w", err)
+		return nil, 0, fmt.Errorf("project not found: %w", err)
 	}
 
-	return s.repo.GetByProjectID(project.ID)
+	filter.ProjectID = project.ID
+	offset := (page - 1) * limit
+	return s.repo.List(filter, limit, offset)
 }
 
-func (s *EndpointService) GetEndpointsByEntityUUID(entityUUID string) ([]models.Endpoint, error) {
+func (s *EndpointService) GetEndpointsByEntityUUID(ctx context.Context, entityUUID string, filter *models.EndpointFilter, page, limit int) ([]models.Endpoint, int64, error) {
 	// Validate entity exists and get internal ID
 	entity, err := s.entityRepo.GetByUUID(entityUUID)
 	if err != nil {
-		return nil, fmt.Errorf("entity not found: %w", err)
+		return nil, 0, fmt.Errorf("entity not found: %w", err)
+	}
+
+	if _, err := s.guard.requireID(ctx, entity.ProjectID, models.ProjectRoleViewer); err != nil {
+		return nil, 0, err
 	}
 
-	return s.repo.GetByEntityID(entity.ID)
+	filter.EntityID = entity.ID
+	offset := (page - 1) * limit
+	return s.repo.List(filter, limit, offset)
 }
 
-func (s *EndpointService) UpdateEndpoint(uuid string, req *models.UpdateEndpointRequest) (*models.Endpoint, error) {
+func (s *EndpointService) UpdateEndpoint(ctx context.Context, uuid string, req *models.UpdateEndpointRequest, precondition *models.Precondition) (*models.Endpoint, error) {
 	endpoint, err := s.repo.GetByUUID(uuid)
 	if err != nil {
 		return nil, err
 	}
 
+	project, err := s.guard.requireID(ctx, endpoint.ProjectID, models.ProjectRoleDeveloper)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPrecondition(precondition, endpoint.Version); err != nil {
+		return nil, err
+	}
+	before := *endpoint
+
 	if req.Name != "" {
 		endpoint.Name = req.Name
 	}
@@ -104,27 +382,59 @@ func (s *EndpointService) UpdateEndpoint(uuid string, req *models.UpdateEndpoint
 	if req.ResponseSchema != nil {
 		endpoint.ResponseSchema = req.ResponseSchema
 	}
+	if req.Mock != nil {
+		endpoint.Mock = normalizeMock(req.Mock)
+	}
 	if req.RequireAuth != nil {
 		endpoint.RequireAuth = *req.RequireAuth
 	}
 
-	// Set updated_by (in future, get from auth context)
-	endpoint.SetUpdatedBy("system")
+	if err := s.checkSchemas(project, endpoint); err != nil {
+		return nil, err
+	}
 
-	err = s.repo.Update(endpoint)
+	existing, err := s.repo.GetByProjectID(project.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update endpoint: %w", err)
+		return nil, err
+	}
+	if err := checkRouteConflicts(endpoint, existing); err != nil {
+		return nil, err
+	}
+
+	endpoint.SetUpdatedBy(auth.Actor(ctx))
+
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Endpoints().Update(endpoint); err != nil {
+			return fmt.Errorf("failed to update endpoint: %w", err)
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEndpoint, endpoint.UUID, models.AuditActionUpdate, &before, endpoint)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return endpoint, nil
 }
 
-func (s *EndpointService) DeleteEndpoint(uuid string) error {
-	_, err := s.repo.GetByUUID(uuid)
+func (s *EndpointService) DeleteEndpoint(ctx context.Context, uuid string, precondition *models.Precondition) error {
+	endpoint, err := s.repo.GetByUUID(uuid)
 	if err != nil {
 		return err
 	}
 
-	// Soft delete with deleted_by (in future, get from auth context)
-	return s.repo.DeleteByUUID(uuid, "system")
+	project, err := s.guard.requireID(ctx, endpoint.ProjectID, models.ProjectRoleDeveloper)
+	if err != nil {
+		return err
+	}
+	if err := checkPrecondition(precondition, endpoint.Version); err != nil {
+		return err
+	}
+
+	// Soft delete with deleted_by
+	return s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Endpoints().DeleteByUUID(uuid, auth.Actor(ctx), endpoint.Version); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEndpoint, endpoint.UUID, models.AuditActionDelete, endpoint, nil)
+	})
 }
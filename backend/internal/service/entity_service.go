@@ -1,32 +1,143 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
 	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
 	"github.com/yourusername/lambra/internal/repository"
 )
 
 type EntityService struct {
 	repo        *repository.EntityRepository
 	projectRepo *repository.ProjectRepository
+	uow         *repository.UnitOfWork
+	audit       *AuditService
+	guard       *projectGuard
 }
 
-func NewEntityService(repo *repository.EntityRepository, projectRepo *repository.ProjectRepository) *EntityService {
+func NewEntityService(
+	repo *repository.EntityRepository,
+	projectRepo *repository.ProjectRepository,
+	uow *repository.UnitOfWork,
+	audit *AuditService,
+	authz rbac.Authorizer,
+) *EntityService {
 	return &EntityService{
 		repo:        repo,
 		projectRepo: projectRepo,
+		uow:         uow,
+		audit:       audit,
+		guard:       newProjectGuard(authz, projectRepo),
 	}
 }
 
-func (s *EntityService) CreateEntity(req *models.CreateEntityRequest) (*models.Entity, error) {
+func (s *EntityService) CreateEntity(ctx context.Context, req *models.CreateEntityRequest) (*models.Entity, error) {
+	if err := s.guard.require(ctx, req.ProjectUUID, models.ProjectRoleDeveloper); err != nil {
+		return nil, err
+	}
+
 	// Validate project exists and get internal ID
 	project, err := s.projectRepo.GetByUUID(req.ProjectUUID)
 	if err != nil {
 		return nil, fmt.Errorf("project not found: %w", err)
 	}
 
+	entity, err := newEntity(ctx, project, req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEntityConflicts(entity, existing); err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Entities().Create(entity); err != nil {
+			return fmt.Errorf("failed to create entity: %w", err)
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEntity, entity.UUID, models.AuditActionCreate, nil, entity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+// BulkCreateEntities creates the entities of a request in one transaction, so either all of them
+// are created or none. Invalid items are reported together as field errors of their index.
+func (s *EntityService) BulkCreateEntities(ctx context.Context, projectUUID string, req *models.BulkCreateEntitiesRequest) ([]models.Entity, error) {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleDeveloper); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Each item joins the batch once it passes, so later items are checked against all earlier ones
+	var fieldErrs []apperror.FieldError
+	batch := make([]models.Entity, 0, len(req.Entities))
+	for i, item := range req.Entities {
+		candidate := models.Entity{Name: item.Name, TableName: item.TableName}
+		candidate.UUID = fmt.Sprintf("entities[%d]", i) // Placeholder, so items are not skipped as the same entity
+		if err := checkEntityConflicts(&candidate, batch); err != nil {
+			message, _ := apperror.Message(err)
+			fieldErrs = append(fieldErrs, apperror.FieldError{Field: fmt.Sprintf("entities[%d]", i), Message: message})
+			continue
+		}
+		batch = append(batch, candidate)
+	}
+	if len(fieldErrs) > 0 {
+		return nil, apperror.Validation("invalid entities", fieldErrs...)
+	}
+
+	existing, err := s.repo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range batch {
+		if err := checkEntityConflicts(&batch[i], existing); err != nil {
+			return nil, apperror.Conflict(fmt.Sprintf("entities[%d]: %s", i, err))
+		}
+	}
+
+	entities := make([]models.Entity, len(req.Entities))
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		for i := range req.Entities {
+			entity, err := newEntity(ctx, project, &req.Entities[i])
+			if err != nil {
+				return err
+			}
+			if err := tx.Entities().Create(entity); err != nil {
+				return fmt.Errorf("failed to create entities[%d]: %w", i, err)
+			}
+			if err := s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEntity, entity.UUID, models.AuditActionCreate, nil, entity); err != nil {
+				return err
+			}
+			entities[i] = *entity
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
+
+// newEntity builds the entity a create request describes
+func newEntity(ctx context.Context, project *models.Project, req *models.CreateEntityRequest) (*models.Entity, error) {
 	// Marshal fields to JSON
 	fieldsJSON, err := json.Marshal(req.Fields)
 	if err != nil {
@@ -45,37 +156,54 @@ func (s *EntityService) CreateEntity(req *models.CreateEntityRequest) (*models.E
 		entity.Description.Valid = true
 	}
 
-	// Set created_by (in future, get from auth context)
-	entity.SetCreatedBy("system")
+	entity.SetCreatedBy(auth.Actor(ctx))
+	return entity, nil
+}
 
-	err = s.repo.Create(entity)
+func (s *EntityService) GetEntityByUUID(ctx context.Context, uuid string) (*models.Entity, error) {
+	entity, err := s.repo.GetByUUID(uuid)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create entity: %w", err)
+		return nil, err
+	}
+
+	if _, err := s.guard.requireID(ctx, entity.ProjectID, models.ProjectRoleViewer); err != nil {
+		return nil, err
 	}
 
 	return entity, nil
 }
 
-func (s *EntityService) GetEntityByUUID(uuid string) (*models.Entity, error) {
-	return s.repo.GetByUUID(uuid)
-}
+func (s *EntityService) GetEntitiesByProjectUUID(ctx context.Context, projectUUID string, filter *models.EntityFilter, page, limit int) ([]models.Entity, int64, error) {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleViewer); err != nil {
+		return nil, 0, err
+	}
 
-func (s *EntityService) GetEntitiesByProjectUUID(projectUUID string) ([]models.Entity, error) {
 	// Validate project exists and get internal ID
 	project, err := s.projectRepo.GetByUUID(projectUUID)
 	if err != nil {
-		return nil, fmt.Errorf("project not found: %w", err)
+		return nil, 0, fmt.Errorf("project not found: %w", err)
 	}
 
-	return s.repo.GetByProjectID(project.ID)
+	filter.ProjectID = project.ID
+	offset := (page - 1) * limit
+	return s.repo.List(filter, limit, offset)
 }
 
-func (s *EntityService) UpdateEntity(uuid string, req *models.UpdateEntityRequest) (*models.Entity, error) {
+func (s *EntityService) UpdateEntity(ctx context.Context, uuid string, req *models.UpdateEntityRequest, precondition *models.Precondition) (*models.Entity, error) {
 	entity, err := s.repo.GetByUUID(uuid)
 	if err != nil {
 		return nil, err
 	}
 
+	project, err := s.guard.requireID(ctx, entity.ProjectID, models.ProjectRoleDeveloper)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPrecondition(precondition, entity.Version); err != nil {
+		return nil, err
+	}
+	before := *entity
+
 	if req.Name != "" {
 		entity.Name = req.Name
 	}
@@ -94,23 +222,52 @@ func (s *EntityService) UpdateEntity(uuid string, req *models.UpdateEntityReques
 		entity.Fields = fieldsJSON
 	}
 
-	// Set updated_by (in future, get from auth context)
-	entity.SetUpdatedBy("system")
+	existing, err := s.repo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEntityConflicts(entity, existing); err != nil {
+		return nil, err
+	}
+
+	entity.SetUpdatedBy(auth.Actor(ctx))
 
-	err = s.repo.Update(entity)
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Entities().Update(entity); err != nil {
+			return fmt.Errorf("failed to update entity: %w", err)
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEntity, entity.UUID, models.AuditActionUpdate, &before, entity)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update entity: %w", err)
+		return nil, err
 	}
 
 	return entity, nil
 }
 
-func (s *EntityService) DeleteEntity(uuid string) error {
-	_, err := s.repo.GetByUUID(uuid)
+func (s *EntityService) DeleteEntity(ctx context.Context, uuid string, precondition *models.Precondition) error {
+	entity, err := s.repo.GetByUUID(uuid)
 	if err != nil {
 		return err
 	}
 
-	// Soft delete with deleted_by (in future, get from auth context)
-	return s.repo.DeleteByUUID(uuid, "system")
+	project, err := s.guard.requireID(ctx, entity.ProjectID, models.ProjectRoleDeveloper)
+	if err != nil {
+		return err
+	}
+	if err := checkPrecondition(precondition, entity.Version); err != nil {
+		return err
+	}
+
+	// Soft delete the entity together with its endpoints
+	actor := auth.Actor(ctx)
+	return s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Entities().DeleteByUUID(uuid, actor, entity.Version); err != nil {
+			return err
+		}
+		if err := tx.Endpoints().DeleteByEntityID(entity.ID, actor, entity.UUID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEntity, entity.UUID, models.AuditActionDelete, entity, nil)
+	})
 }
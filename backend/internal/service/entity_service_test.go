@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+func newTestEntityService(t *testing.T) (*EntityService, sqlmock.Sqlmock) {
+	db, mock := newTestDB(t)
+	return NewEntityService(
+		repository.NewEntityRepository(db),
+		repository.NewProjectRepository(db),
+		repository.NewUnitOfWork(db),
+		&AuditService{},
+		roleAuthorizer{role: models.ProjectRoleDeveloper},
+	), mock
+}
+
+func bulkEntities(names ...string) *models.BulkCreateEntitiesRequest {
+	req := &models.BulkCreateEntitiesRequest{}
+	for i := 0; i+1 < len(names); i += 2 {
+		req.Entities = append(req.Entities, models.CreateEntityRequest{
+			Name:      names[i],
+			TableName: names[i+1],
+			Fields:    []models.EntityField{{Name: "id", Type: "string"}},
+		})
+	}
+	return req
+}
+
+func expectProjectByUUID(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`FROM projects\s+WHERE uuid = \? AND deleted_at IS NULL`).WithArgs("prj-1").
+		WillReturnRows(sqlmock.NewRows(projectRows).AddRow(1, "prj-1", "Shop", "shop", 7, 2))
+}
+
+func TestEntityService_BulkCreateRejectsDuplicatesInBatch(t *testing.T) {
+	svc, mock := newTestEntityService(t)
+	expectProjectByUUID(mock)
+
+	// Nothing is read or written past the project: every item is checked against the batch first
+	req := bulkEntities("Order", "orders", "Customer", "customers", "Order", "order_copies", "Invoice", "ORDERS")
+	_, err := svc.BulkCreateEntities(userContext("alice"), "prj-1", req)
+	if !errors.Is(err, apperror.ErrValidation) {
+		t.Fatalf("BulkCreateEntities() error = %v, want a validation error", err)
+	}
+
+	fields := apperror.Fields(err)
+	if len(fields) != 2 || fields[0].Field != "entities[2]" || fields[1].Field != "entities[3]" {
+		t.Errorf("BulkCreateEntities() fields = %v, want entities[2] and entities[3]", fields)
+	}
+}
+
+func TestEntityService_BulkCreateConflictsWithExisting(t *testing.T) {
+	svc, mock := newTestEntityService(t)
+	expectProjectByUUID(mock)
+	mock.ExpectQuery(`FROM entities\s+WHERE project_id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(entityRows).AddRow(11, "ent-2", 1, "Customer", "customers", 1))
+
+	_, err := svc.BulkCreateEntities(userContext("alice"), "prj-1", bulkEntities("Order", "orders", "Customer", "clients"))
+	if !errors.Is(err, apperror.ErrConflict) {
+		t.Fatalf("BulkCreateEntities() error = %v, want a conflict", err)
+	}
+	if message, _ := apperror.Message(err); !strings.HasPrefix(message, "entities[1]") {
+		t.Errorf("BulkCreateEntities() message = %q, want it to name entities[1]", message)
+	}
+}
+
+func TestEntityService_BulkCreateRollsBack(t *testing.T) {
+	svc, mock := newTestEntityService(t)
+	expectProjectByUUID(mock)
+	mock.ExpectQuery(`FROM entities\s+WHERE project_id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(entityRows))
+	mock.ExpectBegin()
+	for i, name := range []string{"Order", "Customer"} {
+		mock.ExpectExec(`INSERT INTO entities`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`FROM entities\s+WHERE uuid = \?`).
+			WillReturnRows(sqlmock.NewRows(entityRows).AddRow(i+1, name, 1, name, name, 1))
+		mock.ExpectExec(`INSERT INTO audit_logs`).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec(`INSERT INTO entities`).WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	req := bulkEntities("Order", "orders", "Customer", "customers", "Invoice", "invoices")
+	entities, err := svc.BulkCreateEntities(userContext("alice"), "prj-1", req)
+	if err == nil {
+		t.Fatal("BulkCreateEntities() error = nil, want the failed insert")
+	}
+	if entities != nil {
+		t.Errorf("BulkCreateEntities() = %v, want no entities", entities)
+	}
+}
+
+func TestEntityService_BulkCreate(t *testing.T) {
+	svc, mock := newTestEntityService(t)
+	expectProjectByUUID(mock)
+	mock.ExpectQuery(`FROM entities\s+WHERE project_id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(entityRows))
+	mock.ExpectBegin()
+	for i, name := range []string{"Order", "Customer"} {
+		mock.ExpectExec(`INSERT INTO entities`).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1), name, sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`FROM entities\s+WHERE uuid = \?`).
+			WillReturnRows(sqlmock.NewRows(entityRows).AddRow(i+1, name, 1, name, name, 1))
+		mock.ExpectExec(`INSERT INTO audit_logs`).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	entities, err := svc.BulkCreateEntities(userContext("alice"), "prj-1", bulkEntities("Order", "orders", "Customer", "customers"))
+	if err != nil {
+		t.Fatalf("BulkCreateEntities() error = %v", err)
+	}
+	if len(entities) != 2 || entities[0].Name != "Order" || entities[1].Name != "Customer" {
+		t.Errorf("BulkCreateEntities() = %v, want Order and Customer in request order", entities)
+	}
+}
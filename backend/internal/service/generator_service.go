@@ -1,11 +1,14 @@
 package service
 
 import (
+	"archive/zip"
 	"context"
 	"fmt"
+	"io"
 	"path/filepath"
 
-	"github.com/yourusername/lambra/internal/generator"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
 	"github.com/yourusername/lambra/internal/repository"
 )
 
@@ -14,7 +17,11 @@ type GeneratorService struct {
 	projectRepo  *repository.ProjectRepository
 	entityRepo   *repository.EntityRepository
 	endpointRepo *repository.EndpointRepository
-	generator    *generator.CodeGenerator
+	configRepo   *repository.ConfigRepository
+	teamRepo     *repository.TeamRepository
+	templateRepo *repository.TemplateRepository
+	renderer     *Renderer
+	guard        *projectGuard
 }
 
 // NewGeneratorService creates a new generator service
@@ -22,12 +29,20 @@ func NewGeneratorService(
 	projectRepo *repository.ProjectRepository,
 	entityRepo *repository.EntityRepository,
 	endpointRepo *repository.EndpointRepository,
+	configRepo *repository.ConfigRepository,
+	teamRepo *repository.TeamRepository,
+	templateRepo *repository.TemplateRepository,
+	authz rbac.Authorizer,
 ) *GeneratorService {
 	return &GeneratorService{
 		projectRepo:  projectRepo,
 		entityRepo:   entityRepo,
 		endpointRepo: endpointRepo,
-		generator:    generator.NewCodeGenerator(),
+		configRepo:   configRepo,
+		teamRepo:     teamRepo,
+		templateRepo: templateRepo,
+		renderer:     NewRenderer(),
+		guard:        newProjectGuard(authz, projectRepo),
 	}
 }
 
@@ -55,6 +70,11 @@ type GeneratedFile struct {
 
 // GenerateEntity generates code for a specific entity
 func (s *GeneratorService) GenerateEntity(ctx context.Context, entityID int64, outputDir string) (*GenerateCodeResponse, error) {
+	return s.generateEntity(ctx, entityID, outputDir, models.ProjectRoleDeveloper)
+}
+
+// generateEntity loads an entity and its project, checks the role of the current user and renders the entity
+func (s *GeneratorService) generateEntity(ctx context.Context, entityID int64, outputDir, role string) (*GenerateCodeResponse, error) {
 	// Get entity
 	entity, err := s.entityRepo.GetByID(entityID)
 	if err != nil {
@@ -67,86 +87,50 @@ func (s *GeneratorService) GenerateEntity(ctx context.Context, entityID int64, o
 		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
-	// Prepare generation context
-	genCtx, err := s.generator.PrepareContext(project, entity)
-	if err != nil {
-		return nil, fmt.Errorf("failed to prepare context: %w", err)
+	if err := s.guard.require(ctx, project.UUID, role); err != nil {
+		return nil, err
 	}
 
-	// Validate context
-	if err := s.generator.ValidateContext(genCtx); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+	templates, err := s.teamTemplates(project)
+	if err != nil {
+		return nil, err
 	}
 
-	// Generate code
-	var files []GeneratedFile
-
-	// Generate model
-	if code, err := s.generator.GenerateModel(genCtx); err == nil {
-		files = append(files, GeneratedFile{
-			Path:    filepath.Join(outputDir, "models", fmt.Sprintf("%s.go", generator.ToSnakeCase(entity.Name))),
-			Content: code,
-			Layer:   "model",
-		})
-	}
+	return s.renderer.RenderEntity(project, entity, templates, outputDir)
+}
 
-	// Generate repository
-	if code, err := s.generator.GenerateRepository(genCtx); err == nil {
-		files = append(files, GeneratedFile{
-			Path:    filepath.Join(outputDir, "repository", fmt.Sprintf("%s_repository.go", generator.ToSnakeCase(entity.Name))),
-			Content: code,
-			Layer:   "repository",
-		})
+// teamTemplates returns the template set of the project's team by layer, nil without a team.
+// Templates managed by other teams are left out.
+func (s *GeneratorService) teamTemplates(project *models.Project) (map[string]string, error) {
+	if !project.TeamID.Valid {
+		return nil, nil
 	}
 
-	// Generate service
-	if code, err := s.generator.GenerateService(genCtx); err == nil {
-		files = append(files, GeneratedFile{
-			Path:    filepath.Join(outputDir, "service", fmt.Sprintf("%s_service.go", generator.ToSnakeCase(entity.Name))),
-			Content: code,
-			Layer:   "service",
-		})
+	team, err := s.teamRepo.GetByID(project.TeamID.Int64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
 
-	// Generate handler
-	if code, err := s.generator.GenerateHandler(genCtx); err == nil {
-		files = append(files, GeneratedFile{
-			Path:    filepath.Join(outputDir, "api/handlers", fmt.Sprintf("%s_handler.go", generator.ToSnakeCase(entity.Name))),
-			Content: code,
-			Layer:   "handler",
-		})
+	templateSet := team.GetSettings().TemplateSet
+	if len(templateSet) == 0 {
+		return nil, nil
 	}
 
-	// Generate DTO
-	if code, err := s.generator.GenerateDTO(genCtx); err == nil {
-		files = append(files, GeneratedFile{
-			Path:    filepath.Join(outputDir, "api/dto", fmt.Sprintf("%s_dto.go", generator.ToSnakeCase(entity.Name))),
-			Content: code,
-			Layer:   "dto",
-		})
+	templates, err := s.templateRepo.GetByUUIDs(templateSet)
+	if err != nil {
+		return nil, err
 	}
 
-	// Generate migration
-	up, down, err := s.generator.GenerateMigration(genCtx)
-	if err == nil {
-		files = append(files, GeneratedFile{
-			Path:    filepath.Join(outputDir, "migrations", fmt.Sprintf("create_%s.up.sql", generator.ToSnakeCase(entity.TableName))),
-			Content: up,
-			Layer:   "migration",
-		})
-		files = append(files, GeneratedFile{
-			Path:    filepath.Join(outputDir, "migrations", fmt.Sprintf("create_%s.down.sql", generator.ToSnakeCase(entity.TableName))),
-			Content: down,
-			Layer:   "migration",
-		})
+	byLayer := make(map[string]string, len(templates))
+	for _, template := range templates {
+		// Settings saved before templates belonged to teams may still refer to another team's templates
+		if template.TeamID.Valid && template.TeamID.Int64 != project.TeamID.Int64 {
+			continue
+		}
+		byLayer[template.Type] = template.Content
 	}
 
-	return &GenerateCodeResponse{
-		Files:    files,
-		EntityID: entityID,
-		Success:  true,
-		Message:  fmt.Sprintf("Successfully generated %d files for entity %s", len(files), entity.Name),
-	}, nil
+	return byLayer, nil
 }
 
 // GenerateProject generates code for all entities in a project
@@ -157,25 +141,40 @@ func (s *GeneratorService) GenerateProject(ctx context.Context, projectID int64,
 		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
+	if err := s.guard.require(ctx, project.UUID, models.ProjectRoleDeveloper); err != nil {
+		return nil, err
+	}
+
 	// Get all entities for the project
 	entities, err := s.entityRepo.GetByProjectID(projectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get entities: %w", err)
 	}
 
-	if len(entities) == 0 {
-		return nil, fmt.Errorf("no entities found for project")
+	endpoints, err := s.endpointRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoints: %w", err)
 	}
 
-	var allFiles []GeneratedFile
+	configs, err := s.environmentConfigs(projectID)
+	if err != nil {
+		return nil, err
+	}
 
-	// Generate code for each entity
-	for _, entity := range entities {
-		response, err := s.GenerateEntity(ctx, entity.ID, outputDir)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate entity %s: %w", entity.Name, err)
-		}
-		allFiles = append(allFiles, response.Files...)
+	templates, err := s.teamTemplates(project)
+	if err != nil {
+		return nil, err
+	}
+
+	allFiles, err := s.renderer.RenderProject(&ProjectDefinition{
+		Project:   project,
+		Entities:  entities,
+		Endpoints: endpoints,
+		Configs:   configs,
+		Templates: templates,
+	}, outputDir)
+	if err != nil {
+		return nil, err
 	}
 
 	return &GenerateCodeResponse{
@@ -185,9 +184,66 @@ func (s *GeneratorService) GenerateProject(ctx context.Context, projectID int64,
 	}, nil
 }
 
+// GenerateProjectByUUID generates code for all entities of the project with a UUID
+func (s *GeneratorService) GenerateProjectByUUID(ctx context.Context, uuid string, outputDir string) (*GenerateCodeResponse, error) {
+	project, err := s.projectRepo.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GenerateProject(ctx, project.ID, outputDir)
+}
+
+// WriteArchive writes generated files as a zip archive
+func WriteArchive(w io.Writer, files []GeneratedFile) error {
+	archive := zip.NewWriter(w)
+	for _, file := range files {
+		entry, err := archive.Create(filepath.ToSlash(file.Path))
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", file.Path, err)
+		}
+		if _, err := io.WriteString(entry, file.Content); err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", file.Path, err)
+		}
+	}
+	return archive.Close()
+}
+
+// GenerateKubernetesManifests renders Kubernetes manifests of a project for every environment.
+// The version tags the container image; an empty version uses "latest".
+func (s *GeneratorService) GenerateKubernetesManifests(project *models.Project, version string, outputDir string) ([]GeneratedFile, error) {
+	configs, err := s.environmentConfigs(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderer.RenderKubernetesManifests(project, configs, version, outputDir)
+}
+
+// GenerateHelmChart renders the Helm chart of a project under helm/<name>.
+// The version becomes the chart version and image tag; an empty version uses "latest".
+func (s *GeneratorService) GenerateHelmChart(project *models.Project, version string, outputDir string) ([]GeneratedFile, error) {
+	configs, err := s.environmentConfigs(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderer.RenderHelmChart(project, configs, version, outputDir)
+}
+
+// environmentConfigs loads the stored variables of a project per environment, secrets by name only
+func (s *GeneratorService) environmentConfigs(projectID int64) (map[string]*models.EnvironmentConfig, error) {
+	variables, err := s.configRepo.GetByProjectID(projectID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return environmentConfigs(variables), nil
+}
+
 // PreviewEntity generates code preview without writing files
 func (s *GeneratorService) PreviewEntity(ctx context.Context, entityID int64) (*GenerateCodeResponse, error) {
-	return s.GenerateEntity(ctx, entityID, "")
+	return s.generateEntity(ctx, entityID, "", models.ProjectRoleViewer)
 }
 
 // GetGeneratedFilesList returns list of files that will be generated
@@ -197,5 +253,9 @@ func (s *GeneratorService) GetGeneratedFilesList(ctx context.Context, entityID i
 		return nil, fmt.Errorf("failed to get entity: %w", err)
 	}
 
-	return s.generator.GetGeneratedFiles(entity.Name), nil
+	if _, err := s.guard.requireID(ctx, entity.ProjectID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	return s.renderer.generator.GetGeneratedFiles(entity.Name), nil
 }
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/config"
+	"github.com/yourusername/lambra/internal/gitprovider"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+// Default branch layout for repositories provisioned by Lambra
+const (
+	defaultBranchName    = "main"
+	developBranchName    = "develop"
+	stagingBranchName    = "staging"
+	productionBranchName = "production"
+
+	// generatedBranchPrefix marks branches created by PublishGeneratedCode
+	generatedBranchPrefix = "lambra/"
+)
+
+// remoteCleanupTimeout bounds deleting a remote repository whose setup failed
+const remoteCleanupTimeout = 30 * time.Second
+
+// GitService provisions repositories on git providers and publishes generated code to them
+type GitService struct {
+	gitRepo          *repository.GitRepositoryRepository
+	projectRepo      *repository.ProjectRepository
+	generatorService *GeneratorService
+	providers        *gitprovider.Registry
+	cfg              *config.GitConfig
+	guard            *projectGuard
+}
+
+// NewGitService creates a new git service
+func NewGitService(
+	gitRepo *repository.GitRepositoryRepository,
+	projectRepo *repository.ProjectRepository,
+	generatorService *GeneratorService,
+	providers *gitprovider.Registry,
+	cfg *config.GitConfig,
+	authz rbac.Authorizer,
+) *GitService {
+	return &GitService{
+		gitRepo:          gitRepo,
+		projectRepo:      projectRepo,
+		generatorService: generatorService,
+		providers:        providers,
+		cfg:              cfg,
+		guard:            newProjectGuard(authz, projectRepo),
+	}
+}
+
+// PublishCodeResponse represents the result of pushing generated code to the git provider
+type PublishCodeResponse struct {
+	Changed         bool     `json:"changed"`
+	Branch          string   `json:"branch,omitempty"`
+	CommitHash      string   `json:"commit_hash,omitempty"`
+	MergeRequestURL string   `json:"merge_request_url,omitempty"`
+	CreatedFiles    []string `json:"created_files,omitempty"`
+	UpdatedFiles    []string `json:"updated_files,omitempty"`
+	Message         string   `json:"message"`
+}
+
+// CreateRepository creates the remote repository for a Lambra project, sets up the
+// environment branches and protects the production branch
+func (s *GitService) CreateRepository(ctx context.Context, req *models.CreateGitRepositoryRequest) (*models.GitRepository, error) {
+	if err := s.guard.require(ctx, req.ProjectUUID, models.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+
+	providerName := req.Provider
+	if providerName == "" {
+		providerName = s.cfg.DefaultProvider
+	}
+
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByUUID(req.ProjectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if project.GitRepoID.Valid {
+		return nil, apperror.Conflict("project already has a git repository")
+	}
+
+	remote, err := provider.CreateRepository(ctx, &gitprovider.CreateRepositoryRequest{
+		Name:          req.RepoName,
+		Description:   req.Description,
+		DefaultBranch: defaultBranchName,
+		Private:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	gitRepository, err := s.setUpRepository(ctx, provider, project, remote, req.RepoName)
+	if err != nil {
+		// Nothing refers to the remote repository yet, so it would be left behind unnoticed
+		s.deleteRemote(ctx, provider, remote)
+		return nil, err
+	}
+
+	if err := s.projectRepo.UpdateGitRepoID(project.ID, gitRepository.ID); err != nil {
+		return nil, err
+	}
+
+	return gitRepository, nil
+}
+
+// setUpRepository creates the environment branches of a new remote repository, protects the
+// production branch and records the repository
+func (s *GitService) setUpRepository(ctx context.Context, provider gitprovider.Provider, project *models.Project, remote *gitprovider.Repository, repoName string) (*models.GitRepository, error) {
+	baseBranch := remote.DefaultBranch
+	if baseBranch == "" {
+		baseBranch = defaultBranchName
+	}
+
+	for _, branch := range []string{developBranchName, stagingBranchName, productionBranchName} {
+		if err := provider.CreateBranch(ctx, remote, branch, baseBranch); err != nil {
+			return nil, err
+		}
+	}
+
+	// Production only receives changes through reviewed merge requests
+	if err := provider.ProtectBranch(ctx, remote, productionBranchName); err != nil {
+		return nil, err
+	}
+
+	gitRepository := &models.GitRepository{
+		ProjectID:        project.ID,
+		Provider:         provider.Name(),
+		RepoURL:          remote.WebURL,
+		RepoName:         repoName,
+		RepoPath:         remote.FullName,
+		GitLabRepoID:     remote.ID,
+		DefaultBranch:    baseBranch,
+		DevelopBranch:    developBranchName,
+		StagingBranch:    stagingBranchName,
+		ProductionBranch: productionBranchName,
+	}
+
+	gitRepository.SetCreatedBy(auth.Actor(ctx))
+
+	if err := s.gitRepo.Create(gitRepository); err != nil {
+		return nil, fmt.Errorf("failed to save git repository: %w", err)
+	}
+
+	return gitRepository, nil
+}
+
+// deleteRemote removes a remote repository whose setup failed. It outlives a canceled request,
+// since the request failing is what leaves the repository behind.
+func (s *GitService) deleteRemote(ctx context.Context, provider gitprovider.Provider, remote *gitprovider.Repository) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), remoteCleanupTimeout)
+	defer cancel()
+
+	if err := provider.DeleteRepository(ctx, remote); err != nil {
+		log.Printf("failed to delete %s repository %s after its setup failed: %v", provider.Name(), remote.FullName, err)
+	}
+}
+
+// GetRepositoryByProjectUUID retrieves the git repository of a project
+func (s *GitService) GetRepositoryByProjectUUID(ctx context.Context, projectUUID string) (*models.GitRepository, error) {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	return s.gitRepo.GetByProjectID(project.ID)
+}
+
+// PublishGeneratedCode regenerates the project code and, when it differs from the
+// develop branch, pushes it to a new branch and opens a merge request into develop
+func (s *GitService) PublishGeneratedCode(ctx context.Context, projectUUID string) (*PublishCodeResponse, error) {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	gitRepository, err := s.gitRepo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.providers.Get(gitRepository.Provider)
+	if err != nil {
+		return nil, err
+	}
+	remote := gitprovider.RepositoryFromModel(gitRepository)
+
+	generated, err := s.generatorService.GenerateProject(ctx, project.ID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PublishCodeResponse{}
+	var changes []gitprovider.FileChange
+
+	for _, file := range generated.Files {
+		filePath := filepath.ToSlash(file.Path)
+
+		existing, err := provider.GetFile(ctx, remote, filePath, gitRepository.DevelopBranch)
+		switch {
+		case errors.Is(err, gitprovider.ErrNotFound):
+			changes = append(changes, gitprovider.FileChange{Operation: gitprovider.FileOperationCreate, Path: filePath, Content: file.Content})
+			result.CreatedFiles = append(result.CreatedFiles, filePath)
+		case err != nil:
+			return nil, err
+		case !bytes.Equal(existing.Content, []byte(file.Content)):
+			changes = append(changes, gitprovider.FileChange{Operation: gitprovider.FileOperationUpdate, Path: filePath, Content: file.Content, SHA: existing.SHA})
+			result.UpdatedFiles = append(result.UpdatedFiles, filePath)
+		}
+	}
+
+	if len(changes) == 0 {
+		result.Message = "Generated code is up to date"
+		return result, nil
+	}
+
+	branch := fmt.Sprintf("%sgenerate-%s", generatedBranchPrefix, time.Now().UTC().Format("20060102150405"))
+	commit, err := provider.Push(ctx, remote, &gitprovider.PushRequest{
+		Branch:     branch,
+		BaseBranch: gitRepository.DevelopBranch,
+		Message:    fmt.Sprintf("Regenerate %s from Lambra definitions", project.Name),
+		Changes:    changes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mr, err := provider.OpenMergeRequest(ctx, remote, &gitprovider.MergeRequestOptions{
+		SourceBranch: branch,
+		TargetBranch: gitRepository.DevelopBranch,
+		Title:        fmt.Sprintf("Regenerate %s", project.Name),
+		Description:  fmt.Sprintf("Generated by Lambra: %d file(s) created, %d file(s) updated.", len(result.CreatedFiles), len(result.UpdatedFiles)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The project stays in generating state until the merge request lands upstream
+	if err := s.projectRepo.UpdateStatusByUUID(project.UUID, models.ProjectStatusGenerating); err != nil {
+		return nil, err
+	}
+
+	result.Changed = true
+	result.Branch = branch
+	result.CommitHash = commit.SHA
+	result.MergeRequestURL = mr.URL
+	result.Message = fmt.Sprintf("Opened merge request with %d changed file(s)", len(changes))
+
+	return result, nil
+}
+
+// HandleWebhook applies a verified provider webhook event. Pushes and merges
+// into the develop branch update the last known commit; merging or closing a
+// Lambra-generated branch returns the project to active. Events of repositories
+// Lambra does not track are ignored, so providers do not retry them.
+func (s *GitService) HandleWebhook(providerName string, event *gitprovider.WebhookEvent) error {
+	gitRepository, err := s.gitRepo.GetByRemoteID(providerName, event.RepoID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if event.Branch != gitRepository.DevelopBranch {
+		return nil
+	}
+
+	if event.CommitHash != "" && event.Type != gitprovider.EventClose {
+		if err := s.gitRepo.UpdateLastCommitHash(gitRepository.ID, event.CommitHash, providerName); err != nil {
+			return err
+		}
+	}
+
+	// A closed merge request will not land, so the project stops waiting for it
+	settles := event.Type == gitprovider.EventMerge || event.Type == gitprovider.EventClose
+	if settles && strings.HasPrefix(event.SourceBranch, generatedBranchPrefix) {
+		project, err := s.projectRepo.GetByID(gitRepository.ProjectID)
+		if err != nil {
+			return err
+		}
+		if project.Status == models.ProjectStatusGenerating {
+			return s.projectRepo.UpdateStatusByUUID(project.UUID, models.ProjectStatusActive)
+		}
+	}
+
+	return nil
+}
+
+// GetProvider returns a configured git provider by name
+func (s *GitService) GetProvider(name string) (gitprovider.Provider, error) {
+	return s.providers.Get(name)
+}
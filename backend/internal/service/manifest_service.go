@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/manifest"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
+	"github.com/yourusername/lambra/internal/repository"
+	"github.com/yourusername/lambra/internal/schema"
+)
+
+type ManifestService struct {
+	projectRepo  *repository.ProjectRepository
+	entityRepo   *repository.EntityRepository
+	endpointRepo *repository.EndpointRepository
+	uow          *repository.UnitOfWork
+	audit        *AuditService
+	guard        *projectGuard
+}
+
+func NewManifestService(
+	projectRepo *repository.ProjectRepository,
+	entityRepo *repository.EntityRepository,
+	endpointRepo *repository.EndpointRepository,
+	uow *repository.UnitOfWork,
+	audit *AuditService,
+	authz rbac.Authorizer,
+) *ManifestService {
+	return &ManifestService{
+		projectRepo:  projectRepo,
+		entityRepo:   entityRepo,
+		endpointRepo: endpointRepo,
+		uow:          uow,
+		audit:        audit,
+		guard:        newProjectGuard(authz, projectRepo),
+	}
+}
+
+// projectState is the current state of a project with its manifest
+type projectState struct {
+	project   *models.Project
+	entities  map[string]*models.Entity   // By name
+	endpoints map[string]*models.Endpoint // By entity name and endpoint key
+	manifest  *manifest.Manifest
+}
+
+func endpointStateKey(entity, key string) string {
+	return entity + " " + key
+}
+
+func (s *ManifestService) load(uuid string) (*projectState, error) {
+	project, err := s.projectRepo.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := s.entityRepo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := s.endpointRepo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := manifest.FromProject(project, entities, endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &projectState{
+		project:   project,
+		entities:  make(map[string]*models.Entity, len(entities)),
+		endpoints: make(map[string]*models.Endpoint, len(endpoints)),
+		manifest:  m,
+	}
+	names := make(map[int64]string, len(entities))
+	for i := range entities {
+		state.entities[entities[i].Name] = &entities[i]
+		names[entities[i].ID] = entities[i].Name
+	}
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+		state.endpoints[endpointStateKey(names[endpoint.EntityID], endpoint.Method+" "+endpoint.Path)] = endpoint
+	}
+	return state, nil
+}
+
+// ExportProject returns the manifest of the current state of a project
+func (s *ManifestService) ExportProject(ctx context.Context, uuid string) (*manifest.Manifest, error) {
+	if err := s.guard.require(ctx, uuid, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	state, err := s.load(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return state.manifest, nil
+}
+
+// ApplyManifest makes a project match a manifest in one transaction and returns the changes it
+// made. Applying the same manifest again changes nothing. With dryRun the changes are only
+// computed.
+func (s *ManifestService) ApplyManifest(ctx context.Context, uuid string, m *manifest.Manifest, dryRun bool) (*manifest.Plan, error) {
+	if err := s.guard.require(ctx, uuid, models.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	state, err := s.load(uuid)
+	if err != nil {
+		return nil, err
+	}
+	if m.Metadata.Namespace != state.project.Namespace {
+		return nil, apperror.Validation("invalid manifest", apperror.FieldError{
+			Field:   "metadata.namespace",
+			Message: "does not match the namespace of the project",
+		})
+	}
+	if err := CheckManifestSchemas(m); err != nil {
+		return nil, err
+	}
+
+	plan, err := manifest.Diff(state.manifest, m)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun || plan.Empty() {
+		return plan, nil
+	}
+
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		applier := &manifestApplier{ctx: ctx, tx: tx, state: state, desired: m}
+		for _, change := range plan.Changes {
+			if err := applier.apply(change); err != nil {
+				return err
+			}
+		}
+		if err := applier.checkConflicts(); err != nil {
+			return err
+		}
+		for _, record := range applier.records {
+			if err := s.audit.Record(ctx, tx, state.project.UUID, record.resourceType, record.resourceID, record.action, record.before, record.after); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply manifest: %w", err)
+	}
+
+	return plan, nil
+}
+
+// CheckManifestSchemas validates the endpoint schemas and mocks of a manifest against the entities it declares
+func CheckManifestSchemas(m *manifest.Manifest) error {
+	_, entities, endpoints, err := m.ToProject()
+	if err != nil {
+		return err
+	}
+	entitySchemas, err := schema.EntitySchemas(entities)
+	if err != nil {
+		return err
+	}
+
+	// ToProject lists endpoints entity by entity in manifest order
+	var fieldErrs []apperror.FieldError
+	next := 0
+	for i, entity := range m.Spec.Entities {
+		for j := range entity.Endpoints {
+			endpoint := endpoints[next]
+			next++
+			prefix := fmt.Sprintf("spec.entities[%d].endpoints[%d].", i, j)
+			fieldErrs = append(fieldErrs, schemaErrors(prefix, endpoint.RequestSchema, endpoint.ResponseSchema, entitySchemas)...)
+			fieldErrs = append(fieldErrs, mockErrors(prefix, endpoint.Mock, endpoint.ResponseSchema, entitySchemas)...)
+		}
+	}
+	if len(fieldErrs) > 0 {
+		return apperror.Validation("invalid manifest", fieldErrs...)
+	}
+	return nil
+}
+
+// auditRecord is an audit entry recorded once all changes of a manifest are applied
+type auditRecord struct {
+	resourceType string
+	resourceID   string
+	action       string
+	before       interface{}
+	after        interface{}
+}
+
+// manifestApplier applies the changes of a plan inside a transaction
+type manifestApplier struct {
+	ctx     context.Context
+	tx      *repository.Tx
+	state   *projectState
+	desired *manifest.Manifest
+	records []auditRecord
+}
+
+func (a *manifestApplier) apply(change manifest.Change) error {
+	actor := auth.Actor(a.ctx)
+
+	switch change.Resource {
+	case manifest.ResourceProject:
+		project := a.state.project
+		before := *project
+		if err := a.desired.ApplyTo(project); err != nil {
+			return err
+		}
+		project.SetUpdatedBy(actor)
+		if err := a.tx.Projects().Update(project); err != nil {
+			return err
+		}
+		a.record(models.AuditResourceProject, project.UUID, models.AuditActionUpdate, &before, project)
+
+	case manifest.ResourceEntity:
+		switch change.Action {
+		case manifest.ActionDelete:
+			entity := a.state.entities[change.Entity]
+			if err := a.tx.Entities().DeleteByUUID(entity.UUID, actor, entity.Version); err != nil {
+				return err
+			}
+			if err := a.tx.Endpoints().DeleteByEntityID(entity.ID, actor, entity.UUID); err != nil {
+				return err
+			}
+			a.record(models.AuditResourceEntity, entity.UUID, models.AuditActionDelete, entity, nil)
+			delete(a.state.entities, change.Entity)
+
+		case manifest.ActionCreate:
+			spec := a.desired.Entity(change.Entity)
+			entity := &models.Entity{ProjectID: a.state.project.ID}
+			if err := spec.ApplyTo(entity); err != nil {
+				return err
+			}
+			entity.SetCreatedBy(actor)
+			if err := a.tx.Entities().Create(entity); err != nil {
+				return err
+			}
+			a.record(models.AuditResourceEntity, entity.UUID, models.AuditActionCreate, nil, entity)
+			a.state.entities[change.Entity] = entity
+
+		case manifest.ActionUpdate:
+			entity := a.state.entities[change.Entity]
+			before := *entity
+			if err := a.desired.Entity(change.Entity).ApplyTo(entity); err != nil {
+				return err
+			}
+			entity.SetUpdatedBy(actor)
+			if err := a.tx.Entities().Update(entity); err != nil {
+				return err
+			}
+			a.record(models.AuditResourceEntity, entity.UUID, models.AuditActionUpdate, &before, entity)
+		}
+
+	case manifest.ResourceEndpoint:
+		key := endpointStateKey(change.Entity, change.Endpoint)
+		switch change.Action {
+		case manifest.ActionDelete:
+			endpoint := a.state.endpoints[key]
+			if err := a.tx.Endpoints().DeleteByUUID(endpoint.UUID, actor, endpoint.Version); err != nil {
+				return err
+			}
+			a.record(models.AuditResourceEndpoint, endpoint.UUID, models.AuditActionDelete, endpoint, nil)
+			delete(a.state.endpoints, key)
+
+		case manifest.ActionCreate:
+			entity := a.state.entities[change.Entity]
+			endpoint := &models.Endpoint{EntityID: entity.ID, ProjectID: a.state.project.ID}
+			if err := a.desired.Entity(change.Entity).Endpoint(change.Endpoint).ApplyTo(endpoint); err != nil {
+				return err
+			}
+			endpoint.SetCreatedBy(actor)
+			if err := a.tx.Endpoints().Create(endpoint); err != nil {
+				return err
+			}
+			a.record(models.AuditResourceEndpoint, endpoint.UUID, models.AuditActionCreate, nil, endpoint)
+			a.state.endpoints[key] = endpoint
+
+		case manifest.ActionUpdate:
+			endpoint := a.state.endpoints[key]
+			before := *endpoint
+			if err := a.desired.Entity(change.Entity).Endpoint(change.Endpoint).ApplyTo(endpoint); err != nil {
+				return err
+			}
+			endpoint.SetUpdatedBy(actor)
+			if err := a.tx.Endpoints().Update(endpoint); err != nil {
+				return err
+			}
+			a.record(models.AuditResourceEndpoint, endpoint.UUID, models.AuditActionUpdate, &before, endpoint)
+		}
+	}
+
+	return nil
+}
+
+// checkConflicts runs the conflict checks of the entity and endpoint services on the state the
+// changes leave behind, so a manifest cannot create what the API would reject
+func (a *manifestApplier) checkConflicts() error {
+	entities, err := a.tx.Entities().GetByProjectID(a.state.project.ID)
+	if err != nil {
+		return err
+	}
+	for i := range entities {
+		if err := checkEntityConflicts(&entities[i], entities); err != nil {
+			return err
+		}
+	}
+
+	endpoints, err := a.tx.Endpoints().GetByProjectID(a.state.project.ID)
+	if err != nil {
+		return err
+	}
+	for i := range endpoints {
+		if err := checkRouteConflicts(&endpoints[i], endpoints); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *manifestApplier) record(resourceType, resourceID, action string, before, after interface{}) {
+	a.records = append(a.records, auditRecord{
+		resourceType: resourceType,
+		resourceID:   resourceID,
+		action:       action,
+		before:       before,
+		after:        after,
+	})
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
+	"github.com/yourusername/lambra/internal/repository"
+	"github.com/yourusername/lambra/internal/schema"
+)
+
+// Items of synthesized list responses of endpoints without a response schema
+const mockListItems = 2
+
+// MockService answers requests to the endpoints of a project from their definitions, so clients can
+// be built before the service is generated and deployed
+type MockService struct {
+	projectRepo  *repository.ProjectRepository
+	entityRepo   *repository.EntityRepository
+	endpointRepo *repository.EndpointRepository
+	guard        *projectGuard
+}
+
+func NewMockService(
+	projectRepo *repository.ProjectRepository,
+	entityRepo *repository.EntityRepository,
+	endpointRepo *repository.EndpointRepository,
+	authz rbac.Authorizer,
+) *MockService {
+	return &MockService{
+		projectRepo:  projectRepo,
+		entityRepo:   entityRepo,
+		endpointRepo: endpointRepo,
+		guard:        newProjectGuard(authz, projectRepo),
+	}
+}
+
+// Serve answers a request to the mock of a project the way the generated service would: it picks
+// the endpoint whose route matches, rejects requests without a bearer token when the endpoint
+// requires auth and request bodies that do not match the request schema, then returns the example
+// of the endpoint or a response synthesized from its response schema. A scenario named by the
+// request replaces all of this with the response it configures.
+func (s *MockService) Serve(ctx context.Context, projectUUID string, req *models.MockRequest) (*models.MockResponse, error) {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	endpoints, err := s.endpointRepo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, params, methodMismatch := matchEndpoint(endpoints, req.Method, req.Path)
+	if endpoint == nil {
+		if methodMismatch {
+			return mockError(http.StatusMethodNotAllowed, fmt.Sprintf("no endpoint matches %s %s", req.Method, req.Path)), nil
+		}
+		return mockError(http.StatusNotFound, fmt.Sprintf("no endpoint matches %s %s", req.Method, req.Path)), nil
+	}
+
+	resp, err := s.answer(project, endpoint, params, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.EndpointUUID = endpoint.UUID
+	return resp, nil
+}
+
+// answer builds the response of a matched endpoint
+func (s *MockService) answer(project *models.Project, endpoint *models.Endpoint, params map[string]string, req *models.MockRequest) (*models.MockResponse, error) {
+	var mock models.EndpointMock
+	if len(endpoint.Mock) > 0 {
+		if err := json.Unmarshal(endpoint.Mock, &mock); err != nil {
+			return nil, fmt.Errorf("invalid mock of endpoint %s: %w", endpoint.UUID, err)
+		}
+	}
+
+	if req.Scenario != "" {
+		scenario := mock.Scenario(req.Scenario)
+		if scenario == nil {
+			return mockError(http.StatusBadRequest, fmt.Sprintf("endpoint %q has no mock scenario %q", endpoint.Name, req.Scenario)), nil
+		}
+		if len(scenario.Body) == 0 && scenario.Status >= http.StatusBadRequest {
+			return mockError(scenario.Status, http.StatusText(scenario.Status)), nil
+		}
+		return &models.MockResponse{Status: scenario.Status, Body: scenario.Body}, nil
+	}
+
+	if endpoint.RequireAuth && !hasBearerToken(req.Authorization) {
+		resp := mockError(http.StatusUnauthorized, "missing bearer token")
+		resp.Headers = map[string]string{"WWW-Authenticate": "Bearer"}
+		return resp, nil
+	}
+
+	entities, err := s.entityRepo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	entitySchemas, err := schema.EntitySchemas(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	var body interface{}
+	if hasRequestBody(endpoint.Method) {
+		requestSchema, err := schema.Parse(endpoint.RequestSchema, entitySchemas)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request schema of endpoint %s: %w", endpoint.UUID, err)
+		}
+		if len(bytes.TrimSpace(req.Body)) > 0 {
+			if err := json.Unmarshal(req.Body, &body); err != nil {
+				return mockError(http.StatusBadRequest, "request body is not valid JSON"), nil
+			}
+		}
+		if !requestSchema.IsEmpty() {
+			if violations := requestSchema.Validate(body); len(violations) > 0 {
+				return mockValidationError(violations), nil
+			}
+		}
+	}
+
+	status := mock.Status
+	if status == 0 {
+		status = http.StatusOK
+		if endpoint.Method == http.MethodPost {
+			status = http.StatusCreated
+		}
+	}
+	if len(mock.Example) > 0 {
+		return &models.MockResponse{Status: status, Body: mock.Example}, nil
+	}
+
+	responseSchema, err := schema.Parse(endpoint.ResponseSchema, entitySchemas)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response schema of endpoint %s: %w", endpoint.UUID, err)
+	}
+
+	var sample interface{}
+	switch {
+	case !responseSchema.IsEmpty():
+		sample = responseSchema.Sample()
+	case endpoint.Method == http.MethodDelete:
+		if mock.Status == 0 {
+			status = http.StatusNoContent
+		}
+		return &models.MockResponse{Status: status}, nil
+	default:
+		sample, err = s.entitySample(endpoint, entitySchemas, entities)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fillSample(sample, body, params, endpoint.Path)
+	encoded, err := json.Marshal(sample)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MockResponse{Status: status, Body: encoded}, nil
+}
+
+// entitySample synthesizes the response the generated handler of an endpoint without a response
+// schema returns: a page of its entity for lists, else the entity itself
+func (s *MockService) entitySample(endpoint *models.Endpoint, entitySchemas map[string]*schema.Schema, entities []models.Entity) (interface{}, error) {
+	var entitySchema *schema.Schema
+	for i := range entities {
+		if entities[i].ID == endpoint.EntityID {
+			entitySchema = entitySchemas[entities[i].Name]
+		}
+	}
+	if entitySchema == nil {
+		return nil, fmt.Errorf("endpoint %s belongs to no live entity", endpoint.UUID)
+	}
+
+	if endpoint.Method == http.MethodGet && trailingParam(endpoint.Path) == "" {
+		list := &schema.Schema{Type: schema.TypeArray, Items: entitySchema, MinItems: intPtr(mockListItems), MaxItems: intPtr(mockListItems)}
+		return map[string]interface{}{
+			"data":   list.Sample(),
+			"total":  mockListItems,
+			"limit":  10,
+			"offset": 0,
+		}, nil
+	}
+	return entitySchema.Sample(), nil
+}
+
+// fillSample makes an object sample echo the request: properties named like a path parameter, or
+// id for the trailing one, take its value, and properties sent in the body take the sent value
+func fillSample(sample, body interface{}, params map[string]string, path string) {
+	object, ok := sample.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if sent, ok := body.(map[string]interface{}); ok {
+		for name, value := range sent {
+			if _, ok := object[name]; ok {
+				object[name] = value
+			}
+		}
+	}
+
+	trailing := trailingParam(path)
+	for name, value := range params {
+		property := name
+		if _, ok := object[property]; !ok && name == trailing {
+			property = "id"
+		}
+		current, ok := object[property]
+		if !ok {
+			continue
+		}
+		if _, isNumber := current.(float64); isNumber {
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				object[property] = number
+			}
+			continue
+		}
+		object[property] = value
+	}
+}
+
+// matchEndpoint picks the endpoint whose route matches a request, preferring the route with the most
+// static segments like Gin does. methodMismatch reports a route that matched under another method.
+func matchEndpoint(endpoints []models.Endpoint, method, path string) (match *models.Endpoint, params map[string]string, methodMismatch bool) {
+	best := -1
+	for i := range endpoints {
+		routeParams, static, ok := matchRoute(endpoints[i].Path, path)
+		if !ok {
+			continue
+		}
+		if !strings.EqualFold(endpoints[i].Method, method) {
+			methodMismatch = true
+			continue
+		}
+		if static > best {
+			match, params, best = &endpoints[i], routeParams, static
+		}
+	}
+	return match, params, methodMismatch
+}
+
+// matchRoute matches a path against a route with :param and *catchAll segments, returning the
+// parameter values and the number of static segments of the route
+func matchRoute(route, path string) (map[string]string, int, bool) {
+	routeSegments := strings.Split(strings.Trim(route, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	params := make(map[string]string)
+	static := 0
+	for i, segment := range routeSegments {
+		if strings.HasPrefix(segment, "*") && len(segment) > 1 {
+			params[segment[1:]] = "/" + strings.Join(pathSegments[i:], "/")
+			return params, static, true
+		}
+		if i >= len(pathSegments) {
+			return nil, 0, false
+		}
+		switch {
+		case isWildcard(segment):
+			if pathSegments[i] == "" {
+				return nil, 0, false
+			}
+			params[segment[1:]] = pathSegments[i]
+		case segment == pathSegments[i]:
+			static++
+		default:
+			return nil, 0, false
+		}
+	}
+	if len(routeSegments) != len(pathSegments) {
+		return nil, 0, false
+	}
+	return params, static, true
+}
+
+// trailingParam returns the name of the last segment of a route when it is a parameter
+func trailingParam(path string) string {
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	if last := segments[len(segments)-1]; strings.HasPrefix(last, ":") {
+		return last[1:]
+	}
+	return ""
+}
+
+func mockError(status int, message string) *models.MockResponse {
+	body, _ := json.Marshal(map[string]string{"error": message})
+	return &models.MockResponse{Status: status, Body: body}
+}
+
+func mockValidationError(violations []*schema.Error) *models.MockResponse {
+	fieldErrs := make([]apperror.FieldError, len(violations))
+	for i, violation := range violations {
+		fieldErrs[i] = apperror.FieldError{Field: violation.Path, Message: violation.Message}
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"error":  "request body does not match the request schema",
+		"errors": fieldErrs,
+	})
+	return &models.MockResponse{Status: http.StatusBadRequest, Body: body}
+}
+
+func hasBearerToken(header string) bool {
+	scheme, token, found := strings.Cut(header, " ")
+	return found && strings.EqualFold(scheme, "Bearer") && strings.TrimSpace(token) != ""
+}
+
+// hasRequestBody reports whether requests of a method carry a body the generator binds
+func hasRequestBody(method string) bool {
+	return method != http.MethodGet && method != http.MethodDelete
+}
+
+func intPtr(n int) *int {
+	return &n
+}
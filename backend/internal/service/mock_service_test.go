@@ -0,0 +1,97 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/lambra/internal/models"
+)
+
+func TestMatchRoute(t *testing.T) {
+	tests := []struct {
+		name   string
+		route  string
+		path   string
+		params map[string]string
+		static int
+		ok     bool
+	}{
+		{"static", "/users", "/users", map[string]string{}, 1, true},
+		{"param", "/users/:id", "/users/42", map[string]string{"id": "42"}, 1, true},
+		{"nested params", "/users/:id/orders/:orderId", "/users/1/orders/2", map[string]string{"id": "1", "orderId": "2"}, 2, true},
+		{"trailing slash", "/users/:id", "/users/42/", map[string]string{"id": "42"}, 1, true},
+		{"catch-all", "/files/*path", "/files/a/b.txt", map[string]string{"path": "/a/b.txt"}, 1, true},
+		{"empty catch-all", "/files/*path", "/files", map[string]string{"path": "/"}, 1, true},
+		{"static mismatch", "/users", "/orders", nil, 0, false},
+		{"too short", "/users/:id", "/users", nil, 0, false},
+		{"too long", "/users/:id", "/users/42/orders", nil, 0, false},
+		{"empty param", "/users/:id/orders", "/users//orders", nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, static, ok := matchRoute(tt.route, tt.path)
+			if ok != tt.ok {
+				t.Fatalf("matchRoute(%s, %s) ok = %v, want %v", tt.route, tt.path, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if static != tt.static {
+				t.Errorf("matchRoute(%s, %s) static = %d, want %d", tt.route, tt.path, static, tt.static)
+			}
+			if !reflect.DeepEqual(params, tt.params) {
+				t.Errorf("matchRoute(%s, %s) params = %v, want %v", tt.route, tt.path, params, tt.params)
+			}
+		})
+	}
+}
+
+func TestMatchEndpoint(t *testing.T) {
+	endpoints := []models.Endpoint{
+		{Name: "List users", Method: "GET", Path: "/users"},
+		{Name: "Create user", Method: "POST", Path: "/users"},
+		{Name: "Get user", Method: "GET", Path: "/users/:id"},
+		{Name: "Get me", Method: "GET", Path: "/users/me"},
+		{Name: "Delete user", Method: "DELETE", Path: "/users/:id"},
+		{Name: "Get file", Method: "GET", Path: "/files/*path"},
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		want           string
+		params         map[string]string
+		methodMismatch bool
+	}{
+		{name: "static route", method: "GET", path: "/users", want: "List users", params: map[string]string{}},
+		{name: "method picks the route", method: "POST", path: "/users", want: "Create user", params: map[string]string{}},
+		{name: "method is case-insensitive", method: "post", path: "/users", want: "Create user", params: map[string]string{}},
+		{name: "param route", method: "GET", path: "/users/42", want: "Get user", params: map[string]string{"id": "42"}},
+		{name: "static over param", method: "GET", path: "/users/me", want: "Get me", params: map[string]string{}},
+		{name: "trailing slash", method: "GET", path: "/users/42/", want: "Get user", params: map[string]string{"id": "42"}},
+		{name: "catch-all", method: "GET", path: "/files/a/b", want: "Get file", params: map[string]string{"path": "/a/b"}},
+		{name: "method mismatch", method: "PUT", path: "/users/42", methodMismatch: true},
+		{name: "no route", method: "GET", path: "/orders"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, params, methodMismatch := matchEndpoint(endpoints, tt.method, tt.path)
+			if tt.want == "" {
+				if match != nil {
+					t.Fatalf("matchEndpoint(%s %s) = %q, want no match", tt.method, tt.path, match.Name)
+				}
+				if methodMismatch != tt.methodMismatch {
+					t.Errorf("matchEndpoint(%s %s) methodMismatch = %v, want %v", tt.method, tt.path, methodMismatch, tt.methodMismatch)
+				}
+				return
+			}
+			if match == nil || match.Name != tt.want {
+				t.Fatalf("matchEndpoint(%s %s) = %v, want %q", tt.method, tt.path, match, tt.want)
+			}
+			if !reflect.DeepEqual(params, tt.params) {
+				t.Errorf("matchEndpoint(%s %s) params = %v, want %v", tt.method, tt.path, params, tt.params)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// ErrVersionMismatch is returned when the If-Match of a request names another version than the current one
+var ErrVersionMismatch = apperror.PreconditionFailed("resource version does not match If-Match")
+
+// checkPrecondition returns ErrVersionMismatch unless precondition holds for version
+func checkPrecondition(precondition *models.Precondition, version int64) error {
+	if !precondition.Matches(version) {
+		return ErrVersionMismatch
+	}
+	return nil
+}
@@ -1,52 +1,374 @@
 package service
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"log"
+	"path"
+	"strings"
 
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/generator"
 	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
 	"github.com/yourusername/lambra/internal/repository"
 )
 
+// ErrUserNotFound is returned when a project member refers to an unknown user
+var ErrUserNotFound = apperror.NotFound("user")
+
+// ErrNamespaceTaken is returned when another project of the same team already uses a namespace
+var ErrNamespaceTaken = repository.ErrNamespaceTaken
+
 type ProjectService struct {
-	repo *repository.ProjectRepository
+	repo         *repository.ProjectRepository
+	userRepo     *repository.UserRepository
+	teamRepo     *repository.TeamRepository
+	entityRepo   *repository.EntityRepository
+	endpointRepo *repository.EndpointRepository
+	snapshotRepo *repository.SnapshotRepository
+	uow          *repository.UnitOfWork
+	audit        *AuditService
+	authz        rbac.Authorizer
+	guard        *projectGuard
+	teamGuard    *teamGuard
 }
 
-func NewProjectService(repo *repository.ProjectRepository) *ProjectService {
-	return &ProjectService{repo: repo}
+func NewProjectService(
+	repo *repository.ProjectRepository,
+	userRepo *repository.UserRepository,
+	teamRepo *repository.TeamRepository,
+	entityRepo *repository.EntityRepository,
+	endpointRepo *repository.EndpointRepository,
+	snapshotRepo *repository.SnapshotRepository,
+	uow *repository.UnitOfWork,
+	audit *AuditService,
+	authz rbac.Authorizer,
+) *ProjectService {
+	return &ProjectService{
+		repo:         repo,
+		userRepo:     userRepo,
+		teamRepo:     teamRepo,
+		entityRepo:   entityRepo,
+		endpointRepo: endpointRepo,
+		snapshotRepo: snapshotRepo,
+		uow:          uow,
+		audit:        audit,
+		authz:        authz,
+		guard:        newProjectGuard(authz, repo),
+		teamGuard:    &teamGuard{teams: teamRepo},
+	}
 }
 
-func (s *ProjectService) CreateProject(req *models.CreateProjectRequest) (*models.Project, error) {
+// CreateProject creates a project, inside a team when one is given. Team defaults fill in the
+// module path and dialect the request leaves empty.
+func (s *ProjectService) CreateProject(ctx context.Context, req *models.CreateProjectRequest) (*models.Project, error) {
 	project := &models.Project{
-		Name:      req.Name,
-		Namespace: req.Namespace,
-		Status:    models.ProjectStatusActive,
+		Name:       req.Name,
+		Namespace:  req.Namespace,
+		Status:     models.ProjectStatusActive,
+		Image:      req.Image,
+		Port:       req.Port,
+		ModulePath: req.ModulePath,
+		Dialect:    req.Dialect,
 	}
+	if project.Port == 0 {
+		project.Port = models.DefaultProjectPort
+	}
+
+	if req.TeamUUID != "" {
+		team, err := s.teamRepo.GetByUUID(req.TeamUUID)
+		if err != nil {
+			return nil, apperror.Validation("team not found", apperror.FieldError{Field: "team_id", Message: "references an unknown team"})
+		}
+		if err := s.teamGuard.require(ctx, team.ID, models.TeamRoleMember); err != nil {
+			return nil, err
+		}
+		project.TeamID = sql.NullInt64{Int64: team.ID, Valid: true}
+
+		settings := team.GetSettings()
+		if project.ModulePath == "" && settings.ModulePathPrefix != "" {
+			project.ModulePath = strings.TrimSuffix(settings.ModulePathPrefix, "/") + "/" + generator.ToKebabCase(project.Name)
+		}
+		if project.Dialect == "" {
+			project.Dialect = settings.Dialect
+		}
+	}
+
+	taken, err := s.repo.NamespaceExists(project.TeamID, project.Namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fmt.Errorf("%w: %s", ErrNamespaceTaken, project.Namespace)
+	}
+
+	environments, err := marshalEnvironments(req.Environments)
+	if err != nil {
+		return nil, err
+	}
+	project.Environments = environments
 
 	if req.Description != "" {
 		project.Description = sql.NullString{String: req.Description, Valid: true}
 	}
 
-	// Set created_by (in future, get from auth context)
-	project.SetCreatedBy("system")
+	project.SetCreatedBy(auth.Actor(ctx))
 
-	err := s.repo.Create(project)
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Projects().Create(project); err != nil {
+			return fmt.Errorf("failed to create project: %w", err)
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceProject, project.UUID, models.AuditActionCreate, nil, project)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create project: %w", err)
+		return nil, err
+	}
+
+	// The creator owns the project
+	if err := s.grantOwner(ctx, project); err != nil {
+		return nil, err
 	}
 
 	return project, nil
 }
 
-func (s *ProjectService) GetProjectByUUID(uuid string) (*models.Project, error) {
+// CloneProject deep-copies a project with its entities and endpoints into a new project under a
+// new name and namespace. Every copy gets a fresh UUID and endpoints point at the copies of their
+// entities. With a snapshot the entities, endpoints, image, port and environments come from it
+// instead of the current state.
+func (s *ProjectService) CloneProject(ctx context.Context, uuid string, req *models.CloneProjectRequest) (*models.Project, error) {
+	if err := s.guard.require(ctx, uuid, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	source, err := s.repo.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := &models.Project{
+		Name:         req.Name,
+		Namespace:    req.Namespace,
+		Status:       models.ProjectStatusActive,
+		Description:  source.Description,
+		TeamID:       source.TeamID,
+		Image:        source.Image,
+		Port:         source.Port,
+		ModulePath:   req.ModulePath,
+		Dialect:      source.Dialect,
+		Environments: source.Environments,
+	}
+	if req.Description != "" {
+		clone.Description = sql.NullString{String: req.Description, Valid: true}
+	}
+	if clone.ModulePath == "" && source.ModulePath != "" {
+		clone.ModulePath = path.Join(path.Dir(source.ModulePath), generator.ToKebabCase(req.Name))
+	}
+
+	if req.TeamUUID != "" {
+		team, err := s.teamRepo.GetByUUID(req.TeamUUID)
+		if err != nil {
+			return nil, apperror.Validation("team not found", apperror.FieldError{Field: "team_id", Message: "references an unknown team"})
+		}
+		clone.TeamID = sql.NullInt64{Int64: team.ID, Valid: true}
+	}
+	if clone.TeamID.Valid {
+		if err := s.teamGuard.require(ctx, clone.TeamID.Int64, models.TeamRoleMember); err != nil {
+			return nil, err
+		}
+	}
+
+	taken, err := s.repo.NamespaceExists(clone.TeamID, clone.Namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fmt.Errorf("%w: %s", ErrNamespaceTaken, clone.Namespace)
+	}
+
+	entities, endpoints, err := s.cloneSource(source, req.SnapshotUUID, clone)
+	if err != nil {
+		return nil, err
+	}
+
+	clone.SetCreatedBy(auth.Actor(ctx))
+
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Projects().Create(clone); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, tx, clone.UUID, models.AuditResourceProject, clone.UUID, models.AuditActionCreate, nil, clone); err != nil {
+			return err
+		}
+
+		// Old entity UUID to the internal ID of its copy
+		entityIDs := make(map[string]int64, len(entities))
+		for _, entity := range entities {
+			copied := models.Entity{
+				ProjectID:   clone.ID,
+				Name:        entity.Name,
+				TableName:   entity.TableName,
+				Description: entity.Description,
+				Fields:      entity.Fields,
+			}
+			copied.SetCreatedBy(auth.Actor(ctx))
+			if err := tx.Entities().Create(&copied); err != nil {
+				return err
+			}
+			entityIDs[entity.UUID] = copied.ID
+			if err := s.audit.Record(ctx, tx, clone.UUID, models.AuditResourceEntity, copied.UUID, models.AuditActionCreate, nil, &copied); err != nil {
+				return err
+			}
+		}
+
+		for _, endpoint := range endpoints {
+			entityID, ok := entityIDs[endpoint.entityUUID]
+			if !ok {
+				return fmt.Errorf("endpoint %s belongs to no entity of the clone source", endpoint.UUID)
+			}
+			copied := models.Endpoint{
+				EntityID:       entityID,
+				ProjectID:      clone.ID,
+				Name:           endpoint.Name,
+				Path:           endpoint.Path,
+				Method:         endpoint.Method,
+				Description:    endpoint.Description,
+				RequestSchema:  endpoint.RequestSchema,
+				ResponseSchema: endpoint.ResponseSchema,
+				Mock:           endpoint.Mock,
+				RequireAuth:    endpoint.RequireAuth,
+			}
+			copied.SetCreatedBy(auth.Actor(ctx))
+			if err := tx.Endpoints().Create(&copied); err != nil {
+				return err
+			}
+			if err := s.audit.Record(ctx, tx, clone.UUID, models.AuditResourceEndpoint, copied.UUID, models.AuditActionCreate, nil, &copied); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone project: %w", err)
+	}
+
+	// The user cloning the project owns the copy
+	if err := s.grantOwner(ctx, clone); err != nil {
+		return nil, err
+	}
+
+	return clone, nil
+}
+
+// grantOwner makes the acting user the owner of a project it just created. When the grant fails the
+// project is discarded: nobody could see or delete a project without an owner, and it would keep
+// holding its namespace.
+func (s *ProjectService) grantOwner(ctx context.Context, project *models.Project) error {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	err := s.authz.Grant(ctx, project.UUID, user.Username, models.ProjectRoleOwner)
+	if err == nil {
+		return nil
+	}
+	if discardErr := s.repo.Discard(project.UUID); discardErr != nil {
+		log.Printf("project %s has no owner and could not be discarded: %v", project.UUID, discardErr)
+	}
+	return fmt.Errorf("failed to grant project ownership: %w", err)
+}
+
+// clonedEndpoint is an endpoint to copy with the UUID of the entity it belongs to
+type clonedEndpoint struct {
+	models.Endpoint
+	entityUUID string
+}
+
+// cloneSource returns the entities and endpoints a clone copies: the current ones of the source
+// project, or those of one of its snapshots, whose settings then also replace those of the clone
+func (s *ProjectService) cloneSource(source *models.Project, snapshotUUID string, clone *models.Project) ([]models.Entity, []clonedEndpoint, error) {
+	if snapshotUUID == "" {
+		entities, err := s.entityRepo.GetByProjectID(source.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		endpoints, err := s.endpointRepo.GetByProjectID(source.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		entityUUIDs := make(map[int64]string, len(entities))
+		for _, entity := range entities {
+			entityUUIDs[entity.ID] = entity.UUID
+		}
+		cloned := make([]clonedEndpoint, len(endpoints))
+		for i, endpoint := range endpoints {
+			cloned[i] = clonedEndpoint{Endpoint: endpoint, entityUUID: entityUUIDs[endpoint.EntityID]}
+		}
+		return entities, cloned, nil
+	}
+
+	invalidSnapshot := func(message string) error {
+		return apperror.Validation("invalid snapshot", apperror.FieldError{Field: "snapshot_id", Message: message})
+	}
+
+	snapshot, err := s.snapshotRepo.GetByUUID(snapshotUUID)
+	if err != nil || snapshot.ProjectID != source.ID {
+		return nil, nil, invalidSnapshot("references an unknown snapshot of this project")
+	}
+	metadata, err := snapshot.GetMetadata()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse snapshot metadata: %w", err)
+	}
+	if len(metadata.Endpoints) > 0 && metadata.EndpointEntities == nil {
+		return nil, nil, invalidSnapshot("was taken before snapshots recorded the entities of endpoints")
+	}
+
+	cloned := make([]clonedEndpoint, len(metadata.Endpoints))
+	for i, endpoint := range metadata.Endpoints {
+		cloned[i] = clonedEndpoint{Endpoint: endpoint, entityUUID: metadata.EndpointEntities[endpoint.UUID]}
+	}
+
+	if image, ok := metadata.Config["image"].(string); ok {
+		clone.Image = image
+	}
+	if port, ok := metadata.Config["port"].(float64); ok {
+		clone.Port = int(port)
+	}
+	if environments, ok := metadata.Config["environments"]; ok && environments != nil {
+		raw, err := json.Marshal(environments)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal snapshot environments: %w", err)
+		}
+		clone.Environments = raw
+	}
+
+	return metadata.Entities, cloned, nil
+}
+
+func (s *ProjectService) GetProjectByUUID(ctx context.Context, uuid string) (*models.Project, error) {
+	if err := s.guard.require(ctx, uuid, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetByUUID(uuid)
 }
 
-func (s *ProjectService) GetProjectWithRelations(uuid string) (*models.ProjectWithRelations, error) {
+func (s *ProjectService) GetProjectWithRelations(ctx context.Context, uuid string) (*models.ProjectWithRelations, error) {
+	if err := s.guard.require(ctx, uuid, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetWithGitRepoByUUID(uuid)
 }
 
-func (s *ProjectService) GetAllProjects(page, limit int) ([]models.Project, int64, error) {
+// GetAllProjects lists the projects the current user can see, optionally narrowed to a team
+func (s *ProjectService) GetAllProjects(ctx context.Context, teamUUID string, filter *models.ProjectFilter, page, limit int) ([]models.Project, int64, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -54,15 +376,43 @@ func (s *ProjectService) GetAllProjects(page, limit int) ([]models.Project, int6
 		limit = 20
 	}
 
+	uuids, err := s.guard.visibleProjects(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(uuids) == 0 {
+		return []models.Project{}, 0, nil
+	}
+
+	filter.UUIDs = uuids
+	if teamUUID != "" {
+		team, err := s.teamRepo.GetByUUID(teamUUID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %s", ErrTeamNotFound, teamUUID)
+		}
+		if err := s.teamGuard.require(ctx, team.ID, models.TeamRoleMember); err != nil {
+			return nil, 0, err
+		}
+		filter.TeamID = team.ID
+	}
+
 	offset := (page - 1) * limit
-	return s.repo.GetAll(limit, offset)
+	return s.repo.GetAll(filter, limit, offset)
 }
 
-func (s *ProjectService) UpdateProject(uuid string, req *models.UpdateProjectRequest) (*models.Project, error) {
+func (s *ProjectService) UpdateProject(ctx context.Context, uuid string, req *models.UpdateProjectRequest, precondition *models.Precondition) (*models.Project, error) {
+	if err := s.guard.require(ctx, uuid, models.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+
 	project, err := s.repo.GetByUUID(uuid)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkPrecondition(precondition, project.Version); err != nil {
+		return nil, err
+	}
+	before := *project
 
 	if req.Name != "" {
 		project.Name = req.Name
@@ -73,24 +423,118 @@ func (s *ProjectService) UpdateProject(uuid string, req *models.UpdateProjectReq
 	if req.Status != "" {
 		project.Status = req.Status
 	}
+	if req.Image != "" {
+		project.Image = req.Image
+	}
+	if req.Port != 0 {
+		project.Port = req.Port
+	}
+	if req.ModulePath != "" {
+		project.ModulePath = req.ModulePath
+	}
+	if req.Dialect != "" {
+		project.Dialect = req.Dialect
+	}
+	if req.Environments != nil {
+		environments, err := marshalEnvironments(req.Environments)
+		if err != nil {
+			return nil, err
+		}
+		project.Environments = environments
+	}
 
-	// Set updated_by (in future, get from auth context)
-	project.SetUpdatedBy("system")
+	project.SetUpdatedBy(auth.Actor(ctx))
 
-	err = s.repo.Update(project)
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Projects().Update(project); err != nil {
+			return fmt.Errorf("failed to update project: %w", err)
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceProject, project.UUID, models.AuditActionUpdate, &before, project)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update project: %w", err)
+		return nil, err
 	}
 
 	return project, nil
 }
 
-func (s *ProjectService) DeleteProject(uuid string) error {
-	_, err := s.repo.GetByUUID(uuid)
+func (s *ProjectService) DeleteProject(ctx context.Context, uuid string, precondition *models.Precondition) error {
+	if err := s.guard.require(ctx, uuid, models.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	project, err := s.repo.GetByUUID(uuid)
 	if err != nil {
 		return err
 	}
+	if err := checkPrecondition(precondition, project.Version); err != nil {
+		return err
+	}
+
+	// Soft delete the whole hierarchy, marking every row with the project UUID so a restore brings
+	// back exactly these rows
+	actor := auth.Actor(ctx)
+	return s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Projects().DeleteByUUID(uuid, actor, project.Version); err != nil {
+			return err
+		}
+		if err := tx.Endpoints().DeleteByProjectID(project.ID, actor, project.UUID); err != nil {
+			return err
+		}
+		if err := tx.Entities().DeleteByProjectID(project.ID, actor, project.UUID); err != nil {
+			return err
+		}
+		if err := tx.GitRepositories().DeleteByProjectID(project.ID, actor, project.UUID); err != nil {
+			return err
+		}
+		if err := tx.Snapshots().DeleteByProjectID(project.ID, actor, project.UUID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceProject, project.UUID, models.AuditActionDelete, project, nil)
+	})
+}
+
+// GetMembers lists the members of a project and their roles
+func (s *ProjectService) GetMembers(ctx context.Context, projectUUID string) ([]models.ProjectMember, error) {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	return s.authz.Members(ctx, projectUUID)
+}
+
+// SetMember adds a user to a project or changes their role
+func (s *ProjectService) SetMember(ctx context.Context, projectUUID, username string, req *models.SetProjectMemberRequest) error {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	if _, err := s.userRepo.GetByUsername(username); err != nil {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, username)
+	}
+
+	return s.authz.Grant(ctx, projectUUID, username, req.Role)
+}
+
+// RemoveMember removes a user from a project
+func (s *ProjectService) RemoveMember(ctx context.Context, projectUUID, username string) error {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	return s.authz.Revoke(ctx, projectUUID, username)
+}
+
+// marshalEnvironments encodes per-environment settings, storing an empty object when none are given
+func marshalEnvironments(environments map[string]models.EnvironmentSettings) ([]byte, error) {
+	if environments == nil {
+		environments = map[string]models.EnvironmentSettings{}
+	}
+
+	data, err := json.Marshal(environments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal environments: %w", err)
+	}
 
-	// Soft delete with deleted_by (in future, get from auth context)
-	return s.repo.DeleteByUUID(uuid, "system")
+	return data, nil
 }
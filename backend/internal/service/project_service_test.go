@@ -0,0 +1,157 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+func newTestProjectService(t *testing.T) (*ProjectService, sqlmock.Sqlmock) {
+	db, mock := newTestDB(t)
+	return NewProjectService(
+		repository.NewProjectRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewTeamRepository(db),
+		repository.NewEntityRepository(db),
+		repository.NewEndpointRepository(db),
+		repository.NewSnapshotRepository(db),
+		repository.NewUnitOfWork(db),
+		&AuditService{},
+		roleAuthorizer{role: models.ProjectRoleOwner},
+	), mock
+}
+
+// Deleting a project marks its live children with the project UUID. Children already in the trash
+// keep the deletion ID of their own delete, so restoring the project leaves them there.
+func TestProjectService_DeleteMarksLiveChildren(t *testing.T) {
+	svc, mock := newTestProjectService(t)
+	mock.ExpectQuery(`FROM projects\s+WHERE uuid = \? AND deleted_at IS NULL`).WithArgs("prj-1").
+		WillReturnRows(sqlmock.NewRows(projectRows).AddRow(1, "prj-1", "Shop", "shop", 7, 2))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE projects SET deleted_by = \?, deleted_at = NOW\(\), deletion_id = uuid`).
+		WithArgs("alice", "prj-1", int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, table := range []string{"endpoints", "entities", "git_repositories", "generation_snapshots"} {
+		mock.ExpectExec(`UPDATE `+table+` SET deleted_by = \?, deleted_at = NOW\(\), deletion_id = \?\s+WHERE project_id = \? AND deleted_at IS NULL`).
+			WithArgs("alice", "prj-1", int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec(`INSERT INTO audit_logs`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := svc.DeleteProject(userContext("alice"), "prj-1", nil); err != nil {
+		t.Fatalf("DeleteProject() error = %v", err)
+	}
+}
+
+var cloneRequest = &models.CloneProjectRequest{Name: "Shop Copy", Namespace: "shop-copy"}
+
+// expectCloneSource answers the access and namespace checks of cloning project prj-1 (ID 1)
+func expectCloneSource(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`FROM projects\s+WHERE uuid = \? AND deleted_at IS NULL`).WithArgs("prj-1").
+		WillReturnRows(sqlmock.NewRows(projectRows).AddRow(1, "prj-1", "Shop", "shop", nil, 4))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM projects`).WithArgs("shop-copy", sqlmock.AnyArg(), "").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+}
+
+// expectCloneWrites expects the copy to be written as project 2 with entity copies 20 and 21.
+// Every insert names only the new IDs, and no statement touches the rows of the source.
+func expectCloneWrites(mock sqlmock.Sqlmock, image string, port int) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO projects`).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "Shop Copy", sqlmock.AnyArg(),
+		models.ProjectStatusActive, "shop-copy", image, port, sqlmock.AnyArg(), sqlmock.AnyArg(),
+		sqlmock.AnyArg(), sqlmock.AnyArg(), "alice").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM projects\s+WHERE uuid = \? AND deleted_at IS NULL`).
+		WillReturnRows(sqlmock.NewRows(projectRows).AddRow(2, "prj-2", "Shop Copy", "shop-copy", nil, 1))
+	mock.ExpectExec(`INSERT INTO audit_logs`).WillReturnResult(sqlmock.NewResult(0, 1))
+	for i, name := range []string{"Order", "Customer"} {
+		mock.ExpectExec(`INSERT INTO entities`).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(2), name,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "alice").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`FROM entities\s+WHERE uuid = \?`).
+			WillReturnRows(sqlmock.NewRows(entityRows).AddRow(20+i, "ent-copy", 2, name, "", 1))
+		mock.ExpectExec(`INSERT INTO audit_logs`).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	for _, endpoint := range []struct {
+		name     string
+		entityID int64
+	}{{"List orders", 20}, {"List customers", 21}} {
+		mock.ExpectExec(`INSERT INTO endpoints`).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), endpoint.entityID, int64(2),
+			endpoint.name, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), "alice").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`FROM endpoints\s+WHERE uuid = \?`).
+			WillReturnRows(sqlmock.NewRows(endpointRows).AddRow(30, "ep-copy", endpoint.entityID, 2, endpoint.name, "", "GET", 1))
+		mock.ExpectExec(`INSERT INTO audit_logs`).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+}
+
+func TestProjectService_CloneRemapsIDs(t *testing.T) {
+	svc, mock := newTestProjectService(t)
+	expectCloneSource(mock)
+	mock.ExpectQuery(`FROM entities\s+WHERE project_id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(entityRows).
+			AddRow(10, "ent-1", 1, "Order", "orders", 3).
+			AddRow(11, "ent-2", 1, "Customer", "customers", 2))
+	// Endpoints are listed in another order than their entities, so the copy must follow the entity
+	mock.ExpectQuery(`FROM endpoints\s+WHERE project_id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(endpointRows).
+			AddRow(40, "ep-1", 10, 1, "List orders", "/orders", "GET", 1).
+			AddRow(41, "ep-2", 11, 1, "List customers", "/customers", "GET", 1))
+	expectCloneWrites(mock, "", 0)
+
+	clone, err := svc.CloneProject(userContext("alice"), "prj-1", cloneRequest)
+	if err != nil {
+		t.Fatalf("CloneProject() error = %v", err)
+	}
+	if clone.UUID != "prj-2" || clone.ID != 2 {
+		t.Errorf("CloneProject() = %s (%d), want the new project prj-2 (2)", clone.UUID, clone.ID)
+	}
+}
+
+func TestProjectService_CloneFromSnapshot(t *testing.T) {
+	metadata, _ := json.Marshal(models.SnapshotMetadata{
+		Entities: []models.Entity{
+			{BaseEntity: models.BaseEntity{UUID: "ent-1"}, Name: "Order", TableName: "orders"},
+			{BaseEntity: models.BaseEntity{UUID: "ent-2"}, Name: "Customer", TableName: "customers"},
+		},
+		Endpoints: []models.Endpoint{
+			{BaseEntity: models.BaseEntity{UUID: "ep-1"}, Name: "List orders", Path: "/orders", Method: "GET"},
+			{BaseEntity: models.BaseEntity{UUID: "ep-2"}, Name: "List customers", Path: "/customers", Method: "GET"},
+		},
+		EndpointEntities: map[string]string{"ep-1": "ent-1", "ep-2": "ent-2"},
+		Config:           map[string]interface{}{"image": "registry.example.com/shop:1.0.0", "port": 9000},
+	})
+
+	svc, mock := newTestProjectService(t)
+	expectCloneSource(mock)
+	mock.ExpectQuery(`FROM generation_snapshots\s+WHERE uuid = \?`).WithArgs("snap-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uuid", "project_id", "metadata"}).AddRow(5, "snap-1", 1, metadata))
+	// The image and port come from the snapshot instead of the current project
+	expectCloneWrites(mock, "registry.example.com/shop:1.0.0", 9000)
+
+	req := *cloneRequest
+	req.SnapshotUUID = "snap-1"
+	clone, err := svc.CloneProject(userContext("alice"), "prj-1", &req)
+	if err != nil {
+		t.Fatalf("CloneProject() error = %v", err)
+	}
+	if clone.UUID != "prj-2" {
+		t.Errorf("CloneProject() = %s, want the new project prj-2", clone.UUID)
+	}
+}
+
+func TestProjectService_CloneFromSnapshotOfOtherProject(t *testing.T) {
+	svc, mock := newTestProjectService(t)
+	expectCloneSource(mock)
+	mock.ExpectQuery(`FROM generation_snapshots\s+WHERE uuid = \?`).WithArgs("snap-9").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uuid", "project_id", "metadata"}).AddRow(9, "snap-9", 3, []byte(`{}`)))
+
+	req := *cloneRequest
+	req.SnapshotUUID = "snap-9"
+	_, err := svc.CloneProject(userContext("alice"), "prj-1", &req)
+	if fields := apperror.Fields(err); len(fields) != 1 || fields[0].Field != "snapshot_id" {
+		t.Errorf("CloneProject() error = %v, want a snapshot_id field error", err)
+	}
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/generator"
+	"github.com/yourusername/lambra/internal/models"
+)
+
+// ProjectDefinition is everything the code of a project is generated from
+type ProjectDefinition struct {
+	Project   *models.Project
+	Entities  []models.Entity
+	Endpoints []models.Endpoint
+	Configs   map[string]*models.EnvironmentConfig // Variables by environment, nil for none
+	Templates map[string]string                    // Custom templates by layer, nil for the built-in ones
+}
+
+// Renderer turns project definitions into generated files. It does not touch storage, so the
+// API and offline generation produce the same tree.
+type Renderer struct {
+	generator *generator.CodeGenerator
+}
+
+// NewRenderer creates a new renderer
+func NewRenderer() *Renderer {
+	return &Renderer{generator: generator.NewCodeGenerator()}
+}
+
+// RenderProject generates the code of every entity, the router, deployment manifests, Helm chart
+// and local Docker Compose setup of a project
+func (r *Renderer) RenderProject(def *ProjectDefinition, outputDir string) ([]GeneratedFile, error) {
+	if len(def.Entities) == 0 {
+		return nil, apperror.Validation("project has no entities to generate")
+	}
+
+	var allFiles []GeneratedFile
+
+	// Generate code for each entity
+	for i := range def.Entities {
+		entity := &def.Entities[i]
+		response, err := r.RenderEntity(def.Project, entity, def.Templates, outputDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate entity %s: %w", entity.Name, err)
+		}
+		allFiles = append(allFiles, response.Files...)
+	}
+
+	// Generate the router and auth middleware serving the project endpoints
+	router, err := r.RenderRouter(def.Project, def.Entities, def.Endpoints, outputDir)
+	if err != nil {
+		return nil, err
+	}
+	allFiles = append(allFiles, router...)
+
+	// Generate the request and response body types of the endpoint schemas
+	bodies, err := r.RenderSchemaTypes(def.Entities, def.Endpoints, outputDir)
+	if err != nil {
+		return nil, err
+	}
+	allFiles = append(allFiles, bodies...)
+
+	// Generate deployment manifests for every environment
+	manifests, err := r.RenderKubernetesManifests(def.Project, def.Configs, "", outputDir)
+	if err != nil {
+		return nil, err
+	}
+	allFiles = append(allFiles, manifests...)
+
+	// Generate Helm chart
+	chart, err := r.RenderHelmChart(def.Project, def.Configs, "", outputDir)
+	if err != nil {
+		return nil, err
+	}
+	allFiles = append(allFiles, chart...)
+
+	// Generate local Docker Compose setup
+	compose, err := r.RenderCompose(def.Project, def.Configs, outputDir)
+	if err != nil {
+		return nil, err
+	}
+	allFiles = append(allFiles, compose...)
+
+	return allFiles, nil
+}
+
+// RenderEntity generates the code files of an entity, custom templates replace the built-in ones of their layer
+func (r *Renderer) RenderEntity(project *models.Project, entity *models.Entity, templates map[string]string, outputDir string) (*GenerateCodeResponse, error) {
+	// Prepare generation context
+	genCtx, err := r.generator.PrepareContext(project, entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare context: %w", err)
+	}
+	genCtx.Templates = templates
+
+	// Validate context
+	if err := r.generator.ValidateContext(genCtx); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Generate code
+	var files []GeneratedFile
+
+	// Generate model
+	code, err := r.generator.GenerateModel(genCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate model: %w", err)
+	}
+	files = append(files, GeneratedFile{
+		Path:    filepath.Join(outputDir, "models", fmt.Sprintf("%s.go", generator.ToSnakeCase(entity.Name))),
+		Content: code,
+		Layer:   "model",
+	})
+
+	// Generate repository
+	code, err = r.generator.GenerateRepository(genCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate repository: %w", err)
+	}
+	files = append(files, GeneratedFile{
+		Path:    filepath.Join(outputDir, "repository", fmt.Sprintf("%s_repository.go", generator.ToSnakeCase(entity.Name))),
+		Content: code,
+		Layer:   "repository",
+	})
+
+	// Generate service
+	code, err = r.generator.GenerateService(genCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate service: %w", err)
+	}
+	files = append(files, GeneratedFile{
+		Path:    filepath.Join(outputDir, "service", fmt.Sprintf("%s_service.go", generator.ToSnakeCase(entity.Name))),
+		Content: code,
+		Layer:   "service",
+	})
+
+	// Generate handler
+	code, err = r.generator.GenerateHandler(genCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate handler: %w", err)
+	}
+	files = append(files, GeneratedFile{
+		Path:    filepath.Join(outputDir, "api/handlers", fmt.Sprintf("%s_handler.go", generator.ToSnakeCase(entity.Name))),
+		Content: code,
+		Layer:   "handler",
+	})
+
+	// Generate DTO
+	code, err = r.generator.GenerateDTO(genCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate dto: %w", err)
+	}
+	files = append(files, GeneratedFile{
+		Path:    filepath.Join(outputDir, "api/dto", fmt.Sprintf("%s_dto.go", generator.ToSnakeCase(entity.Name))),
+		Content: code,
+		Layer:   "dto",
+	})
+
+	// Generate migration
+	up, down, err := r.generator.GenerateMigration(genCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate migration: %w", err)
+	}
+	files = append(files, GeneratedFile{
+		Path:    filepath.Join(outputDir, "migrations", fmt.Sprintf("create_%s.up.sql", generator.ToSnakeCase(entity.TableName))),
+		Content: up,
+		Layer:   "migration",
+	})
+	files = append(files, GeneratedFile{
+		Path:    filepath.Join(outputDir, "migrations", fmt.Sprintf("create_%s.down.sql", generator.ToSnakeCase(entity.TableName))),
+		Content: down,
+		Layer:   "migration",
+	})
+
+	return &GenerateCodeResponse{
+		Files:    files,
+		EntityID: entity.ID,
+		Success:  true,
+		Message:  fmt.Sprintf("Successfully generated %d files for entity %s", len(files), entity.Name),
+	}, nil
+}
+
+// RenderRouter renders the router of a project, endpoints requiring auth go through the JWT middleware
+func (r *Renderer) RenderRouter(project *models.Project, entities []models.Entity, endpoints []models.Endpoint, outputDir string) ([]GeneratedFile, error) {
+	routerCtx, err := r.generator.PrepareRouterContext(project, entities, endpoints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare router context: %w", err)
+	}
+
+	rendered, err := r.generator.GenerateRouter(routerCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []GeneratedFile
+	for path, content := range rendered {
+		files = append(files, GeneratedFile{
+			Path:    filepath.Join(outputDir, path),
+			Content: content,
+			Layer:   "router",
+		})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	return files, nil
+}
+
+// RenderSchemaTypes renders the Go types of the request and response schemas of the endpoints
+func (r *Renderer) RenderSchemaTypes(entities []models.Entity, endpoints []models.Endpoint, outputDir string) ([]GeneratedFile, error) {
+	typesCtx, err := r.generator.PrepareSchemaTypesContext(entities, endpoints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare endpoint DTO context: %w", err)
+	}
+
+	rendered, err := r.generator.GenerateSchemaTypes(typesCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []GeneratedFile
+	for path, content := range rendered {
+		files = append(files, GeneratedFile{
+			Path:    filepath.Join(outputDir, path),
+			Content: content,
+			Layer:   "dto",
+		})
+	}
+
+	return files, nil
+}
+
+// RenderKubernetesManifests renders Kubernetes manifests of a project for every environment.
+// The version tags the container image; an empty version uses "latest".
+func (r *Renderer) RenderKubernetesManifests(project *models.Project, configs map[string]*models.EnvironmentConfig, version string, outputDir string) ([]GeneratedFile, error) {
+	var files []GeneratedFile
+
+	for _, environment := range models.DeploymentEnvironments {
+		deployCtx, err := r.generator.PrepareDeployContext(project, environment, version, configs[environment])
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare %s deployment context: %w", environment, err)
+		}
+
+		manifests, err := r.generator.GenerateKubernetesManifests(deployCtx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s manifests: %w", environment, err)
+		}
+
+		for _, filename := range []string{"deployment.yaml", "service.yaml", "configmap.yaml"} {
+			files = append(files, GeneratedFile{
+				Path:    filepath.Join(outputDir, "deploy", environment, filename),
+				Content: manifests[filename],
+				Layer:   "kubernetes",
+			})
+		}
+	}
+
+	return files, nil
+}
+
+// RenderHelmChart renders the Helm chart of a project under helm/<name>.
+// The version becomes the chart version and image tag; an empty version uses "latest".
+func (r *Renderer) RenderHelmChart(project *models.Project, configs map[string]*models.EnvironmentConfig, version string, outputDir string) ([]GeneratedFile, error) {
+	helmCtx, err := r.generator.PrepareHelmContext(project, version, configs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare helm context: %w", err)
+	}
+
+	chart, err := r.generator.GenerateHelmChart(helmCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate helm chart: %w", err)
+	}
+
+	var files []GeneratedFile
+	for _, path := range generator.HelmChartFiles(chart) {
+		files = append(files, GeneratedFile{
+			Path:    filepath.Join(outputDir, "helm", helmCtx.Name, path),
+			Content: chart[path],
+			Layer:   "helm",
+		})
+	}
+
+	return files, nil
+}
+
+// RenderCompose renders docker-compose.yml and .env.example for running a project locally
+func (r *Renderer) RenderCompose(project *models.Project, configs map[string]*models.EnvironmentConfig, outputDir string) ([]GeneratedFile, error) {
+	deployCtx, err := r.generator.PrepareDeployContext(project, generator.ComposeEnvironment, "", configs[generator.ComposeEnvironment])
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare compose context: %w", err)
+	}
+
+	compose, err := r.generator.GenerateCompose(deployCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []GeneratedFile
+	for _, filename := range []string{"docker-compose.yml", ".env.example"} {
+		files = append(files, GeneratedFile{
+			Path:    filepath.Join(outputDir, filename),
+			Content: compose[filename],
+			Layer:   "compose",
+		})
+	}
+
+	return files, nil
+}
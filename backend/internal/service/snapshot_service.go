@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/manifest"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+// ErrSnapshotVersionExists is returned when a project already has a snapshot with the requested version
+var ErrSnapshotVersionExists = apperror.Conflict("snapshot version already exists")
+
+type SnapshotService struct {
+	repo         *repository.SnapshotRepository
+	projectRepo  *repository.ProjectRepository
+	entityRepo   *repository.EntityRepository
+	endpointRepo *repository.EndpointRepository
+	gitRepo      *repository.GitRepositoryRepository
+	generator    *GeneratorService
+	guard        *projectGuard
+}
+
+func NewSnapshotService(
+	repo *repository.SnapshotRepository,
+	projectRepo *repository.ProjectRepository,
+	entityRepo *repository.EntityRepository,
+	endpointRepo *repository.EndpointRepository,
+	gitRepo *repository.GitRepositoryRepository,
+	generator *GeneratorService,
+	authz rbac.Authorizer,
+) *SnapshotService {
+	return &SnapshotService{
+		repo:         repo,
+		projectRepo:  projectRepo,
+		entityRepo:   entityRepo,
+		endpointRepo: endpointRepo,
+		gitRepo:      gitRepo,
+		generator:    generator,
+		guard:        newProjectGuard(authz, projectRepo),
+	}
+}
+
+// CreateSnapshot freezes the current entities and endpoints of a project under a version
+func (s *SnapshotService) CreateSnapshot(ctx context.Context, req *models.CreateSnapshotRequest) (*models.GenerationSnapshot, error) {
+	if err := s.guard.require(ctx, req.ProjectUUID, models.ProjectRoleMaintainer); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByUUID(req.ProjectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if _, err := s.repo.GetByProjectAndVersion(project.ID, req.Version); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotVersionExists, req.Version)
+	}
+
+	entities, err := s.entityRepo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := s.endpointRepo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Manifests and the Helm chart are versioned with the snapshot
+	manifestFiles, err := s.generator.GenerateKubernetesManifests(project, req.Version, "")
+	if err != nil {
+		return nil, err
+	}
+	chartFiles, err := s.generator.GenerateHelmChart(project, req.Version, "")
+	if err != nil {
+		return nil, err
+	}
+	manifestFiles = append(manifestFiles, chartFiles...)
+	manifests := make(map[string]string, len(manifestFiles))
+	for _, file := range manifestFiles {
+		manifests[file.Path] = file.Content
+	}
+
+	entityUUIDs := make(map[int64]string, len(entities))
+	for _, entity := range entities {
+		entityUUIDs[entity.ID] = entity.UUID
+	}
+	endpointEntities := make(map[string]string, len(endpoints))
+	for _, endpoint := range endpoints {
+		endpointEntities[endpoint.UUID] = entityUUIDs[endpoint.EntityID]
+	}
+
+	metadata, err := json.Marshal(models.SnapshotMetadata{
+		Entities:         entities,
+		Endpoints:        endpoints,
+		EndpointEntities: endpointEntities,
+		Config: map[string]interface{}{
+			"name":         project.Name,
+			"namespace":    project.Namespace,
+			"description":  project.Description.String,
+			"image":        project.Image,
+			"port":         project.Port,
+			"module_path":  project.ModulePath,
+			"dialect":      project.Dialect,
+			"environments": project.GetEnvironments(),
+		},
+		Manifests: manifests,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal snapshot metadata: %w", err)
+	}
+
+	// Every entity is generated with its own table migration
+	tables := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		tables = append(tables, entity.TableName)
+	}
+	databaseSnapshot, err := json.Marshal(models.DatabaseSnapshotInfo{
+		MigrationVersion:  req.Version,
+		AppliedMigrations: tables,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal database snapshot: %w", err)
+	}
+
+	snapshot := &models.GenerationSnapshot{
+		ProjectID:        project.ID,
+		Version:          req.Version,
+		Metadata:         metadata,
+		DatabaseSnapshot: databaseSnapshot,
+		Status:           models.SnapshotStatusCreated,
+	}
+
+	// Pin the snapshot to the latest known commit when the project has a repository
+	if gitRepository, err := s.gitRepo.GetByProjectID(project.ID); err == nil {
+		snapshot.GitCommitHash = gitRepository.LastCommitHash.String
+	}
+
+	if req.GitTag != "" {
+		snapshot.GitTag = sql.NullString{String: req.GitTag, Valid: true}
+	}
+
+	snapshot.SetCreatedBy(auth.Actor(ctx))
+
+	err = s.repo.Create(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+func (s *SnapshotService) GetSnapshotByUUID(ctx context.Context, uuid string) (*models.GenerationSnapshot, error) {
+	snapshot, err := s.repo.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.guard.requireID(ctx, snapshot.ProjectID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func (s *SnapshotService) GetSnapshotsByProjectUUID(ctx context.Context, projectUUID string, page, limit int) ([]models.GenerationSnapshot, int64, error) {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleViewer); err != nil {
+		return nil, 0, err
+	}
+
+	// Validate project exists and get internal ID
+	project, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("project not found: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	return s.repo.GetByProjectID(project.ID, limit, offset)
+}
+
+// DiffSnapshots returns the changes between a snapshot and another snapshot of the same project,
+// or the current state of the project when toUUID is empty
+func (s *SnapshotService) DiffSnapshots(ctx context.Context, uuid, toUUID string) (*manifest.Plan, error) {
+	snapshot, err := s.GetSnapshotByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	from, err := snapshotManifest(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var to *manifest.Manifest
+	if toUUID != "" {
+		other, err := s.repo.GetByUUID(toUUID)
+		if err != nil || other.ProjectID != snapshot.ProjectID {
+			return nil, apperror.Validation("invalid snapshot", apperror.FieldError{
+				Field:   "to",
+				Message: "references an unknown snapshot of this project",
+			})
+		}
+		if to, err = snapshotManifest(other); err != nil {
+			return nil, err
+		}
+	} else {
+		project, err := s.projectRepo.GetByID(snapshot.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		entities, err := s.entityRepo.GetByProjectID(project.ID)
+		if err != nil {
+			return nil, err
+		}
+		endpoints, err := s.endpointRepo.GetByProjectID(project.ID)
+		if err != nil {
+			return nil, err
+		}
+		if to, err = manifest.FromProject(project, entities, endpoints); err != nil {
+			return nil, err
+		}
+	}
+
+	return manifest.Diff(from, to)
+}
+
+func snapshotManifest(snapshot *models.GenerationSnapshot) (*manifest.Manifest, error) {
+	metadata, err := snapshot.GetMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot metadata: %w", err)
+	}
+	return manifest.FromSnapshot(metadata)
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+var (
+	// ErrTeamExists is returned when a team slug is already taken
+	ErrTeamExists = apperror.Conflict("team slug already exists")
+	// ErrTeamNotFound is returned when a project refers to an unknown team
+	ErrTeamNotFound = apperror.NotFound("team")
+	// ErrTeamNotEmpty is returned when deleting a team that still owns projects
+	ErrTeamNotEmpty = apperror.Conflict("team still owns projects")
+	// ErrLastTeamOwner is returned when a change would leave a team without an owner
+	ErrLastTeamOwner = apperror.Conflict("a team must keep at least one owner")
+	// ErrTemplateNotFound is returned when a template set refers to an unknown template
+	ErrTemplateNotFound = apperror.Validation("unknown template in template set",
+		apperror.FieldError{Field: "settings.template_set", Message: "references an unknown template"})
+	// ErrTemplateOfOtherTeam is returned when a template set refers to a template another team manages
+	ErrTemplateOfOtherTeam = apperror.Validation("template of another team in template set",
+		apperror.FieldError{Field: "settings.template_set", Message: "references a template of another team"})
+)
+
+// TeamService manages teams, their members and the defaults they apply to their projects
+type TeamService struct {
+	repo         *repository.TeamRepository
+	projectRepo  *repository.ProjectRepository
+	userRepo     *repository.UserRepository
+	templateRepo *repository.TemplateRepository
+	guard        *teamGuard
+}
+
+func NewTeamService(
+	repo *repository.TeamRepository,
+	projectRepo *repository.ProjectRepository,
+	userRepo *repository.UserRepository,
+	templateRepo *repository.TemplateRepository,
+) *TeamService {
+	return &TeamService{
+		repo:         repo,
+		projectRepo:  projectRepo,
+		userRepo:     userRepo,
+		templateRepo: templateRepo,
+		guard:        &teamGuard{teams: repo},
+	}
+}
+
+// CreateTeam creates a team owned by the current user
+func (s *TeamService) CreateTeam(ctx context.Context, req *models.CreateTeamRequest) (*models.Team, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	exists, err := s.repo.SlugExists(req.Slug)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("%w: %s", ErrTeamExists, req.Slug)
+	}
+
+	settings, err := s.marshalSettings(0, &req.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	team := &models.Team{
+		Name:     req.Name,
+		Slug:     req.Slug,
+		Settings: settings,
+	}
+	if req.Description != "" {
+		team.Description = sql.NullString{String: req.Description, Valid: true}
+	}
+
+	team.SetCreatedBy(user.Username)
+
+	err = s.repo.Create(team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create team: %w", err)
+	}
+
+	owner := &models.TeamMember{TeamID: team.ID, UserID: user.ID, Role: models.TeamRoleOwner}
+	owner.SetCreatedBy(user.Username)
+	if err := s.repo.UpsertMember(owner); err != nil {
+		return nil, fmt.Errorf("failed to grant team ownership: %w", err)
+	}
+
+	return team, nil
+}
+
+// GetTeams lists the teams of the current user
+func (s *TeamService) GetTeams(ctx context.Context, page, limit int) ([]models.Team, int64, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return []models.Team{}, 0, nil
+	}
+
+	offset := (page - 1) * limit
+	return s.repo.GetByUsername(user.Username, limit, offset)
+}
+
+// GetTeam returns a team the current user is a member of
+func (s *TeamService) GetTeam(ctx context.Context, uuid string) (*models.Team, error) {
+	return s.getTeam(ctx, uuid, models.TeamRoleMember)
+}
+
+func (s *TeamService) UpdateTeam(ctx context.Context, uuid string, req *models.UpdateTeamRequest) (*models.Team, error) {
+	team, err := s.getTeam(ctx, uuid, models.TeamRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		team.Name = req.Name
+	}
+	if req.Description != "" {
+		team.Description = sql.NullString{String: req.Description, Valid: true}
+	}
+	if req.Settings != nil {
+		settings, err := s.marshalSettings(team.ID, req.Settings)
+		if err != nil {
+			return nil, err
+		}
+		team.Settings = settings
+	}
+
+	team.SetUpdatedBy(auth.Actor(ctx))
+
+	err = s.repo.Update(team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update team: %w", err)
+	}
+
+	return team, nil
+}
+
+// DeleteTeam deletes a team once it no longer owns projects
+func (s *TeamService) DeleteTeam(ctx context.Context, uuid string) error {
+	team, err := s.getTeam(ctx, uuid, models.TeamRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	_, total, err := s.projectRepo.GetAll(&models.ProjectFilter{TeamID: team.ID}, 1, 0)
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return fmt.Errorf("%w: %d projects", ErrTeamNotEmpty, total)
+	}
+
+	// Soft delete with deleted_by
+	return s.repo.DeleteByUUID(uuid, auth.Actor(ctx))
+}
+
+// GetTeamStats summarizes the projects of a team
+func (s *TeamService) GetTeamStats(ctx context.Context, uuid string) (*models.TeamStats, error) {
+	team, err := s.getTeam(ctx, uuid, models.TeamRoleMember)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetStats(team.ID)
+}
+
+func (s *TeamService) GetMembers(ctx context.Context, uuid string) ([]models.TeamMember, error) {
+	team, err := s.getTeam(ctx, uuid, models.TeamRoleMember)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMembers(team.ID)
+}
+
+// SetMember adds a user to a team or changes their role
+func (s *TeamService) SetMember(ctx context.Context, uuid, username string, req *models.SetTeamMemberRequest) (*models.TeamMember, error) {
+	team, err := s.getTeam(ctx, uuid, models.TeamRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByUsername(username)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, username)
+	}
+
+	if req.Role != models.TeamRoleOwner {
+		if err := s.checkNotLastOwner(team.ID, username); err != nil {
+			return nil, err
+		}
+	}
+
+	member := &models.TeamMember{TeamID: team.ID, UserID: user.ID, Role: req.Role}
+	member.SetCreatedBy(auth.Actor(ctx))
+
+	if err := s.repo.UpsertMember(member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// RemoveMember removes a user from a team
+func (s *TeamService) RemoveMember(ctx context.Context, uuid, username string) error {
+	team, err := s.getTeam(ctx, uuid, models.TeamRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByUsername(username)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, username)
+	}
+
+	if err := s.checkNotLastOwner(team.ID, username); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteMember(team.ID, user.ID, auth.Actor(ctx))
+}
+
+// getTeam loads a team and checks the role of the current user in it
+func (s *TeamService) getTeam(ctx context.Context, uuid, role string) (*models.Team, error) {
+	team, err := s.repo.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.guard.require(ctx, team.ID, role); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// checkNotLastOwner rejects removing the owner role from the only owner of a team
+func (s *TeamService) checkNotLastOwner(teamID int64, username string) error {
+	members, err := s.repo.GetMembers(teamID)
+	if err != nil {
+		return err
+	}
+
+	owners, isOwner := 0, false
+	for _, member := range members {
+		if member.Role == models.TeamRoleOwner {
+			owners++
+			isOwner = isOwner || member.Username == username
+		}
+	}
+
+	if isOwner && owners == 1 {
+		return ErrLastTeamOwner
+	}
+	return nil
+}
+
+// marshalSettings checks that the template set refers to existing templates of the team, or read-only
+// ones without a team, and encodes the defaults. teamID is zero for a team not created yet.
+func (s *TeamService) marshalSettings(teamID int64, settings *models.TeamSettings) ([]byte, error) {
+	for _, templateUUID := range settings.TemplateSet {
+		template, err := s.templateRepo.GetByUUID(templateUUID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, templateUUID)
+		}
+		if template.TeamID.Valid && template.TeamID.Int64 != teamID {
+			return nil, fmt.Errorf("%w: %s", ErrTemplateOfOtherTeam, templateUUID)
+		}
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal team settings: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/generator"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+// ErrTemplateReadOnly is returned when changing a template created before templates belonged to teams
+var ErrTemplateReadOnly = apperror.Forbidden("templates without a team are read-only")
+
+// TemplateService manages the code generation templates of teams. Only team owners change them,
+// as they end up in the generated code of every project of the team.
+type TemplateService struct {
+	repo     *repository.TemplateRepository
+	teamRepo *repository.TeamRepository
+	uow      *repository.UnitOfWork
+	audit    *AuditService
+	guard    *teamGuard
+	engine   *generator.TemplateEngine
+}
+
+func NewTemplateService(repo *repository.TemplateRepository, teamRepo *repository.TeamRepository, uow *repository.UnitOfWork, audit *AuditService) *TemplateService {
+	return &TemplateService{
+		repo:     repo,
+		teamRepo: teamRepo,
+		uow:      uow,
+		audit:    audit,
+		guard:    &teamGuard{teams: teamRepo},
+		engine:   generator.NewTemplateEngine(),
+	}
+}
+
+func (s *TemplateService) CreateTemplate(ctx context.Context, req *models.CreateTemplateRequest) (*models.Template, error) {
+	team, err := s.teamRepo.GetByUUID(req.TeamUUID)
+	if err != nil {
+		return nil, apperror.Validation("team not found", apperror.FieldError{Field: "team_id", Message: "references an unknown team"})
+	}
+	if err := s.guard.require(ctx, team.ID, models.TeamRoleOwner); err != nil {
+		return nil, err
+	}
+	if err := s.checkContent(req.Content); err != nil {
+		return nil, err
+	}
+
+	template := &models.Template{
+		Name:      req.Name,
+		Type:      req.Type,
+		Content:   req.Content,
+		IsDefault: req.IsDefault,
+		TeamID:    sql.NullInt64{Int64: team.ID, Valid: true},
+	}
+	if req.Description != "" {
+		template.Description = sql.NullString{String: req.Description, Valid: true}
+	}
+
+	template.SetCreatedBy(auth.Actor(ctx))
+
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Templates().Create(template); err != nil {
+			return fmt.Errorf("failed to create template: %w", err)
+		}
+		return s.audit.Record(ctx, tx, "", models.AuditResourceTemplate, template.UUID, models.AuditActionCreate, nil, template)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (s *TemplateService) GetTemplateByUUID(uuid string) (*models.Template, error) {
+	return s.repo.GetByUUID(uuid)
+}
+
+func (s *TemplateService) GetTemplates(templateType string, page, limit int) ([]models.Template, int64, error) {
+	offset := (page - 1) * limit
+	return s.repo.GetAll(templateType, limit, offset)
+}
+
+func (s *TemplateService) UpdateTemplate(ctx context.Context, uuid string, req *models.UpdateTemplateRequest, precondition *models.Precondition) (*models.Template, error) {
+	template, err := s.getEditable(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPrecondition(precondition, template.Version); err != nil {
+		return nil, err
+	}
+	before := *template
+
+	if req.Name != "" {
+		template.Name = req.Name
+	}
+	if req.Type != "" {
+		template.Type = req.Type
+	}
+	if req.Content != "" {
+		if err := s.checkContent(req.Content); err != nil {
+			return nil, err
+		}
+		template.Content = req.Content
+	}
+	if req.Description != "" {
+		template.Description = sql.NullString{String: req.Description, Valid: true}
+	}
+	if req.IsDefault != nil {
+		template.IsDefault = *req.IsDefault
+	}
+
+	template.SetUpdatedBy(auth.Actor(ctx))
+
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Templates().Update(template); err != nil {
+			return fmt.Errorf("failed to update template: %w", err)
+		}
+		return s.audit.Record(ctx, tx, "", models.AuditResourceTemplate, template.UUID, models.AuditActionUpdate, &before, template)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (s *TemplateService) DeleteTemplate(ctx context.Context, uuid string, precondition *models.Precondition) error {
+	template, err := s.getEditable(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err := checkPrecondition(precondition, template.Version); err != nil {
+		return err
+	}
+
+	// Soft delete with deleted_by
+	return s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Templates().DeleteByUUID(uuid, auth.Actor(ctx), template.Version); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, "", models.AuditResourceTemplate, template.UUID, models.AuditActionDelete, template, nil)
+	})
+}
+
+// getEditable loads a template the current user may change, as an owner of its team
+func (s *TemplateService) getEditable(ctx context.Context, uuid string) (*models.Template, error) {
+	template, err := s.repo.GetByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+	if !template.TeamID.Valid {
+		return nil, ErrTemplateReadOnly
+	}
+	if err := s.guard.require(ctx, template.TeamID.Int64, models.TeamRoleOwner); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// checkContent rejects templates that do not parse, so they fail when saved rather than when generating
+func (s *TemplateService) checkContent(content string) error {
+	if err := s.engine.Parse(content); err != nil {
+		return apperror.Validation("invalid template", apperror.FieldError{Field: "content", Message: err.Error()})
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+func newTestTemplateService(t *testing.T) (*TemplateService, sqlmock.Sqlmock) {
+	db, mock := newTestDB(t)
+	return NewTemplateService(repository.NewTemplateRepository(db), repository.NewTeamRepository(db), repository.NewUnitOfWork(db), &AuditService{}), mock
+}
+
+func expectTemplate(mock sqlmock.Sqlmock, uuid string, teamID interface{}) {
+	mock.ExpectQuery(`FROM templates\s+WHERE uuid = \?`).
+		WithArgs(uuid).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uuid", "name", "type", "content", "team_id", "version"}).
+			AddRow(1, uuid, "Model", "model", "package models", teamID, 3))
+}
+
+func expectTeamRole(mock sqlmock.Sqlmock, teamID int64, username, role string) {
+	rows := sqlmock.NewRows([]string{"role"})
+	if role != "" {
+		rows.AddRow(role)
+	}
+	mock.ExpectQuery(`SELECT m.role\s+FROM team_members`).WithArgs(teamID, username).WillReturnRows(rows)
+}
+
+func TestTemplateService_UpdateRequiresTeamOwner(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		wantErr error
+	}{
+		{"not a member", "", ErrTeamForbidden},
+		{"member", models.TeamRoleMember, ErrTeamForbidden},
+		{"owner with broken content", models.TeamRoleOwner, apperror.ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mock := newTestTemplateService(t)
+			expectTemplate(mock, "tpl-1", 7)
+			expectTeamRole(mock, 7, "mallory", tt.role)
+
+			// The template is never written: the update fails before reaching the database
+			_, err := svc.UpdateTemplate(userContext("mallory"), "tpl-1", &models.UpdateTemplateRequest{Content: "{{ .Name"}, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateTemplate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTemplateService_DeleteRequiresTeamOwner(t *testing.T) {
+	svc, mock := newTestTemplateService(t)
+	expectTemplate(mock, "tpl-1", 7)
+	expectTeamRole(mock, 7, "mallory", models.TeamRoleMember)
+
+	if err := svc.DeleteTemplate(userContext("mallory"), "tpl-1", nil); !errors.Is(err, ErrTeamForbidden) {
+		t.Errorf("DeleteTemplate() error = %v, want %v", err, ErrTeamForbidden)
+	}
+}
+
+func TestTemplateService_TemplatesWithoutTeamAreReadOnly(t *testing.T) {
+	svc, mock := newTestTemplateService(t)
+	expectTemplate(mock, "tpl-1", nil)
+
+	_, err := svc.UpdateTemplate(userContext("alice"), "tpl-1", &models.UpdateTemplateRequest{Name: "Renamed"}, nil)
+	if !errors.Is(err, ErrTemplateReadOnly) {
+		t.Errorf("UpdateTemplate() error = %v, want %v", err, ErrTemplateReadOnly)
+	}
+}
+
+func TestTemplateService_CreateChecksTeamAndContent(t *testing.T) {
+	expectTeam := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`FROM teams\s+WHERE uuid = \?`).
+			WithArgs("team-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "uuid", "name", "slug"}).AddRow(7, "team-1", "Shop", "shop"))
+	}
+	req := func(content string) *models.CreateTemplateRequest {
+		return &models.CreateTemplateRequest{Name: "Model", Type: "model", Content: content, TeamUUID: "team-1"}
+	}
+
+	t.Run("other team", func(t *testing.T) {
+		svc, mock := newTestTemplateService(t)
+		expectTeam(mock)
+		expectTeamRole(mock, 7, "mallory", "")
+
+		if _, err := svc.CreateTemplate(userContext("mallory"), req("package models")); !errors.Is(err, ErrTeamForbidden) {
+			t.Errorf("CreateTemplate() error = %v, want %v", err, ErrTeamForbidden)
+		}
+	})
+
+	t.Run("broken content", func(t *testing.T) {
+		svc, mock := newTestTemplateService(t)
+		expectTeam(mock)
+		expectTeamRole(mock, 7, "alice", models.TeamRoleOwner)
+
+		_, err := svc.CreateTemplate(userContext("alice"), req("{{ range .Fields }}"))
+		if !errors.Is(err, apperror.ErrValidation) {
+			t.Fatalf("CreateTemplate() error = %v, want a validation error", err)
+		}
+		if fields := apperror.Fields(err); len(fields) != 1 || fields[0].Field != "content" {
+			t.Errorf("CreateTemplate() fields = %v, want content", fields)
+		}
+	})
+}
+
+func TestTemplateService_UpdateRollsBackWithoutAuditEntry(t *testing.T) {
+	svc, mock := newTestTemplateService(t)
+	expectTemplate(mock, "tpl-1", 7)
+	expectTeamRole(mock, 7, "alice", models.TeamRoleOwner)
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE templates`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO audit_logs`).WillReturnError(errors.New("disk full"))
+	mock.ExpectRollback()
+
+	_, err := svc.UpdateTemplate(userContext("alice"), "tpl-1", &models.UpdateTemplateRequest{Name: "Renamed"}, nil)
+	if err == nil {
+		t.Fatal("UpdateTemplate() error = nil, want the audit failure")
+	}
+}
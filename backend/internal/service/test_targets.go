@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxTestRedirects bounds the redirects an endpoint test follows, like the default of net/http
+const maxTestRedirects = 10
+
+// errTargetNotAllowed is returned when an endpoint test would reach a host it may not reach
+var errTargetNotAllowed = errors.New("is not an allowed endpoint test target")
+
+// targetPolicy decides which hosts endpoint tests may reach, so testing an endpoint cannot be used
+// to reach the Lambra server itself, cloud metadata or other internal services. Without an
+// allowlist only public addresses are allowed; with one, only the hosts and networks it lists.
+type targetPolicy struct {
+	hosts    map[string]bool
+	networks []*net.IPNet
+}
+
+// newTargetPolicy parses an allowlist of host names, IP addresses and CIDR ranges
+func newTargetPolicy(allowed []string) (*targetPolicy, error) {
+	policy := &targetPolicy{hosts: make(map[string]bool)}
+	for _, entry := range allowed {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+			continue
+		case strings.Contains(entry, "/"):
+			_, network, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid endpoint test target %q: %w", entry, err)
+			}
+			policy.networks = append(policy.networks, network)
+		case net.ParseIP(entry) != nil:
+			ip := net.ParseIP(entry)
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			policy.networks = append(policy.networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		default:
+			policy.hosts[entry] = true
+		}
+	}
+	return policy, nil
+}
+
+func (p *targetPolicy) restricted() bool {
+	return len(p.hosts) > 0 || len(p.networks) > 0
+}
+
+// allowHost checks a host before it is resolved: listed hosts pass, IP literals are checked by
+// address and other names wait for allowIP unless an allowlist is configured
+func (p *targetPolicy) allowHost(host string) error {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if p.hosts[host] {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return p.allowIP(host, ip)
+	}
+	if p.restricted() {
+		return fmt.Errorf("host %s %w", host, errTargetNotAllowed)
+	}
+	return nil
+}
+
+// allowIP checks an address a host resolved to
+func (p *targetPolicy) allowIP(host string, ip net.IP) error {
+	if p.hosts[strings.ToLower(strings.TrimSuffix(host, "."))] {
+		return nil
+	}
+	if p.restricted() {
+		for _, network := range p.networks {
+			if network.Contains(ip) {
+				return nil
+			}
+		}
+		return fmt.Errorf("address %s of %s %w", ip, host, errTargetNotAllowed)
+	}
+	if !isPublicIP(ip) {
+		return fmt.Errorf("address %s of %s %w: loopback, link-local and private addresses must be allowlisted", ip, host, errTargetNotAllowed)
+	}
+	return nil
+}
+
+// isPublicIP reports whether an address lies outside the loopback, link-local, private,
+// unspecified and multicast ranges
+func isPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		// 100.64.0.0/10 is shared address space of carrier-grade NAT and cluster networks
+		if ip[0] == 100 && ip[1]&0xc0 == 64 {
+			return false
+		}
+		if ip[0] == 0 {
+			return false
+		}
+	}
+	return !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsPrivate() && !ip.IsUnspecified() && !ip.IsMulticast() && !ip.IsInterfaceLocalMulticast()
+}
+
+// dialContext resolves the host of a connection, checks every address it resolved to and connects
+// to a checked address, so a name cannot be rebound to another address between check and dial
+func (p *targetPolicy) dialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, resolved := range addrs {
+			if err := p.allowIP(host, resolved.IP); err != nil {
+				return nil, err
+			}
+		}
+
+		var lastErr error
+		for _, resolved := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(resolved.IP.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no addresses found for %s", host)
+		}
+		return nil, lastErr
+	}
+}
+
+// checkRedirect applies the policy to every redirect before it is followed
+func (p *targetPolicy) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxTestRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxTestRedirects)
+	}
+	return p.checkURL(req.URL)
+}
+
+// checkURL rejects URLs that are not http or https or whose host the policy does not allow
+func (p *targetPolicy) checkURL(target *url.URL) error {
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return fmt.Errorf("scheme %s %w", target.Scheme, errTargetNotAllowed)
+	}
+	return p.allowHost(target.Hostname())
+}
+
+// client returns an HTTP client that only reaches targets the policy allows. It ignores proxy
+// settings, since connections through a proxy would bypass the check of the resolved address.
+func (p *targetPolicy) client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           p.dialContext(dialer),
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     transport,
+		CheckRedirect: p.checkRedirect,
+	}
+}
@@ -0,0 +1,422 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/auth"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/rbac"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+// ErrProjectDeleted is returned when restoring an entity or endpoint of a project that is in the trash
+var ErrProjectDeleted = apperror.Conflict("the project is deleted, restore it first")
+
+// ErrEntityDeleted is returned when restoring an endpoint of an entity that is in the trash
+var ErrEntityDeleted = apperror.Conflict("the entity is deleted, restore it first")
+
+// TrashService lists, restores and purges soft deleted projects, entities and endpoints
+type TrashService struct {
+	projectRepo  *repository.ProjectRepository
+	entityRepo   *repository.EntityRepository
+	endpointRepo *repository.EndpointRepository
+	uow          *repository.UnitOfWork
+	audit        *AuditService
+	guard        *projectGuard
+}
+
+func NewTrashService(
+	projectRepo *repository.ProjectRepository,
+	entityRepo *repository.EntityRepository,
+	endpointRepo *repository.EndpointRepository,
+	uow *repository.UnitOfWork,
+	audit *AuditService,
+	authz rbac.Authorizer,
+) *TrashService {
+	return &TrashService{
+		projectRepo:  projectRepo,
+		entityRepo:   entityRepo,
+		endpointRepo: endpointRepo,
+		uow:          uow,
+		audit:        audit,
+		guard:        newProjectGuard(authz, projectRepo),
+	}
+}
+
+// GetDeletedProjects lists the deleted projects the current user has a role on, most recently deleted first
+func (s *TrashService) GetDeletedProjects(ctx context.Context) ([]models.Project, error) {
+	projects, err := s.projectRepo.GetDeleted()
+	if err != nil {
+		return nil, err
+	}
+
+	visible := []models.Project{}
+	for _, project := range projects {
+		err := s.guard.require(ctx, project.UUID, models.ProjectRoleViewer)
+		if errors.Is(err, apperror.ErrForbidden) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		visible = append(visible, project)
+	}
+
+	return visible, nil
+}
+
+// GetProjectTrash lists the entities and endpoints of a project deleted on their own. Those deleted
+// with their entity come back when it is restored.
+func (s *TrashService) GetProjectTrash(ctx context.Context, projectUUID string) (*models.ProjectTrash, error) {
+	if err := s.guard.require(ctx, projectUUID, models.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := s.entityRepo.GetDeletedByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := s.endpointRepo.GetDeletedByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ProjectTrash{Entities: entities, Endpoints: endpoints}, nil
+}
+
+// RestoreProject brings back a deleted project and the rows deleted with it, unless another project
+// of its team took its namespace
+func (s *TrashService) RestoreProject(ctx context.Context, uuid string) (*models.Project, error) {
+	if err := s.guard.require(ctx, uuid, models.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.projectRepo.GetDeletedByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	taken, err := s.projectRepo.NamespaceExists(deleted.TeamID, deleted.Namespace, deleted.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, apperror.Conflict(fmt.Sprintf("namespace %q is used by another project of the team", deleted.Namespace))
+	}
+
+	var project *models.Project
+	actor := auth.Actor(ctx)
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Projects().Restore(uuid, actor); err != nil {
+			return err
+		}
+		if err := tx.Entities().RestoreByDeletionID(uuid, actor); err != nil {
+			return err
+		}
+		if err := tx.Endpoints().RestoreByDeletionID(uuid, actor); err != nil {
+			return err
+		}
+		if err := tx.GitRepositories().RestoreByDeletionID(uuid, actor); err != nil {
+			return err
+		}
+		if err := tx.Snapshots().RestoreByDeletionID(uuid, actor); err != nil {
+			return err
+		}
+		restored, err := tx.Projects().GetByUUID(uuid)
+		if err != nil {
+			return err
+		}
+		project = restored
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceProject, project.UUID, models.AuditActionRestore, deleted, project)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// PurgeProject permanently removes a deleted project and everything inside it
+func (s *TrashService) PurgeProject(ctx context.Context, uuid string) error {
+	if err := s.guard.require(ctx, uuid, models.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	project, err := s.projectRepo.GetDeletedByUUID(uuid)
+	if err != nil {
+		return err
+	}
+
+	return s.purgeProject(ctx, project)
+}
+
+func (s *TrashService) purgeProject(ctx context.Context, project *models.Project) error {
+	return s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Projects().Purge(project.UUID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceProject, project.UUID, models.AuditActionPurge, project, nil)
+	})
+}
+
+// RestoreEntity brings back a deleted entity and the endpoints deleted with it, unless a live entity
+// of its project took its name or table or a live endpoint took one of their routes
+func (s *TrashService) RestoreEntity(ctx context.Context, uuid string) (*models.Entity, error) {
+	deleted, err := s.entityRepo.GetDeletedByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.liveProject(ctx, deleted.ProjectID, models.ProjectRoleDeveloper)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := s.entityRepo.GetByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEntityConflicts(deleted, entities); err != nil {
+		return nil, err
+	}
+
+	cascaded, err := s.endpointRepo.GetByDeletionID(uuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRoutes(project, cascaded); err != nil {
+		return nil, err
+	}
+
+	var entity *models.Entity
+	actor := auth.Actor(ctx)
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Entities().Restore(uuid, actor); err != nil {
+			return err
+		}
+		if err := tx.Endpoints().RestoreByDeletionID(uuid, actor); err != nil {
+			return err
+		}
+		restored, err := tx.Entities().GetByUUID(uuid)
+		if err != nil {
+			return err
+		}
+		entity = restored
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEntity, entity.UUID, models.AuditActionRestore, deleted, entity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+// PurgeEntity permanently removes a deleted entity and its endpoints
+func (s *TrashService) PurgeEntity(ctx context.Context, uuid string) error {
+	entity, err := s.entityRepo.GetDeletedByUUID(uuid)
+	if err != nil {
+		return err
+	}
+
+	project, err := s.guard.requireID(ctx, entity.ProjectID, models.ProjectRoleMaintainer)
+	if err != nil {
+		return err
+	}
+
+	return s.purgeEntity(ctx, project, entity)
+}
+
+func (s *TrashService) purgeEntity(ctx context.Context, project *models.Project, entity *models.Entity) error {
+	return s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Entities().Purge(entity.UUID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEntity, entity.UUID, models.AuditActionPurge, entity, nil)
+	})
+}
+
+// RestoreEndpoint brings back a deleted endpoint unless a live endpoint of its project took its route
+func (s *TrashService) RestoreEndpoint(ctx context.Context, uuid string) (*models.Endpoint, error) {
+	deleted, err := s.endpointRepo.GetDeletedByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.liveProject(ctx, deleted.ProjectID, models.ProjectRoleDeveloper)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.entityRepo.GetByID(deleted.EntityID); errors.Is(err, apperror.ErrNotFound) {
+		return nil, ErrEntityDeleted
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkRoutes(project, []models.Endpoint{*deleted}); err != nil {
+		return nil, err
+	}
+
+	var endpoint *models.Endpoint
+	err = s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Endpoints().Restore(uuid, auth.Actor(ctx)); err != nil {
+			return err
+		}
+		restored, err := tx.Endpoints().GetByUUID(uuid)
+		if err != nil {
+			return err
+		}
+		endpoint = restored
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEndpoint, endpoint.UUID, models.AuditActionRestore, deleted, endpoint)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+// PurgeEndpoint permanently removes a deleted endpoint
+func (s *TrashService) PurgeEndpoint(ctx context.Context, uuid string) error {
+	endpoint, err := s.endpointRepo.GetDeletedByUUID(uuid)
+	if err != nil {
+		return err
+	}
+
+	project, err := s.guard.requireID(ctx, endpoint.ProjectID, models.ProjectRoleMaintainer)
+	if err != nil {
+		return err
+	}
+
+	return s.purgeEndpoint(ctx, project, endpoint)
+}
+
+func (s *TrashService) purgeEndpoint(ctx context.Context, project *models.Project, endpoint *models.Endpoint) error {
+	return s.uow.Do(ctx, func(tx *repository.Tx) error {
+		if err := tx.Endpoints().Purge(endpoint.UUID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, tx, project.UUID, models.AuditResourceEndpoint, endpoint.UUID, models.AuditActionPurge, endpoint, nil)
+	})
+}
+
+// checkRoutes returns a conflict when the route of a deleted endpoint collides with a live endpoint
+// of the project
+func (s *TrashService) checkRoutes(project *models.Project, deleted []models.Endpoint) error {
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	endpoints, err := s.endpointRepo.GetByProjectID(project.ID)
+	if err != nil {
+		return err
+	}
+	for i := range deleted {
+		if err := checkRouteConflicts(&deleted[i], endpoints); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// liveProject checks the role of the current user on the project of a deleted resource, which
+// must not be deleted itself
+func (s *TrashService) liveProject(ctx context.Context, projectID int64, required string) (*models.Project, error) {
+	project, err := s.guard.requireID(ctx, projectID, required)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil, ErrProjectDeleted
+	}
+	return project, err
+}
+
+// PurgeExpired permanently removes the resources deleted before a time. Endpoints and entities go
+// first so those inside an expired project are not counted twice.
+func (s *TrashService) PurgeExpired(ctx context.Context, before time.Time) (*models.PurgeResult, error) {
+	result := &models.PurgeResult{}
+	projects := make(map[int64]*models.Project)
+	project := func(id int64) (*models.Project, error) {
+		if p, ok := projects[id]; ok {
+			return p, nil
+		}
+		p, err := s.projectRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		projects[id] = p
+		return p, nil
+	}
+
+	endpoints, err := s.endpointRepo.GetDeletedBefore(before)
+	if err != nil {
+		return result, err
+	}
+	for i := range endpoints {
+		p, err := project(endpoints[i].ProjectID)
+		if err != nil {
+			return result, err
+		}
+		if err := s.purgeEndpoint(ctx, p, &endpoints[i]); err != nil {
+			return result, err
+		}
+		result.Endpoints++
+	}
+
+	entities, err := s.entityRepo.GetDeletedBefore(before)
+	if err != nil {
+		return result, err
+	}
+	for i := range entities {
+		p, err := project(entities[i].ProjectID)
+		if err != nil {
+			return result, err
+		}
+		if err := s.purgeEntity(ctx, p, &entities[i]); err != nil {
+			return result, err
+		}
+		result.Entities++
+	}
+
+	deleted, err := s.projectRepo.GetDeletedBefore(before)
+	if err != nil {
+		return result, err
+	}
+	for i := range deleted {
+		if err := s.purgeProject(ctx, &deleted[i]); err != nil {
+			return result, err
+		}
+		result.Projects++
+	}
+
+	return result, nil
+}
+
+// RunPurger purges the resources deleted longer than retention ago every interval until ctx is done
+func (s *TrashService) RunPurger(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.PurgeExpired(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("trash: purge failed: %v", err)
+		} else if *result != (models.PurgeResult{}) {
+			log.Printf("trash: purged %d projects, %d entities and %d endpoints", result.Projects, result.Entities, result.Endpoints)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/yourusername/lambra/internal/apperror"
+	"github.com/yourusername/lambra/internal/models"
+	"github.com/yourusername/lambra/internal/repository"
+)
+
+func newTestTrashService(t *testing.T) (*TrashService, sqlmock.Sqlmock) {
+	db, mock := newTestDB(t)
+	return NewTrashService(
+		repository.NewProjectRepository(db),
+		repository.NewEntityRepository(db),
+		repository.NewEndpointRepository(db),
+		repository.NewUnitOfWork(db),
+		&AuditService{},
+		roleAuthorizer{role: models.ProjectRoleOwner},
+	), mock
+}
+
+var (
+	projectRows  = []string{"id", "uuid", "name", "namespace", "team_id", "version"}
+	entityRows   = []string{"id", "uuid", "project_id", "name", "table_name", "version"}
+	endpointRows = []string{"id", "uuid", "entity_id", "project_id", "name", "path", "method", "version"}
+)
+
+// expectLiveProject answers the lookup of the live project with internal ID 1
+func expectLiveProject(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`FROM projects\s+WHERE id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(projectRows).AddRow(1, "prj-1", "Shop", "shop", 7, 2))
+}
+
+func TestTrashService_RestoreProjectNamespaceTaken(t *testing.T) {
+	svc, mock := newTestTrashService(t)
+	mock.ExpectQuery(`FROM projects\s+WHERE uuid = \? AND deleted_at IS NOT NULL`).WithArgs("prj-1").
+		WillReturnRows(sqlmock.NewRows(projectRows).AddRow(1, "prj-1", "Shop", "shop", 7, 2))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM projects`).WithArgs("shop", sqlmock.AnyArg(), "prj-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	// Nothing is restored: the conflict is found before the transaction starts
+	_, err := svc.RestoreProject(userContext("alice"), "prj-1")
+	if !errors.Is(err, apperror.ErrConflict) {
+		t.Errorf("RestoreProject() error = %v, want a conflict", err)
+	}
+}
+
+func TestTrashService_RestoreEntityConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		live      [2]string
+		wantError error
+	}{
+		{"name taken", [2]string{"Order", "purchase_orders"}, apperror.ErrConflict},
+		{"table taken", [2]string{"Purchase", "ORDERS"}, apperror.ErrConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mock := newTestTrashService(t)
+			mock.ExpectQuery(`FROM entities e\s+JOIN projects p ON p.id = e.project_id\s+WHERE e.uuid = \?`).WithArgs("ent-1").
+				WillReturnRows(sqlmock.NewRows(entityRows).AddRow(10, "ent-1", 1, "Order", "orders", 4))
+			expectLiveProject(mock)
+			mock.ExpectQuery(`FROM entities\s+WHERE project_id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+				WillReturnRows(sqlmock.NewRows(entityRows).AddRow(11, "ent-2", 1, tt.live[0], tt.live[1], 1))
+
+			_, err := svc.RestoreEntity(userContext("alice"), "ent-1")
+			if !errors.Is(err, tt.wantError) {
+				t.Errorf("RestoreEntity() error = %v, want %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestTrashService_RestoreEntityOfDeletedProject(t *testing.T) {
+	svc, mock := newTestTrashService(t)
+	mock.ExpectQuery(`FROM entities e\s+JOIN projects p ON p.id = e.project_id\s+WHERE e.uuid = \?`).WithArgs("ent-1").
+		WillReturnRows(sqlmock.NewRows(entityRows).AddRow(10, "ent-1", 1, "Order", "orders", 4))
+	mock.ExpectQuery(`FROM projects\s+WHERE id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(projectRows))
+
+	if _, err := svc.RestoreEntity(userContext("alice"), "ent-1"); !errors.Is(err, ErrProjectDeleted) {
+		t.Errorf("RestoreEntity() error = %v, want %v", err, ErrProjectDeleted)
+	}
+}
+
+func TestTrashService_RestoreEntity(t *testing.T) {
+	svc, mock := newTestTrashService(t)
+	mock.ExpectQuery(`FROM entities e\s+JOIN projects p ON p.id = e.project_id\s+WHERE e.uuid = \?`).WithArgs("ent-1").
+		WillReturnRows(sqlmock.NewRows(entityRows).AddRow(10, "ent-1", 1, "Order", "orders", 4))
+	expectLiveProject(mock)
+	mock.ExpectQuery(`FROM entities\s+WHERE project_id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(entityRows).AddRow(11, "ent-2", 1, "Customer", "customers", 1))
+	mock.ExpectQuery(`FROM endpoints ep.*WHERE ep.deletion_id = \?`).WithArgs("ent-1", "ent-1").
+		WillReturnRows(sqlmock.NewRows(endpointRows))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE entities`).WithArgs("alice", "ent-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE endpoints`).WithArgs("alice", "ent-1", "ent-1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`FROM entities\s+WHERE uuid = \? AND deleted_at IS NULL`).WithArgs("ent-1").
+		WillReturnRows(sqlmock.NewRows(entityRows).AddRow(10, "ent-1", 1, "Order", "orders", 5))
+	mock.ExpectExec(`INSERT INTO audit_logs`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	entity, err := svc.RestoreEntity(userContext("alice"), "ent-1")
+	if err != nil {
+		t.Fatalf("RestoreEntity() error = %v", err)
+	}
+	if entity.UUID != "ent-1" || entity.Version != 5 {
+		t.Errorf("RestoreEntity() = %s at version %d, want ent-1 at version 5", entity.UUID, entity.Version)
+	}
+}
+
+func TestTrashService_RestoreEndpointConflicts(t *testing.T) {
+	expectDeletedEndpoint := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`FROM endpoints ep.*WHERE ep.uuid = \?`).WithArgs("ep-1").
+			WillReturnRows(sqlmock.NewRows(endpointRows).AddRow(20, "ep-1", 10, 1, "Get order", "/orders/:id", "GET", 2))
+		expectLiveProject(mock)
+	}
+
+	t.Run("entity deleted", func(t *testing.T) {
+		svc, mock := newTestTrashService(t)
+		expectDeletedEndpoint(mock)
+		mock.ExpectQuery(`FROM entities\s+WHERE id = \? AND deleted_at IS NULL`).WithArgs(int64(10)).
+			WillReturnRows(sqlmock.NewRows(entityRows))
+
+		if _, err := svc.RestoreEndpoint(userContext("alice"), "ep-1"); !errors.Is(err, ErrEntityDeleted) {
+			t.Errorf("RestoreEndpoint() error = %v, want %v", err, ErrEntityDeleted)
+		}
+	})
+
+	t.Run("route taken", func(t *testing.T) {
+		svc, mock := newTestTrashService(t)
+		expectDeletedEndpoint(mock)
+		mock.ExpectQuery(`FROM entities\s+WHERE id = \? AND deleted_at IS NULL`).WithArgs(int64(10)).
+			WillReturnRows(sqlmock.NewRows(entityRows).AddRow(10, "ent-1", 1, "Order", "orders", 4))
+		mock.ExpectQuery(`FROM endpoints\s+WHERE project_id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows(endpointRows).AddRow(21, "ep-2", 10, 1, "Find order", "/orders/:orderId", "GET", 1))
+
+		if _, err := svc.RestoreEndpoint(userContext("alice"), "ep-1"); !errors.Is(err, apperror.ErrConflict) {
+			t.Errorf("RestoreEndpoint() error = %v, want a conflict", err)
+		}
+	})
+}
+
+// Restoring a project brings back the rows its delete marked with the project UUID. Children
+// deleted on their own earlier carry their own UUID as deletion ID and stay in the trash.
+func TestTrashService_RestoreProjectBringsBackItsDeletion(t *testing.T) {
+	svc, mock := newTestTrashService(t)
+	mock.ExpectQuery(`FROM projects\s+WHERE uuid = \? AND deleted_at IS NOT NULL`).WithArgs("prj-1").
+		WillReturnRows(sqlmock.NewRows(projectRows).AddRow(1, "prj-1", "Shop", "shop", 7, 2))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM projects`).WithArgs("shop", sqlmock.AnyArg(), "prj-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE projects\s+SET deleted_by = NULL.*WHERE uuid = \?`).WithArgs("alice", "prj-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE entities\s+SET deleted_by = NULL.*WHERE deletion_id = \? AND uuid <> \?`).
+		WithArgs("alice", "prj-1", "prj-1").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE endpoints\s+SET deleted_by = NULL.*WHERE deletion_id = \? AND uuid <> \?`).
+		WithArgs("alice", "prj-1", "prj-1").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(`UPDATE git_repositories\s+SET deleted_by = NULL.*WHERE deletion_id = \?`).
+		WithArgs("alice", "prj-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE generation_snapshots\s+SET deleted_by = NULL.*WHERE deletion_id = \?`).
+		WithArgs("alice", "prj-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM projects\s+WHERE uuid = \? AND deleted_at IS NULL`).WithArgs("prj-1").
+		WillReturnRows(sqlmock.NewRows(projectRows).AddRow(1, "prj-1", "Shop", "shop", 7, 3))
+	mock.ExpectExec(`INSERT INTO audit_logs`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	project, err := svc.RestoreProject(userContext("alice"), "prj-1")
+	if err != nil {
+		t.Fatalf("RestoreProject() error = %v", err)
+	}
+	if project.UUID != "prj-1" || project.Version != 3 {
+		t.Errorf("RestoreProject() = %s at version %d, want prj-1 at version 3", project.UUID, project.Version)
+	}
+}
+
+// Restoring an entity brings back the endpoints deleted with it, never one deleted on its own
+func TestTrashService_RestoreEntityBringsBackItsEndpoints(t *testing.T) {
+	svc, mock := newTestTrashService(t)
+	mock.ExpectQuery(`FROM entities e\s+JOIN projects p ON p.id = e.project_id\s+WHERE e.uuid = \?`).WithArgs("ent-1").
+		WillReturnRows(sqlmock.NewRows(entityRows).AddRow(10, "ent-1", 1, "Order", "orders", 4))
+	expectLiveProject(mock)
+	mock.ExpectQuery(`FROM entities\s+WHERE project_id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(entityRows))
+	mock.ExpectQuery(`FROM endpoints ep.*WHERE ep.deletion_id = \? AND ep.uuid <> \? AND ep.deleted_at IS NOT NULL`).
+		WithArgs("ent-1", "ent-1").
+		WillReturnRows(sqlmock.NewRows(endpointRows).AddRow(20, "ep-1", 10, 1, "Get order", "/orders/:id", "GET", 2))
+	mock.ExpectQuery(`FROM endpoints\s+WHERE project_id = \? AND deleted_at IS NULL`).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(endpointRows))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE entities\s+SET deleted_by = NULL.*WHERE uuid = \?`).WithArgs("alice", "ent-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE endpoints\s+SET deleted_by = NULL.*WHERE deletion_id = \? AND uuid <> \?`).
+		WithArgs("alice", "ent-1", "ent-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM entities\s+WHERE uuid = \? AND deleted_at IS NULL`).WithArgs("ent-1").
+		WillReturnRows(sqlmock.NewRows(entityRows).AddRow(10, "ent-1", 1, "Order", "orders", 5))
+	mock.ExpectExec(`INSERT INTO audit_logs`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if _, err := svc.RestoreEntity(userContext("alice"), "ent-1"); err != nil {
+		t.Fatalf("RestoreEntity() error = %v", err)
+	}
+}
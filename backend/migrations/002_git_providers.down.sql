@@ -0,0 +1,6 @@
+-- Rollback: remove git provider columns
+
+ALTER TABLE git_repositories
+    DROP INDEX idx_provider_remote_id,
+    DROP COLUMN repo_path,
+    DROP COLUMN provider;
@@ -0,0 +1,6 @@
+-- Support multiple git hosting providers (GitLab, GitHub, Gitea)
+
+ALTER TABLE git_repositories
+    ADD COLUMN provider VARCHAR(20) NOT NULL DEFAULT 'gitlab' AFTER project_id,
+    ADD COLUMN repo_path VARCHAR(255) NOT NULL DEFAULT '' AFTER repo_name,
+    ADD INDEX idx_provider_remote_id (provider, gitlab_repo_id);
@@ -0,0 +1,6 @@
+-- Rollback: remove project deployment settings
+
+ALTER TABLE projects
+    DROP COLUMN environments,
+    DROP COLUMN port,
+    DROP COLUMN image;
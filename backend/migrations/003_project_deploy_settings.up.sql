@@ -0,0 +1,10 @@
+-- Deployment settings used to render Kubernetes manifests per environment
+
+ALTER TABLE projects
+    ADD COLUMN image VARCHAR(255) NOT NULL DEFAULT '' AFTER namespace,
+    ADD COLUMN port INT NOT NULL DEFAULT 8080 AFTER image,
+    ADD COLUMN environments JSON NULL AFTER port;
+
+UPDATE projects SET environments = JSON_OBJECT() WHERE environments IS NULL;
+
+ALTER TABLE projects MODIFY COLUMN environments JSON NOT NULL;
@@ -0,0 +1,3 @@
+-- Rollback: drop project configuration variables
+
+DROP TABLE IF EXISTS project_config_vars;
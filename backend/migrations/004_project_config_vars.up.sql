@@ -0,0 +1,22 @@
+-- Per-environment configuration variables and encrypted secrets of a project
+
+CREATE TABLE IF NOT EXISTS project_config_vars (
+    id BIGINT NOT NULL PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    project_id BIGINT NOT NULL,
+    environment VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    value TEXT NOT NULL,
+    is_secret BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by VARCHAR(100),
+    updated_by VARCHAR(100),
+    deleted_by VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    UNIQUE KEY uk_project_env_name (project_id, environment, name),
+    INDEX idx_uuid (uuid),
+    INDEX idx_project_environment (project_id, environment),
+    INDEX idx_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+-- Rollback: drop users and API tokens
+
+DROP TABLE IF EXISTS api_tokens;
+DROP TABLE IF EXISTS users;
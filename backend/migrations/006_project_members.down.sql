@@ -0,0 +1,3 @@
+-- Rollback: drop project members
+
+DROP TABLE IF EXISTS project_members;
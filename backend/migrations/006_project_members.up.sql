@@ -0,0 +1,32 @@
+-- Project membership with roles for local role-based access control
+
+CREATE TABLE IF NOT EXISTS project_members (
+    id BIGINT NOT NULL PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    project_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    created_by VARCHAR(100),
+    updated_by VARCHAR(100),
+    deleted_by VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uk_project_user (project_id, user_id),
+    INDEX idx_uuid (uuid),
+    INDEX idx_user_id (user_id),
+    INDEX idx_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Projects created before roles existed are owned by their creator when the creator has an account.
+-- Projects created by "system" have no owner until an RBAC_ADMINS user grants one.
+INSERT INTO project_members (id, uuid, project_id, user_id, role, created_by, created_at, updated_at)
+SELECT CONV(SUBSTRING(REPLACE(owners.uuid, '-', ''), 1, 15), 16, 10), owners.uuid, owners.project_id, owners.user_id,
+       'owner', 'system', NOW(), NOW()
+FROM (
+    SELECT UUID() AS uuid, p.id AS project_id, u.id AS user_id
+    FROM projects p
+    JOIN users u ON u.username = p.created_by AND u.deleted_at IS NULL
+) owners;
@@ -0,0 +1,3 @@
+-- Rollback: drop audit logs
+
+DROP TABLE IF EXISTS audit_logs;
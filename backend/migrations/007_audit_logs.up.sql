@@ -0,0 +1,18 @@
+-- Append-only audit trail of changes to project definitions and templates
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGINT NOT NULL PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    project_uuid CHAR(36) NULL,
+    resource_type VARCHAR(30) NOT NULL,
+    resource_id CHAR(36) NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    request_id VARCHAR(64),
+    changes JSON NOT NULL,
+    created_at TIMESTAMP(6) DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_project_created (project_uuid, created_at),
+    INDEX idx_resource (resource_type, resource_id, created_at),
+    INDEX idx_actor (actor),
+    INDEX idx_request_id (request_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,11 @@
+-- Rollback: detach projects from teams and drop teams
+
+ALTER TABLE projects
+    DROP FOREIGN KEY fk_projects_team,
+    DROP INDEX idx_team_namespace,
+    DROP COLUMN team_id,
+    DROP COLUMN module_path,
+    DROP COLUMN dialect;
+
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
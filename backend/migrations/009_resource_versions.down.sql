@@ -0,0 +1,6 @@
+-- Rollback: drop resource versions
+
+ALTER TABLE templates DROP COLUMN version;
+ALTER TABLE endpoints DROP COLUMN version;
+ALTER TABLE entities DROP COLUMN version;
+ALTER TABLE projects DROP COLUMN version;
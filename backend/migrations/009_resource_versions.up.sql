@@ -0,0 +1,6 @@
+-- Versions for optimistic concurrency; every update increments the version the ETag is derived from
+
+ALTER TABLE projects ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE entities ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE endpoints ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE templates ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
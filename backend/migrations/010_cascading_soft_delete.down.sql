@@ -0,0 +1,7 @@
+-- Rollback: drop deletion ids
+
+ALTER TABLE generation_snapshots DROP INDEX idx_deletion_id, DROP COLUMN deletion_id;
+ALTER TABLE git_repositories DROP INDEX idx_deletion_id, DROP COLUMN deletion_id;
+ALTER TABLE endpoints DROP INDEX idx_deletion_id, DROP COLUMN deletion_id;
+ALTER TABLE entities DROP INDEX idx_deletion_id, DROP COLUMN deletion_id;
+ALTER TABLE projects DROP INDEX idx_deletion_id, DROP COLUMN deletion_id;
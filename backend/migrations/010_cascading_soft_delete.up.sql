@@ -0,0 +1,15 @@
+-- Soft deletes cascade through a project hierarchy. deletion_id is the UUID of the resource whose
+-- delete removed a row, so a restore brings back exactly the rows deleted with it
+
+ALTER TABLE projects ADD COLUMN deletion_id CHAR(36) NULL, ADD INDEX idx_deletion_id (deletion_id);
+ALTER TABLE entities ADD COLUMN deletion_id CHAR(36) NULL, ADD INDEX idx_deletion_id (deletion_id);
+ALTER TABLE endpoints ADD COLUMN deletion_id CHAR(36) NULL, ADD INDEX idx_deletion_id (deletion_id);
+ALTER TABLE git_repositories ADD COLUMN deletion_id CHAR(36) NULL, ADD INDEX idx_deletion_id (deletion_id);
+ALTER TABLE generation_snapshots ADD COLUMN deletion_id CHAR(36) NULL, ADD INDEX idx_deletion_id (deletion_id);
+
+-- Rows deleted before cascading were each deleted on their own
+UPDATE projects SET deletion_id = uuid WHERE deleted_at IS NOT NULL;
+UPDATE entities SET deletion_id = uuid WHERE deleted_at IS NOT NULL;
+UPDATE endpoints SET deletion_id = uuid WHERE deleted_at IS NOT NULL;
+UPDATE git_repositories SET deletion_id = uuid WHERE deleted_at IS NOT NULL;
+UPDATE generation_snapshots SET deletion_id = uuid WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Rollback: drop endpoint test runs
+
+DROP TABLE IF EXISTS endpoint_runs;
@@ -0,0 +1,17 @@
+-- History of endpoint test runs against running services
+
+CREATE TABLE IF NOT EXISTS endpoint_runs (
+    id BIGINT NOT NULL PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    endpoint_id BIGINT NOT NULL,
+    environment VARCHAR(20) NULL,
+    method VARCHAR(10) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    request JSON NOT NULL,
+    response JSON NOT NULL,
+    created_by VARCHAR(100),
+    created_at TIMESTAMP(6) DEFAULT CURRENT_TIMESTAMP(6),
+    FOREIGN KEY (endpoint_id) REFERENCES endpoints(id) ON DELETE CASCADE,
+    INDEX idx_endpoint_created (endpoint_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,3 @@
+-- Rollback: drop endpoint mock configuration
+
+ALTER TABLE endpoints DROP COLUMN mock;
@@ -0,0 +1,3 @@
+-- Per-endpoint mock server configuration: fixed example response and error scenarios
+
+ALTER TABLE endpoints ADD COLUMN mock JSON NULL AFTER response_schema;
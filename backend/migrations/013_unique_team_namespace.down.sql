@@ -0,0 +1,7 @@
+-- Rollback: go back to a plain index on team and namespace. Namespaces renamed by the up migration keep their new name.
+
+ALTER TABLE projects
+    DROP INDEX uk_team_namespace,
+    DROP COLUMN live_namespace,
+    DROP COLUMN team_key,
+    ADD INDEX idx_team_namespace (team_id, namespace);
@@ -0,0 +1,26 @@
+-- Namespaces of live projects are unique within a team, and among projects without a team.
+-- Teamless projects share team_key 0, and soft deleted projects drop out of the key so a
+-- namespace can be reused while the old project sits in the trash.
+
+-- Earlier versions allowed duplicates: the oldest live project keeps the namespace and the others
+-- get the last 8 characters of their UUID as suffix, so the unique key can be added
+UPDATE projects p
+JOIN (
+    SELECT id
+    FROM (
+        SELECT id, ROW_NUMBER() OVER (PARTITION BY COALESCE(team_id, 0), namespace ORDER BY created_at, id) AS position
+        FROM projects
+        WHERE deleted_at IS NULL
+    ) ranked
+    WHERE position > 1
+) duplicates ON duplicates.id = p.id
+SET p.namespace = CONCAT(LEFT(p.namespace, 41), '-', RIGHT(p.uuid, 8)),
+    p.version = p.version + 1,
+    p.updated_by = 'system',
+    p.updated_at = NOW();
+
+ALTER TABLE projects
+    ADD COLUMN team_key BIGINT AS (COALESCE(team_id, 0)) STORED,
+    ADD COLUMN live_namespace VARCHAR(50) AS (IF(deleted_at IS NULL, namespace, NULL)) STORED,
+    DROP INDEX idx_team_namespace,
+    ADD UNIQUE KEY uk_team_namespace (team_key, live_namespace);
@@ -0,0 +1,6 @@
+-- Rollback: templates are shared by all teams again
+
+ALTER TABLE templates
+    DROP FOREIGN KEY fk_templates_team,
+    DROP INDEX idx_team_id,
+    DROP COLUMN team_id;
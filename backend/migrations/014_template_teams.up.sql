@@ -0,0 +1,7 @@
+-- Templates belong to the team whose owners manage them. Templates created before teams have
+-- no team and are read-only.
+
+ALTER TABLE templates
+    ADD COLUMN team_id BIGINT NULL AFTER is_default,
+    ADD CONSTRAINT fk_templates_team FOREIGN KEY (team_id) REFERENCES teams(id),
+    ADD INDEX idx_team_id (team_id);
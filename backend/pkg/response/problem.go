@@ -0,0 +1,50 @@
+package response
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"` // Invalid fields of validation problems
+}
+
+// FieldError describes why one field of a request is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// WriteProblem aborts the request with a problem of a status for the requested path
+func WriteProblem(c *gin.Context, statusCode int, detail string, fields []FieldError) {
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(statusCode),
+		Status:   statusCode,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Errors:   fields,
+	}
+
+	// Set before rendering, the JSON renderer keeps an existing content type
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(statusCode, problem)
+}
+
+func PreconditionFailed(c *gin.Context, message string) {
+	Error(c, http.StatusPreconditionFailed, message, nil)
+}
+
+func ValidationFailed(c *gin.Context, message string, fields []FieldError) {
+	WriteProblem(c, http.StatusBadRequest, message, fields)
+}
@@ -52,17 +52,14 @@ func SuccessWithPagination(c *gin.Context, data interface{}, pagination Paginati
 	})
 }
 
+// Error writes an RFC 7807 problem with message as detail, followed by err when set
 func Error(c *gin.Context, statusCode int, message string, err error) {
-	errorMsg := ""
+	detail := message
 	if err != nil {
-		errorMsg = err.Error()
+		detail = message + ": " + err.Error()
 	}
 
-	c.JSON(statusCode, Response{
-		Success: false,
-		Message: message,
-		Error:   errorMsg,
-	})
+	WriteProblem(c, statusCode, detail, nil)
 }
 
 func BadRequest(c *gin.Context, message string, err error) {
@@ -84,3 +81,7 @@ func Unauthorized(c *gin.Context, message string) {
 func Forbidden(c *gin.Context, message string) {
 	Error(c, http.StatusForbidden, message, nil)
 }
+
+func Conflict(c *gin.Context, message string, err error) {
+	Error(c, http.StatusConflict, message, err)
+}
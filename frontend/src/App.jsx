@@ -1,7 +1,9 @@
 import React from 'react'
 import { Routes, Route } from 'react-router-dom'
 import { Layout } from './components/layout/Layout'
+import { RequireAuth } from './components/layout/RequireAuth'
 import { Dashboard } from './pages/Dashboard'
+import { Login } from './pages/Login'
 import { ServiceList } from './pages/ServiceList'
 import { ServiceNew } from './pages/ServiceNew'
 import { ServiceDetail } from './pages/ServiceDetail'
@@ -9,16 +11,26 @@ import { Settings } from './pages/Settings'
 
 function App() {
   return (
-    <Layout>
-      <Routes>
-        <Route path="/" element={<Dashboard />} />
-        <Route path="/services" element={<ServiceList />} />
-        <Route path="/services/new" element={<ServiceNew />} />
-        <Route path="/services/:id" element={<ServiceDetail />} />
-        <Route path="/settings" element={<Settings />} />
-        {/* More routes will be added in next phases */}
-      </Routes>
-    </Layout>
+    <Routes>
+      <Route path="/login" element={<Login />} />
+      <Route
+        path="*"
+        element={
+          <RequireAuth>
+            <Layout>
+              <Routes>
+                <Route path="/" element={<Dashboard />} />
+                <Route path="/services" element={<ServiceList />} />
+                <Route path="/services/new" element={<ServiceNew />} />
+                <Route path="/services/:id" element={<ServiceDetail />} />
+                <Route path="/settings" element={<Settings />} />
+                {/* More routes will be added in next phases */}
+              </Routes>
+            </Layout>
+          </RequireAuth>
+        }
+      />
+    </Routes>
   )
 }
 
@@ -0,0 +1,21 @@
+import axios from './axios'
+
+const TOKEN_KEY = 'token'
+
+export const getToken = () => localStorage.getItem(TOKEN_KEY)
+
+export const setToken = (token) => localStorage.setItem(TOKEN_KEY, token)
+
+export const clearToken = () => localStorage.removeItem(TOKEN_KEY)
+
+export const authApi = {
+  // Log in with username or email and password, returns the JWT
+  login: async (data) => {
+    return axios.post('/auth/login', data)
+  },
+
+  // Get current user
+  me: async () => {
+    return axios.get('/auth/me')
+  },
+}
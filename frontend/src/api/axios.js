@@ -2,6 +2,13 @@ import axios from 'axios'
 
 const baseURL = import.meta.env.VITE_API_BASE_URL || 'http://localhost:8080/api/v1'
 
+// ETag of every resource last read or written, keyed by its URL. PUT and DELETE send it back in
+// If-Match so the server rejects the change with 412 when someone else changed the resource since.
+const etags = new Map()
+
+// ifMatch returns the If-Match header of a resource version, for callers holding it from a list
+export const ifMatch = (version) => (version === undefined ? {} : { 'If-Match': `"${version}"` })
+
 const axiosInstance = axios.create({
   baseURL,
   timeout: 30000,
@@ -18,6 +25,11 @@ axiosInstance.interceptors.request.use(
     if (token) {
       config.headers.Authorization = `Bearer ${token}`
     }
+
+    const method = config.method?.toLowerCase()
+    if ((method === 'put' || method === 'delete') && !config.headers['If-Match'] && etags.has(config.url)) {
+      config.headers['If-Match'] = etags.get(config.url)
+    }
     return config
   },
   (error) => {
@@ -28,10 +40,28 @@ axiosInstance.interceptors.request.use(
 // Response interceptor
 axiosInstance.interceptors.response.use(
   (response) => {
+    const { url, method } = response.config
+    if (method?.toLowerCase() === 'delete') {
+      etags.delete(url)
+    } else if (response.headers.etag) {
+      etags.set(url, response.headers.etag)
+    }
     return response.data
   },
   (error) => {
-    const message = error.response?.data?.message || error.message || 'Something went wrong'
+    // The resource changed since it was read; the caller reloads it before trying again
+    if (error.response?.status === 412) {
+      etags.delete(error.config?.url)
+      error.message = 'This item was changed by someone else. Reload it and try again.'
+    }
+
+    // Expired or revoked tokens send the user back to the login page
+    if (error.response?.status === 401 && window.location.pathname !== '/login') {
+      localStorage.removeItem('token')
+      window.location.assign('/login')
+    }
+
+    const message = error.response?.data?.detail || error.message || 'Something went wrong'
     console.error('API Error:', message)
     return Promise.reject(error)
   }
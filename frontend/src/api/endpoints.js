@@ -1,14 +1,14 @@
-import axios from './axios'
+import axios, { ifMatch } from './axios'
 
 export const endpointsApi = {
   // Get all endpoints for a project
-  getByProject: async (projectId) => {
-    return axios.get(`/projects/${projectId}/endpoints`)
+  getByProject: async (projectId, params = {}) => {
+    return axios.get(`/projects/${projectId}/endpoints`, { params: { limit: 100, ...params } })
   },
 
   // Get all endpoints for an entity
-  getByEntity: async (entityId) => {
-    return axios.get(`/entities/${entityId}/endpoints`)
+  getByEntity: async (entityId, params = {}) => {
+    return axios.get(`/entities/${entityId}/endpoints`, { params: { limit: 100, ...params } })
   },
 
   // Get endpoint by ID
@@ -22,12 +22,12 @@ export const endpointsApi = {
   },
 
   // Update endpoint
-  update: async (id, data) => {
-    return axios.put(`/endpoints/${id}`, data)
+  update: async (id, data, version) => {
+    return axios.put(`/endpoints/${id}`, data, { headers: ifMatch(version) })
   },
 
   // Delete endpoint
-  delete: async (id) => {
-    return axios.delete(`/endpoints/${id}`)
+  delete: async (id, version) => {
+    return axios.delete(`/endpoints/${id}`, { headers: ifMatch(version) })
   },
 }
@@ -1,9 +1,9 @@
-import axios from './axios'
+import axios, { ifMatch } from './axios'
 
 export const entitiesApi = {
   // Get all entities for a project
-  getByProject: async (projectId) => {
-    return axios.get(`/projects/${projectId}/entities`)
+  getByProject: async (projectId, params = {}) => {
+    return axios.get(`/projects/${projectId}/entities`, { params: { limit: 100, ...params } })
   },
 
   // Get entity by ID
@@ -17,12 +17,12 @@ export const entitiesApi = {
   },
 
   // Update entity
-  update: async (id, data) => {
-    return axios.put(`/entities/${id}`, data)
+  update: async (id, data, version) => {
+    return axios.put(`/entities/${id}`, data, { headers: ifMatch(version) })
   },
 
   // Delete entity
-  delete: async (id) => {
-    return axios.delete(`/entities/${id}`)
+  delete: async (id, version) => {
+    return axios.delete(`/entities/${id}`, { headers: ifMatch(version) })
   },
 }
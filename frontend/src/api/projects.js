@@ -1,10 +1,10 @@
-import axios from './axios'
+import axios, { ifMatch } from './axios'
 
 export const projectsApi = {
   // Get all projects
   getAll: async (params = {}) => {
-    const { page = 1, limit = 20 } = params
-    return axios.get('/projects', { params: { page, limit } })
+    const { page = 1, limit = 20, ...filters } = params
+    return axios.get('/projects', { params: { page, limit, ...filters } })
   },
 
   // Get project by ID
@@ -18,13 +18,13 @@ export const projectsApi = {
   },
 
   // Update project
-  update: async (id, data) => {
-    return axios.put(`/projects/${id}`, data)
+  update: async (id, data, version) => {
+    return axios.put(`/projects/${id}`, data, { headers: ifMatch(version) })
   },
 
   // Delete project
-  delete: async (id) => {
-    return axios.delete(`/projects/${id}`)
+  delete: async (id, version) => {
+    return axios.delete(`/projects/${id}`, { headers: ifMatch(version) })
   },
 
   // Generate service
@@ -0,0 +1,14 @@
+import React from 'react'
+import { Navigate, useLocation } from 'react-router-dom'
+import { getToken } from '../../api/auth'
+
+// RequireAuth sends visitors without a token to the login page, returning them afterwards
+export const RequireAuth = ({ children }) => {
+  const location = useLocation()
+
+  if (!getToken()) {
+    return <Navigate to="/login" state={{ from: location }} replace />
+  }
+
+  return children
+}
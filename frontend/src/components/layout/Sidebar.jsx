@@ -1,7 +1,9 @@
 import React from 'react'
-import { Link, useLocation } from 'react-router-dom'
-import { Home, List, Settings } from 'lucide-react'
+import { Link, useLocation, useNavigate } from 'react-router-dom'
+import { useQueryClient } from '@tanstack/react-query'
+import { Home, List, LogOut, Settings } from 'lucide-react'
 import clsx from 'clsx'
+import { clearToken } from '../../api/auth'
 
 const navigation = [
   { name: 'Dashboard', href: '/', icon: Home },
@@ -11,6 +13,14 @@ const navigation = [
 
 export const Sidebar = () => {
   const location = useLocation()
+  const navigate = useNavigate()
+  const queryClient = useQueryClient()
+
+  const handleLogout = () => {
+    clearToken()
+    queryClient.clear()
+    navigate('/login')
+  }
 
   return (
     <div className="flex flex-col w-64 bg-white border-r border-gray-200">
@@ -42,6 +52,13 @@ export const Sidebar = () => {
       </nav>
 
       <div className="p-4 border-t border-gray-200">
+        <button
+          onClick={handleLogout}
+          className="flex items-center w-full px-4 py-3 mb-3 text-sm font-medium text-gray-700 rounded-lg transition-colors hover:bg-gray-50"
+        >
+          <LogOut className="w-5 h-5 mr-3" />
+          Log Out
+        </button>
         <div className="text-xs text-gray-500">
           <p>Lambra Platform</p>
           <p className="mt-1">v1.0.0</p>
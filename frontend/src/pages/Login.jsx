@@ -0,0 +1,111 @@
+import React, { useState } from 'react'
+import { Navigate, useLocation, useNavigate } from 'react-router-dom'
+import { authApi, getToken, setToken } from '../api/auth'
+import { ErrorAlert } from '../components/shared/ErrorAlert'
+import { LoadingSpinner } from '../components/shared/LoadingSpinner'
+
+export const Login = () => {
+  const navigate = useNavigate()
+  const location = useLocation()
+  const from = location.state?.from?.pathname || '/'
+
+  const [formData, setFormData] = useState({
+    username: '',
+    password: '',
+  })
+  const [error, setError] = useState(null)
+  const [submitting, setSubmitting] = useState(false)
+
+  if (getToken()) {
+    return <Navigate to={from} replace />
+  }
+
+  const handleSubmit = async (e) => {
+    e.preventDefault()
+    setError(null)
+    setSubmitting(true)
+
+    try {
+      const response = await authApi.login(formData)
+      setToken(response.data.token)
+      navigate(from, { replace: true })
+    } catch (err) {
+      setError(err.response?.data?.detail || err.message || 'Failed to log in')
+      setSubmitting(false)
+    }
+  }
+
+  const handleChange = (e) => {
+    const { name, value } = e.target
+    setFormData(prev => ({
+      ...prev,
+      [name]: value
+    }))
+  }
+
+  return (
+    <div className="flex items-center justify-center min-h-screen bg-gray-50">
+      <div className="w-full max-w-md">
+        <div className="mb-8 text-center">
+          <h1 className="text-3xl font-bold text-primary-600">Lambra</h1>
+          <p className="mt-1 text-gray-600">
+            Sign in to manage your services
+          </p>
+        </div>
+
+        {error && <ErrorAlert message={error} onClose={() => setError(null)} />}
+
+        <form onSubmit={handleSubmit} className="card">
+          <div className="space-y-6">
+            <div>
+              <label htmlFor="username" className="label">
+                Username or Email
+              </label>
+              <input
+                type="text"
+                id="username"
+                name="username"
+                value={formData.username}
+                onChange={handleChange}
+                className="input"
+                autoComplete="username"
+                required
+              />
+            </div>
+
+            <div>
+              <label htmlFor="password" className="label">
+                Password
+              </label>
+              <input
+                type="password"
+                id="password"
+                name="password"
+                value={formData.password}
+                onChange={handleChange}
+                className="input"
+                autoComplete="current-password"
+                required
+              />
+            </div>
+          </div>
+
+          <button
+            type="submit"
+            disabled={submitting}
+            className="btn btn-primary w-full mt-8 disabled:opacity-50 disabled:cursor-not-allowed"
+          >
+            {submitting ? (
+              <>
+                <LoadingSpinner size="sm" className="inline mr-2" />
+                Signing in...
+              </>
+            ) : (
+              'Sign In'
+            )}
+          </button>
+        </form>
+      </div>
+    </div>
+  )
+}
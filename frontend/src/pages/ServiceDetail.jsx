@@ -53,20 +53,30 @@ export const ServiceDetail = () => {
 
   // Delete entity mutation
   const deleteEntityMutation = useMutation({
-    mutationFn: (entityId) => entitiesApi.delete(entityId),
+    mutationFn: (entity) => entitiesApi.delete(entity.id, entity.version),
     onSuccess: () => {
       queryClient.invalidateQueries(['entities', id])
     },
+    onError: (error) => handleStale(error, ['entities', id]),
   })
 
   // Delete endpoint mutation
   const deleteEndpointMutation = useMutation({
-    mutationFn: (endpointId) => endpointsApi.delete(endpointId),
+    mutationFn: (endpoint) => endpointsApi.delete(endpoint.id, endpoint.version),
     onSuccess: () => {
       queryClient.invalidateQueries(['entity-endpoints'])
     },
+    onError: (error) => handleStale(error, ['entity-endpoints']),
   })
 
+  // A 412 means the item changed since it was listed: reload it so the user sees the current state
+  const handleStale = (error, queryKey) => {
+    if (error.response?.status === 412) {
+      window.alert(error.message)
+      queryClient.invalidateQueries(queryKey)
+    }
+  }
+
   if (projectLoading) {
     return <LoadingSpinner size="lg" className="mt-20" />
   }
@@ -78,15 +88,15 @@ export const ServiceDetail = () => {
   const project = projectData?.data
   const entities = entitiesData?.data || []
 
-  const handleDeleteEntity = (entityId) => {
+  const handleDeleteEntity = (entity) => {
     if (window.confirm('Are you sure you want to delete this entity?')) {
-      deleteEntityMutation.mutate(entityId)
+      deleteEntityMutation.mutate(entity)
     }
   }
 
-  const handleDeleteEndpoint = (endpointId) => {
+  const handleDeleteEndpoint = (endpoint) => {
     if (window.confirm('Are you sure you want to delete this endpoint?')) {
-      deleteEndpointMutation.mutate(endpointId)
+      deleteEndpointMutation.mutate(endpoint)
     }
   }
 
@@ -163,7 +173,7 @@ export const ServiceDetail = () => {
                   setSelectedEntity(entity)
                   setShowEndpointModal(true)
                 }}
-                onDelete={() => handleDeleteEntity(entity.id)}
+                onDelete={() => handleDeleteEntity(entity)}
                 onDeleteEndpoint={handleDeleteEndpoint}
               />
             ))}
@@ -294,7 +304,7 @@ const EntityCard = ({ entity, onAddEndpoint, onDelete, onDeleteEndpoint }) => {
                   </span>
                 </div>
                 <button
-                  onClick={() => onDeleteEndpoint(endpoint.id)}
+                  onClick={() => onDeleteEndpoint(endpoint)}
                   className="text-red-600 hover:text-red-700"
                 >
                   <Trash2 className="w-3 h-3" />
@@ -23,7 +23,7 @@ export const ServiceNew = () => {
       await createProject.mutateAsync(formData)
       navigate('/services')
     } catch (err) {
-      setError(err.response?.data?.error || err.message || 'Failed to create service')
+      setError(err.response?.data?.detail || err.message || 'Failed to create service')
     }
   }
 